@@ -76,6 +76,9 @@ func (rc *nseRegistryCache) AddNetworkServiceEndpoint(entry *registry.NSERegistr
 
 func (rc *nseRegistryCache) addNetworkServiceEndpoint(entry *registry.NSERegistration) (*registry.NSERegistration, error) {
 	logrus.Infof("Start adding network service endpoint %v", entry)
+	if err := entry.GetNetworkService().IsValid(); err != nil {
+		return nil, errors.Wrap(err, "invalid network service")
+	}
 	if endpoint, ok := rc.endpoints[entry.NetworkServiceEndpoint.Name]; ok {
 		return nil, errors.Errorf("network service endpoint with name %s already exists: old: %v; new: %v", endpoint.NetworkServiceEndpoint.Name, endpoint, entry)
 	}
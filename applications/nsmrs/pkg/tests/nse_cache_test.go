@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/networkservicemesh/networkservicemesh/applications/nsmrs/pkg/serviceregistryserver"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 )
 
 func TestNSMRSCacheAdd(t *testing.T) {
@@ -76,6 +77,23 @@ func TestNSMRSCacheNSECollision(t *testing.T) {
 	g.Expect(err.Error()).To(ContainSubstring("already exists"))
 }
 
+func TestNSMRSCacheInvalidNetworkService(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := serviceregistryserver.NewNSERegistryCache()
+	nse := newTestNse("nse1", "ns1")
+	nse.NetworkService.Matches = []*registry.Match{
+		{
+			Routes: []*registry.Destination{
+				{DestinationSelector: map[string]string{"app": "{{index . \"app\""}},
+			},
+		},
+	}
+	_, err := cache.AddNetworkServiceEndpoint(nse)
+	g.Expect(err.Error()).To(ContainSubstring("invalid network service"))
+	g.Expect(len(cache.GetEndpoints("ns1"))).To(Equal(0))
+}
+
 func TestNSMRSCacheNSCollision(t *testing.T) {
 	g := NewWithT(t)
 
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gre - constants and helper methods for GRE remote mechanism
+package gre
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+)
+
+const (
+	// MECHANISM type string
+	MECHANISM = "GRE"
+
+	// Mechanism parameters
+
+	// SrcIP - source IP
+	SrcIP = common.SrcIP
+	// DstIP - destitiona IP
+	DstIP = common.DstIP
+	// SrcOriginalIP - original src IP
+	SrcOriginalIP = common.SrcOriginalIP
+	// DstExternalIP - external destination ip
+	DstExternalIP = common.DstExternalIP
+	// Key - GRE key distinguishing tunnels between the same pair of hosts
+	Key = "key"
+	// Mode - type of the tunnel interface, ModeGRETAP if not set
+	Mode = "mode"
+
+	// ModeGRE - layer 3 GRE tunnel
+	ModeGRE = "gre"
+	// ModeGRETAP - layer 2 GRE tunnel carrying ethernet frames
+	ModeGRETAP = "gretap"
+)
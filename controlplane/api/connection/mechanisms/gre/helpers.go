@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gre
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+)
+
+// Mechanism - a gre mechanism utility wrapper
+type Mechanism interface {
+	// SrcIP -  src ip
+	SrcIP() (string, error)
+	// DstIP - dst ip
+	DstIP() (string, error)
+	// Key - gre key
+	Key() (uint32, error)
+	// Mode - type of the tunnel interface
+	Mode() (string, error)
+}
+
+type mechanism struct {
+	*connection.Mechanism
+}
+
+// ToMechanism - convert unified mechanism to useful wrapper
+func ToMechanism(m *connection.Mechanism) Mechanism {
+	if m.Type == MECHANISM {
+		return &mechanism{
+			m,
+		}
+	}
+	return nil
+}
+
+func (m *mechanism) SrcIP() (string, error) {
+	return common.GetSrcIP(m.Mechanism)
+}
+
+func (m *mechanism) DstIP() (string, error) {
+	return common.GetDstIP(m.Mechanism)
+}
+
+// Key returns the Key parameter of the Mechanism
+func (m *mechanism) Key() (uint32, error) {
+	if m == nil {
+		return 0, errors.New("mechanism cannot be nil")
+	}
+
+	if m.GetParameters() == nil {
+		return 0, errors.Errorf("mechanism.Parameters cannot be nil: %v", m)
+	}
+
+	greKey, ok := m.Parameters[Key]
+	if !ok {
+		return 0, errors.Errorf("mechanism.Type %s requires mechanism.Parameters[%s]", m.GetType(), Key)
+	}
+
+	key, err := strconv.ParseUint(greKey, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "mechanism.Parameters[%s] must be a valid 32-bit unsigned integer, instead was: %s: %v", Key, greKey, m)
+	}
+
+	return uint32(key), nil
+}
+
+// Mode returns the Mode parameter of the Mechanism, ModeGRETAP if it is not set
+func (m *mechanism) Mode() (string, error) {
+	if m == nil {
+		return "", errors.New("mechanism cannot be nil")
+	}
+
+	switch mode := m.GetParameters()[Mode]; mode {
+	case "":
+		return ModeGRETAP, nil
+	case ModeGRE, ModeGRETAP:
+		return mode, nil
+	default:
+		return "", errors.Errorf("mechanism.Parameters[%s] must be %s or %s, instead was: %s", Mode, ModeGRE, ModeGRETAP, mode)
+	}
+}
@@ -44,10 +44,10 @@ const (
 	DstPort = "dst_port"
 	// SrcPublicKey - Source public key
 	SrcPublicKey = "src_public_key"
-	// SrcPrivateKey - Source private key
+	// SrcPrivateKey - Source private key, deprecated: private keys are kept by forwarders and must not be set
 	SrcPrivateKey = "src_private_key"
 	// DstPublicKey - Destination public key
 	DstPublicKey = "dst_public_key"
-	// DstPrivateKey - Source private key
+	// DstPrivateKey - Destination private key, deprecated: private keys are kept by forwarders and must not be set
 	DstPrivateKey = "dst_private_key"
 )
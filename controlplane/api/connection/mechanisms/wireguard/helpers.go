@@ -35,10 +35,6 @@ type Mechanism interface {
 	SrcPublicKey() (string, error)
 	// DstPublicKey - destination public key
 	DstPublicKey() (string, error)
-	// SrcPrivateKey - source private key
-	SrcPrivateKey() (string, error)
-	// dstPrivateKey - destination private key
-	DstPrivateKey() (string, error)
 	// SrcPort - Source interface listening port
 	SrcPort() (int, error)
 	// SrcPort - Destination interface listening port
@@ -94,16 +90,6 @@ func (m *mechanism) DstPublicKey() (string, error) {
 	return m.stringValue(DstPublicKey)
 }
 
-// SrcPrivateKey returns the SrcPrivateKey parameter of the Mechanism
-func (m *mechanism) SrcPrivateKey() (string, error) {
-	return m.stringValue(SrcPrivateKey)
-}
-
-// DstPrivateKey returns the DstPrivateKey parameter of the Mechanism
-func (m *mechanism) DstPrivateKey() (string, error) {
-	return m.stringValue(DstPrivateKey)
-}
-
 // SrcPort - Source interface listening port
 func (m *mechanism) SrcPort() (int, error) {
 	srcPortStr, err := m.stringValue(SrcPort)
@@ -142,3 +128,18 @@ func AssignPort(connID string) string {
 	}
 	return strconv.FormatUint(BasePort+id, 10)
 }
+
+// WithoutPrivateKeys returns the connection without private key parameters of its Wireguard mechanism, the
+// connection is cloned only if it has such parameters
+func WithoutPrivateKeys(c *connection.Connection) *connection.Connection {
+	parameters := c.GetMechanism().GetParameters()
+	_, hasSrc := parameters[SrcPrivateKey]
+	_, hasDst := parameters[DstPrivateKey]
+	if c.GetMechanism().GetType() != MECHANISM || (!hasSrc && !hasDst) {
+		return c
+	}
+	rv := c.Clone()
+	delete(rv.GetMechanism().GetParameters(), SrcPrivateKey)
+	delete(rv.GetMechanism().GetParameters(), DstPrivateKey)
+	return rv
+}
@@ -0,0 +1,40 @@
+package wireguard
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+)
+
+func TestWithoutPrivateKeys(t *testing.T) {
+	c := &connection.Connection{
+		Id: "1",
+		Mechanism: &connection.Mechanism{
+			Type: MECHANISM,
+			Parameters: map[string]string{
+				SrcPublicKey:  "src-public",
+				SrcPrivateKey: "src-private",
+				DstPublicKey:  "dst-public",
+				DstPrivateKey: "dst-private",
+			},
+		},
+	}
+
+	stripped := WithoutPrivateKeys(c)
+	parameters := stripped.GetMechanism().GetParameters()
+	if _, ok := parameters[SrcPrivateKey]; ok {
+		t.Errorf("source private key is not stripped: %v", parameters)
+	}
+	if _, ok := parameters[DstPrivateKey]; ok {
+		t.Errorf("destination private key is not stripped: %v", parameters)
+	}
+	if parameters[SrcPublicKey] != "src-public" || parameters[DstPublicKey] != "dst-public" {
+		t.Errorf("public keys are expected to be kept: %v", parameters)
+	}
+	if c.GetMechanism().GetParameters()[SrcPrivateKey] != "src-private" {
+		t.Errorf("original connection is not expected to be changed: %v", c)
+	}
+	if WithoutPrivateKeys(stripped) != stripped {
+		t.Errorf("connection without private keys is not expected to be cloned")
+	}
+}
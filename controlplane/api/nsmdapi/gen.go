@@ -19,4 +19,4 @@
 
 package nsmdapi
 
-//go:generate bash -c "protoc -I . nsmd.proto --go_out=plugins=grpc:. --proto_path=../"
+//go:generate bash -c "protoc -I . nsmd.proto --go_out=plugins=grpc:. --proto_path=$GOPATH/src/ --proto_path=$GOPATH/pkg/mod/ --proto_path=$( go list -f '{{ .Dir }}' -m github.com/golang/protobuf )"
@@ -7,6 +7,12 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	connection "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	crossconnect "github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	registry "github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,6 +30,133 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// HealEventType is a stage of connection healing.
+type HealEventType int32
+
+const (
+	HealEventType_HEAL_STARTED        HealEventType = 0
+	HealEventType_HEAL_ATTEMPT_FAILED HealEventType = 1
+	HealEventType_HEAL_SUCCEEDED      HealEventType = 2
+	HealEventType_HEAL_FAILED         HealEventType = 3
+)
+
+var HealEventType_name = map[int32]string{
+	0: "HEAL_STARTED",
+	1: "HEAL_ATTEMPT_FAILED",
+	2: "HEAL_SUCCEEDED",
+	3: "HEAL_FAILED",
+}
+
+var HealEventType_value = map[string]int32{
+	"HEAL_STARTED":        0,
+	"HEAL_ATTEMPT_FAILED": 1,
+	"HEAL_SUCCEEDED":      2,
+	"HEAL_FAILED":         3,
+}
+
+func (x HealEventType) String() string {
+	return proto.EnumName(HealEventType_name, int32(x))
+}
+
+func (HealEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{0}
+}
+
+// ModelEventType is a kind of model change, INITIAL_STATE_TRANSFER event holds the whole model at the time the
+// monitoring is started.
+type ModelEventType int32
+
+const (
+	ModelEventType_MODEL_INITIAL_STATE_TRANSFER ModelEventType = 0
+	ModelEventType_MODEL_ADD                    ModelEventType = 1
+	ModelEventType_MODEL_UPDATE                 ModelEventType = 2
+	ModelEventType_MODEL_DELETE                 ModelEventType = 3
+)
+
+var ModelEventType_name = map[int32]string{
+	0: "MODEL_INITIAL_STATE_TRANSFER",
+	1: "MODEL_ADD",
+	2: "MODEL_UPDATE",
+	3: "MODEL_DELETE",
+}
+
+var ModelEventType_value = map[string]int32{
+	"MODEL_INITIAL_STATE_TRANSFER": 0,
+	"MODEL_ADD":                    1,
+	"MODEL_UPDATE":                 2,
+	"MODEL_DELETE":                 3,
+}
+
+func (x ModelEventType) String() string {
+	return proto.EnumName(ModelEventType_name, int32(x))
+}
+
+func (ModelEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{1}
+}
+
+type ClientConnectionState int32
+
+const (
+	ClientConnectionState_CLIENT_CONNECTION_READY         ClientConnectionState = 0
+	ClientConnectionState_CLIENT_CONNECTION_REQUESTING    ClientConnectionState = 1
+	ClientConnectionState_CLIENT_CONNECTION_BROKEN        ClientConnectionState = 2
+	ClientConnectionState_CLIENT_CONNECTION_HEALING_BEGIN ClientConnectionState = 3
+	ClientConnectionState_CLIENT_CONNECTION_HEALING       ClientConnectionState = 4
+	ClientConnectionState_CLIENT_CONNECTION_CLOSING       ClientConnectionState = 5
+)
+
+var ClientConnectionState_name = map[int32]string{
+	0: "CLIENT_CONNECTION_READY",
+	1: "CLIENT_CONNECTION_REQUESTING",
+	2: "CLIENT_CONNECTION_BROKEN",
+	3: "CLIENT_CONNECTION_HEALING_BEGIN",
+	4: "CLIENT_CONNECTION_HEALING",
+	5: "CLIENT_CONNECTION_CLOSING",
+}
+
+var ClientConnectionState_value = map[string]int32{
+	"CLIENT_CONNECTION_READY":         0,
+	"CLIENT_CONNECTION_REQUESTING":    1,
+	"CLIENT_CONNECTION_BROKEN":        2,
+	"CLIENT_CONNECTION_HEALING_BEGIN": 3,
+	"CLIENT_CONNECTION_HEALING":       4,
+	"CLIENT_CONNECTION_CLOSING":       5,
+}
+
+func (x ClientConnectionState) String() string {
+	return proto.EnumName(ClientConnectionState_name, int32(x))
+}
+
+func (ClientConnectionState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{2}
+}
+
+type ForwarderState int32
+
+const (
+	ForwarderState_FORWARDER_STATE_NONE  ForwarderState = 0
+	ForwarderState_FORWARDER_STATE_READY ForwarderState = 1
+)
+
+var ForwarderState_name = map[int32]string{
+	0: "FORWARDER_STATE_NONE",
+	1: "FORWARDER_STATE_READY",
+}
+
+var ForwarderState_value = map[string]int32{
+	"FORWARDER_STATE_NONE":  0,
+	"FORWARDER_STATE_READY": 1,
+}
+
+func (x ForwarderState) String() string {
+	return proto.EnumName(ForwarderState_name, int32(x))
+}
+
+func (ForwarderState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{3}
+}
+
 // ConnectionRequest is sent by a NSM client to build a connection with NSM.
 type ClientConnectionRequest struct {
 	Workspace            string   `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
@@ -280,187 +413,2090 @@ func (m *EnumConnectionReply) GetWorkspace() []string {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*ClientConnectionRequest)(nil), "nsmdapi.ClientConnectionRequest")
-	proto.RegisterType((*ClientConnectionReply)(nil), "nsmdapi.ClientConnectionReply")
-	proto.RegisterType((*DeleteConnectionRequest)(nil), "nsmdapi.DeleteConnectionRequest")
-	proto.RegisterType((*DeleteConnectionReply)(nil), "nsmdapi.DeleteConnectionReply")
-	proto.RegisterType((*EnumConnectionRequest)(nil), "nsmdapi.EnumConnectionRequest")
-	proto.RegisterType((*EnumConnectionReply)(nil), "nsmdapi.EnumConnectionReply")
+// ExplainEndpointSelectionRequest describes a hypothetical connection request, ignore_endpoints are
+// names of endpoints to be treated as already tried, as it happens during healing.
+type ExplainEndpointSelectionRequest struct {
+	Connection           *connection.Connection `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	IgnoreEndpoints      []string               `protobuf:"bytes,2,rep,name=ignore_endpoints,json=ignoreEndpoints,proto3" json:"ignore_endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func init() { proto.RegisterFile("nsmd.proto", fileDescriptor_084cb5dcc765b124) }
+func (m *ExplainEndpointSelectionRequest) Reset()         { *m = ExplainEndpointSelectionRequest{} }
+func (m *ExplainEndpointSelectionRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainEndpointSelectionRequest) ProtoMessage()    {}
+func (*ExplainEndpointSelectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{6}
+}
 
-var fileDescriptor_084cb5dcc765b124 = []byte{
-	// 288 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0x25, 0x6d, 0xbf, 0x4f, 0x7a, 0x45, 0x85, 0x91, 0x9a, 0x50, 0x8a, 0x84, 0xe0, 0xa2, 0xab,
-	0x2c, 0xec, 0xc2, 0xbd, 0x8d, 0x4b, 0xbb, 0x68, 0x76, 0xba, 0x8a, 0xe9, 0x05, 0x87, 0x26, 0x33,
-	0xe3, 0xcc, 0x54, 0xe9, 0x53, 0xf8, 0x6c, 0xbe, 0x91, 0xcc, 0x38, 0xd8, 0xfc, 0x16, 0xdc, 0x85,
-	0xf3, 0x97, 0x93, 0x73, 0x03, 0xc0, 0x54, 0xb9, 0x89, 0x85, 0xe4, 0x9a, 0x93, 0x13, 0xf3, 0x9c,
-	0x09, 0x1a, 0xdd, 0x81, 0xbf, 0x2c, 0x28, 0x32, 0xbd, 0xe4, 0x8c, 0x61, 0xae, 0x29, 0x67, 0x6b,
-	0x7c, 0xdb, 0xa1, 0xd2, 0x64, 0x06, 0xe3, 0x0f, 0x2e, 0xb7, 0x4a, 0x64, 0x39, 0x06, 0x5e, 0xe8,
-	0xcd, 0xc7, 0xeb, 0x03, 0x10, 0x7d, 0x79, 0x30, 0x69, 0x3b, 0x45, 0xb1, 0x3f, 0xee, 0x23, 0x21,
-	0x9c, 0xbe, 0x72, 0xa5, 0xef, 0x33, 0x85, 0x1b, 0x2a, 0x83, 0x81, 0xe5, 0xab, 0x10, 0xb9, 0x81,
-	0xb3, 0xdc, 0x06, 0x1b, 0x20, 0xa1, 0x32, 0x18, 0x5a, 0x4d, 0x1d, 0x24, 0x73, 0xb8, 0x60, 0xaa,
-	0x4c, 0x51, 0xbe, 0xa3, 0x4c, 0x79, 0xbe, 0x45, 0x1d, 0x8c, 0xac, 0xae, 0x09, 0x3b, 0xe5, 0x4f,
-	0x57, 0xa7, 0xfc, 0xf7, 0xab, 0xac, 0xc2, 0x66, 0x8c, 0x04, 0x0b, 0xd4, 0xf8, 0xd7, 0x31, 0x7c,
-	0x98, 0xb4, 0x8d, 0xa2, 0xd8, 0x1b, 0xe2, 0x81, 0xed, 0xca, 0x56, 0x5e, 0xb4, 0x80, 0xcb, 0x26,
-	0xd1, 0xb1, 0xdd, 0xb0, 0xf6, 0x9a, 0xdb, 0xcf, 0x01, 0x8c, 0x56, 0xe9, 0x63, 0x42, 0x9e, 0xc1,
-	0x77, 0x41, 0xcd, 0x13, 0x90, 0x30, 0x76, 0xa7, 0x8d, 0x7b, 0xee, 0x3a, 0xbd, 0x3e, 0xa2, 0x30,
-	0x1d, 0x56, 0x70, 0x5e, 0xaf, 0x46, 0x0e, 0x8e, 0xce, 0x8f, 0x99, 0xce, 0x7a, 0x79, 0x93, 0xf7,
-	0x04, 0x57, 0x6e, 0x9c, 0xfe, 0xae, 0x3d, 0xb3, 0x57, 0xba, 0x76, 0xee, 0xfb, 0xf2, 0xdf, 0xfe,
-	0xce, 0x8b, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4d, 0xad, 0x32, 0x4d, 0xdc, 0x02, 0x00, 0x00,
+func (m *ExplainEndpointSelectionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainEndpointSelectionRequest.Unmarshal(m, b)
+}
+func (m *ExplainEndpointSelectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainEndpointSelectionRequest.Marshal(b, m, deterministic)
+}
+func (m *ExplainEndpointSelectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainEndpointSelectionRequest.Merge(m, src)
+}
+func (m *ExplainEndpointSelectionRequest) XXX_Size() int {
+	return xxx_messageInfo_ExplainEndpointSelectionRequest.Size(m)
+}
+func (m *ExplainEndpointSelectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainEndpointSelectionRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_ExplainEndpointSelectionRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *ExplainEndpointSelectionRequest) GetConnection() *connection.Connection {
+	if m != nil {
+		return m.Connection
+	}
+	return nil
+}
 
-// NSMDClient is the client API for NSMD service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NSMDClient interface {
-	RequestClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ClientConnectionReply, error)
-	EnumConnection(ctx context.Context, in *EnumConnectionRequest, opts ...grpc.CallOption) (*EnumConnectionReply, error)
-	DeleteClientConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionReply, error)
+func (m *ExplainEndpointSelectionRequest) GetIgnoreEndpoints() []string {
+	if m != nil {
+		return m.IgnoreEndpoints
+	}
+	return nil
 }
 
-type nSMDClient struct {
-	cc grpc.ClientConnInterface
+// ExplainEndpointSelectionReply describes how an endpoint would be selected for the request, no connection is created.
+type ExplainEndpointSelectionReply struct {
+	NetworkService       *registry.NetworkService           `protobuf:"bytes,1,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	Endpoints            []*registry.NetworkServiceEndpoint `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	FilteredEndpoints    []*registry.NetworkServiceEndpoint `protobuf:"bytes,3,rep,name=filtered_endpoints,json=filteredEndpoints,proto3" json:"filtered_endpoints,omitempty"`
+	Matches              []*MatchExplanation                `protobuf:"bytes,4,rep,name=matches,proto3" json:"matches,omitempty"`
+	SelectedEndpoint     *registry.NetworkServiceEndpoint   `protobuf:"bytes,5,opt,name=selected_endpoint,json=selectedEndpoint,proto3" json:"selected_endpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
 }
 
-func NewNSMDClient(cc grpc.ClientConnInterface) NSMDClient {
-	return &nSMDClient{cc}
+func (m *ExplainEndpointSelectionReply) Reset()         { *m = ExplainEndpointSelectionReply{} }
+func (m *ExplainEndpointSelectionReply) String() string { return proto.CompactTextString(m) }
+func (*ExplainEndpointSelectionReply) ProtoMessage()    {}
+func (*ExplainEndpointSelectionReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{7}
 }
 
-func (c *nSMDClient) RequestClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ClientConnectionReply, error) {
-	out := new(ClientConnectionReply)
-	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/RequestClientConnection", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExplainEndpointSelectionReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainEndpointSelectionReply.Unmarshal(m, b)
+}
+func (m *ExplainEndpointSelectionReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainEndpointSelectionReply.Marshal(b, m, deterministic)
+}
+func (m *ExplainEndpointSelectionReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainEndpointSelectionReply.Merge(m, src)
+}
+func (m *ExplainEndpointSelectionReply) XXX_Size() int {
+	return xxx_messageInfo_ExplainEndpointSelectionReply.Size(m)
+}
+func (m *ExplainEndpointSelectionReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainEndpointSelectionReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainEndpointSelectionReply proto.InternalMessageInfo
+
+func (m *ExplainEndpointSelectionReply) GetNetworkService() *registry.NetworkService {
+	if m != nil {
+		return m.NetworkService
 	}
-	return out, nil
+	return nil
 }
 
-func (c *nSMDClient) EnumConnection(ctx context.Context, in *EnumConnectionRequest, opts ...grpc.CallOption) (*EnumConnectionReply, error) {
-	out := new(EnumConnectionReply)
-	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/EnumConnection", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExplainEndpointSelectionReply) GetEndpoints() []*registry.NetworkServiceEndpoint {
+	if m != nil {
+		return m.Endpoints
 	}
-	return out, nil
+	return nil
 }
 
-func (c *nSMDClient) DeleteClientConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionReply, error) {
-	out := new(DeleteConnectionReply)
-	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/DeleteClientConnection", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExplainEndpointSelectionReply) GetFilteredEndpoints() []*registry.NetworkServiceEndpoint {
+	if m != nil {
+		return m.FilteredEndpoints
 	}
-	return out, nil
+	return nil
 }
 
-// NSMDServer is the server API for NSMD service.
-type NSMDServer interface {
-	RequestClientConnection(context.Context, *ClientConnectionRequest) (*ClientConnectionReply, error)
-	EnumConnection(context.Context, *EnumConnectionRequest) (*EnumConnectionReply, error)
-	DeleteClientConnection(context.Context, *DeleteConnectionRequest) (*DeleteConnectionReply, error)
+func (m *ExplainEndpointSelectionReply) GetMatches() []*MatchExplanation {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
 }
 
-// UnimplementedNSMDServer can be embedded to have forward compatible implementations.
-type UnimplementedNSMDServer struct {
+func (m *ExplainEndpointSelectionReply) GetSelectedEndpoint() *registry.NetworkServiceEndpoint {
+	if m != nil {
+		return m.SelectedEndpoint
+	}
+	return nil
 }
 
-func (*UnimplementedNSMDServer) RequestClientConnection(ctx context.Context, req *ClientConnectionRequest) (*ClientConnectionReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RequestClientConnection not implemented")
+// MatchExplanation is a result of evaluating a single NetworkService match against the request labels.
+type MatchExplanation struct {
+	Match                *registry.Match     `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	SourceMatched        bool                `protobuf:"varint,2,opt,name=source_matched,json=sourceMatched,proto3" json:"source_matched,omitempty"`
+	Routes               []*RouteExplanation `protobuf:"bytes,3,rep,name=routes,proto3" json:"routes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
-func (*UnimplementedNSMDServer) EnumConnection(ctx context.Context, req *EnumConnectionRequest) (*EnumConnectionReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EnumConnection not implemented")
+
+func (m *MatchExplanation) Reset()         { *m = MatchExplanation{} }
+func (m *MatchExplanation) String() string { return proto.CompactTextString(m) }
+func (*MatchExplanation) ProtoMessage()    {}
+func (*MatchExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{8}
 }
-func (*UnimplementedNSMDServer) DeleteClientConnection(ctx context.Context, req *DeleteConnectionRequest) (*DeleteConnectionReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientConnection not implemented")
+
+func (m *MatchExplanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchExplanation.Unmarshal(m, b)
+}
+func (m *MatchExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchExplanation.Marshal(b, m, deterministic)
+}
+func (m *MatchExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchExplanation.Merge(m, src)
+}
+func (m *MatchExplanation) XXX_Size() int {
+	return xxx_messageInfo_MatchExplanation.Size(m)
+}
+func (m *MatchExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchExplanation.DiscardUnknown(m)
 }
 
-func RegisterNSMDServer(s *grpc.Server, srv NSMDServer) {
-	s.RegisterService(&_NSMD_serviceDesc, srv)
+var xxx_messageInfo_MatchExplanation proto.InternalMessageInfo
+
+func (m *MatchExplanation) GetMatch() *registry.Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
 }
 
-func _NSMD_RequestClientConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientConnectionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *MatchExplanation) GetSourceMatched() bool {
+	if m != nil {
+		return m.SourceMatched
 	}
-	if interceptor == nil {
-		return srv.(NSMDServer).RequestClientConnection(ctx, in)
+	return false
+}
+
+func (m *MatchExplanation) GetRoutes() []*RouteExplanation {
+	if m != nil {
+		return m.Routes
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/nsmdapi.NSMD/RequestClientConnection",
+	return nil
+}
+
+// RouteExplanation holds the endpoints passing the destination selector of a route.
+type RouteExplanation struct {
+	Destination          *registry.Destination              `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Endpoints            []*registry.NetworkServiceEndpoint `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *RouteExplanation) Reset()         { *m = RouteExplanation{} }
+func (m *RouteExplanation) String() string { return proto.CompactTextString(m) }
+func (*RouteExplanation) ProtoMessage()    {}
+func (*RouteExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{9}
+}
+
+func (m *RouteExplanation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteExplanation.Unmarshal(m, b)
+}
+func (m *RouteExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteExplanation.Marshal(b, m, deterministic)
+}
+func (m *RouteExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteExplanation.Merge(m, src)
+}
+func (m *RouteExplanation) XXX_Size() int {
+	return xxx_messageInfo_RouteExplanation.Size(m)
+}
+func (m *RouteExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteExplanation proto.InternalMessageInfo
+
+func (m *RouteExplanation) GetDestination() *registry.Destination {
+	if m != nil {
+		return m.Destination
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NSMDServer).RequestClientConnection(ctx, req.(*ClientConnectionRequest))
+	return nil
+}
+
+func (m *RouteExplanation) GetEndpoints() []*registry.NetworkServiceEndpoint {
+	if m != nil {
+		return m.Endpoints
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _NSMD_EnumConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnumConnectionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+// HealEvent is a single heal decision made by NSMD for a client connection, attempt is a number of failed heal attempts
+// so far.
+type HealEvent struct {
+	ConnectionId         string               `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	HealId               string               `protobuf:"bytes,2,opt,name=heal_id,json=healId,proto3" json:"heal_id,omitempty"`
+	HealState            string               `protobuf:"bytes,3,opt,name=heal_state,json=healState,proto3" json:"heal_state,omitempty"`
+	Type                 HealEventType        `protobuf:"varint,4,opt,name=type,proto3,enum=nsmdapi.HealEventType" json:"type,omitempty"`
+	NetworkService       string               `protobuf:"bytes,5,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	Endpoint             string               `protobuf:"bytes,6,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Attempt              int32                `protobuf:"varint,7,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Error                string               `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *HealEvent) Reset()         { *m = HealEvent{} }
+func (m *HealEvent) String() string { return proto.CompactTextString(m) }
+func (*HealEvent) ProtoMessage()    {}
+func (*HealEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{10}
+}
+
+func (m *HealEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealEvent.Unmarshal(m, b)
+}
+func (m *HealEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealEvent.Marshal(b, m, deterministic)
+}
+func (m *HealEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealEvent.Merge(m, src)
+}
+func (m *HealEvent) XXX_Size() int {
+	return xxx_messageInfo_HealEvent.Size(m)
+}
+func (m *HealEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealEvent proto.InternalMessageInfo
+
+func (m *HealEvent) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
 	}
-	if interceptor == nil {
-		return srv.(NSMDServer).EnumConnection(ctx, in)
+	return ""
+}
+
+func (m *HealEvent) GetHealId() string {
+	if m != nil {
+		return m.HealId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/nsmdapi.NSMD/EnumConnection",
+	return ""
+}
+
+func (m *HealEvent) GetHealState() string {
+	if m != nil {
+		return m.HealState
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NSMDServer).EnumConnection(ctx, req.(*EnumConnectionRequest))
+	return ""
+}
+
+func (m *HealEvent) GetType() HealEventType {
+	if m != nil {
+		return m.Type
 	}
-	return interceptor(ctx, in, info, handler)
+	return HealEventType_HEAL_STARTED
 }
 
-func _NSMD_DeleteClientConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteConnectionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *HealEvent) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
 	}
-	if interceptor == nil {
-		return srv.(NSMDServer).DeleteClientConnection(ctx, in)
+	return ""
+}
+
+func (m *HealEvent) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/nsmdapi.NSMD/DeleteClientConnection",
+	return ""
+}
+
+func (m *HealEvent) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NSMDServer).DeleteClientConnection(ctx, req.(*DeleteConnectionRequest))
+	return 0
+}
+
+func (m *HealEvent) GetError() string {
+	if m != nil {
+		return m.Error
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _NSMD_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "nsmdapi.NSMD",
-	HandlerType: (*NSMDServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "RequestClientConnection",
-			Handler:    _NSMD_RequestClientConnection_Handler,
-		},
-		{
-			MethodName: "EnumConnection",
-			Handler:    _NSMD_EnumConnection_Handler,
+func (m *HealEvent) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type HealHistoryRequest struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealHistoryRequest) Reset()         { *m = HealHistoryRequest{} }
+func (m *HealHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HealHistoryRequest) ProtoMessage()    {}
+func (*HealHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{11}
+}
+
+func (m *HealHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealHistoryRequest.Unmarshal(m, b)
+}
+func (m *HealHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HealHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealHistoryRequest.Merge(m, src)
+}
+func (m *HealHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HealHistoryRequest.Size(m)
+}
+func (m *HealHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealHistoryRequest proto.InternalMessageInfo
+
+func (m *HealHistoryRequest) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+type HealHistoryReply struct {
+	Events               []*HealEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *HealHistoryReply) Reset()         { *m = HealHistoryReply{} }
+func (m *HealHistoryReply) String() string { return proto.CompactTextString(m) }
+func (*HealHistoryReply) ProtoMessage()    {}
+func (*HealHistoryReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{12}
+}
+
+func (m *HealHistoryReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealHistoryReply.Unmarshal(m, b)
+}
+func (m *HealHistoryReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealHistoryReply.Marshal(b, m, deterministic)
+}
+func (m *HealHistoryReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealHistoryReply.Merge(m, src)
+}
+func (m *HealHistoryReply) XXX_Size() int {
+	return xxx_messageInfo_HealHistoryReply.Size(m)
+}
+func (m *HealHistoryReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealHistoryReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealHistoryReply proto.InternalMessageInfo
+
+func (m *HealHistoryReply) GetEvents() []*HealEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// MonitorHealEventsRequest selects heal events of the connection, empty connection_id selects events of all connections.
+type MonitorHealEventsRequest struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MonitorHealEventsRequest) Reset()         { *m = MonitorHealEventsRequest{} }
+func (m *MonitorHealEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorHealEventsRequest) ProtoMessage()    {}
+func (*MonitorHealEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{13}
+}
+
+func (m *MonitorHealEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorHealEventsRequest.Unmarshal(m, b)
+}
+func (m *MonitorHealEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorHealEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *MonitorHealEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorHealEventsRequest.Merge(m, src)
+}
+func (m *MonitorHealEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorHealEventsRequest.Size(m)
+}
+func (m *MonitorHealEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorHealEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorHealEventsRequest proto.InternalMessageInfo
+
+func (m *MonitorHealEventsRequest) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+type PropertiesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PropertiesRequest) Reset()         { *m = PropertiesRequest{} }
+func (m *PropertiesRequest) String() string { return proto.CompactTextString(m) }
+func (*PropertiesRequest) ProtoMessage()    {}
+func (*PropertiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{14}
+}
+
+func (m *PropertiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertiesRequest.Unmarshal(m, b)
+}
+func (m *PropertiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertiesRequest.Marshal(b, m, deterministic)
+}
+func (m *PropertiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertiesRequest.Merge(m, src)
+}
+func (m *PropertiesRequest) XXX_Size() int {
+	return xxx_messageInfo_PropertiesRequest.Size(m)
+}
+func (m *PropertiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertiesRequest proto.InternalMessageInfo
+
+type Backoff struct {
+	Initial              *duration.Duration `protobuf:"bytes,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	Multiplier           float64            `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	Max                  *duration.Duration `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+	Jitter               float64            `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Backoff) Reset()         { *m = Backoff{} }
+func (m *Backoff) String() string { return proto.CompactTextString(m) }
+func (*Backoff) ProtoMessage()    {}
+func (*Backoff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{15}
+}
+
+func (m *Backoff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backoff.Unmarshal(m, b)
+}
+func (m *Backoff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backoff.Marshal(b, m, deterministic)
+}
+func (m *Backoff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backoff.Merge(m, src)
+}
+func (m *Backoff) XXX_Size() int {
+	return xxx_messageInfo_Backoff.Size(m)
+}
+func (m *Backoff) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backoff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backoff proto.InternalMessageInfo
+
+func (m *Backoff) GetInitial() *duration.Duration {
+	if m != nil {
+		return m.Initial
+	}
+	return nil
+}
+
+func (m *Backoff) GetMultiplier() float64 {
+	if m != nil {
+		return m.Multiplier
+	}
+	return 0
+}
+
+func (m *Backoff) GetMax() *duration.Duration {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+func (m *Backoff) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+// PropertiesReply holds properties NSMD is currently running with, config_file is empty if properties are not loaded
+// from a config file.
+type PropertiesReply struct {
+	HealTimeout                     *duration.Duration `protobuf:"bytes,1,opt,name=heal_timeout,json=healTimeout,proto3" json:"heal_timeout,omitempty"`
+	CloseTimeout                    *duration.Duration `protobuf:"bytes,2,opt,name=close_timeout,json=closeTimeout,proto3" json:"close_timeout,omitempty"`
+	HealRequestTimeout              *duration.Duration `protobuf:"bytes,3,opt,name=heal_request_timeout,json=healRequestTimeout,proto3" json:"heal_request_timeout,omitempty"`
+	HealRequestConnectTimeout       *duration.Duration `protobuf:"bytes,4,opt,name=heal_request_connect_timeout,json=healRequestConnectTimeout,proto3" json:"heal_request_connect_timeout,omitempty"`
+	HealRetryCount                  int32              `protobuf:"varint,5,opt,name=heal_retry_count,json=healRetryCount,proto3" json:"heal_retry_count,omitempty"`
+	HealRetryBackoff                *Backoff           `protobuf:"bytes,6,opt,name=heal_retry_backoff,json=healRetryBackoff,proto3" json:"heal_retry_backoff,omitempty"`
+	HealRequestConnectCheckTimeout  *duration.Duration `protobuf:"bytes,7,opt,name=heal_request_connect_check_timeout,json=healRequestConnectCheckTimeout,proto3" json:"heal_request_connect_check_timeout,omitempty"`
+	HealForwarderTimeout            *duration.Duration `protobuf:"bytes,8,opt,name=heal_forwarder_timeout,json=healForwarderTimeout,proto3" json:"heal_forwarder_timeout,omitempty"`
+	HealDstNseWaitTimeout           *duration.Duration `protobuf:"bytes,9,opt,name=heal_dst_nse_wait_timeout,json=healDstNseWaitTimeout,proto3" json:"heal_dst_nse_wait_timeout,omitempty"`
+	HealDstNseWaitBackoff           *Backoff           `protobuf:"bytes,10,opt,name=heal_dst_nse_wait_backoff,json=healDstNseWaitBackoff,proto3" json:"heal_dst_nse_wait_backoff,omitempty"`
+	HealEnabled                     bool               `protobuf:"varint,11,opt,name=heal_enabled,json=healEnabled,proto3" json:"heal_enabled,omitempty"`
+	HealMakeBeforeBreak             bool               `protobuf:"varint,12,opt,name=heal_make_before_break,json=healMakeBeforeBreak,proto3" json:"heal_make_before_break,omitempty"`
+	HealHistorySize                 int32              `protobuf:"varint,13,opt,name=heal_history_size,json=healHistorySize,proto3" json:"heal_history_size,omitempty"`
+	HealHistoryConnections          int32              `protobuf:"varint,14,opt,name=heal_history_connections,json=healHistoryConnections,proto3" json:"heal_history_connections,omitempty"`
+	EndpointFailureThreshold        int32              `protobuf:"varint,15,opt,name=endpoint_failure_threshold,json=endpointFailureThreshold,proto3" json:"endpoint_failure_threshold,omitempty"`
+	EndpointFailureWindow           *duration.Duration `protobuf:"bytes,16,opt,name=endpoint_failure_window,json=endpointFailureWindow,proto3" json:"endpoint_failure_window,omitempty"`
+	EndpointQuarantine              *duration.Duration `protobuf:"bytes,17,opt,name=endpoint_quarantine,json=endpointQuarantine,proto3" json:"endpoint_quarantine,omitempty"`
+	ConfigFile                      string             `protobuf:"bytes,18,opt,name=config_file,json=configFile,proto3" json:"config_file,omitempty"`
+	ConnectionSnapshotInterval      *duration.Duration `protobuf:"bytes,19,opt,name=connection_snapshot_interval,json=connectionSnapshotInterval,proto3" json:"connection_snapshot_interval,omitempty"`
+	ForwarderPolicies               []*ForwarderPolicy `protobuf:"bytes,20,rep,name=forwarder_policies,json=forwarderPolicies,proto3" json:"forwarder_policies,omitempty"`
+	ForwarderHeartbeatInterval      *duration.Duration `protobuf:"bytes,21,opt,name=forwarder_heartbeat_interval,json=forwarderHeartbeatInterval,proto3" json:"forwarder_heartbeat_interval,omitempty"`
+	ForwarderHeartbeatMissThreshold int32              `protobuf:"varint,22,opt,name=forwarder_heartbeat_miss_threshold,json=forwarderHeartbeatMissThreshold,proto3" json:"forwarder_heartbeat_miss_threshold,omitempty"`
+	WireguardKeyRotationInterval    *duration.Duration `protobuf:"bytes,23,opt,name=wireguard_key_rotation_interval,json=wireguardKeyRotationInterval,proto3" json:"wireguard_key_rotation_interval,omitempty"`
+	XXX_NoUnkeyedLiteral            struct{}           `json:"-"`
+	XXX_unrecognized                []byte             `json:"-"`
+	XXX_sizecache                   int32              `json:"-"`
+}
+
+func (m *PropertiesReply) Reset()         { *m = PropertiesReply{} }
+func (m *PropertiesReply) String() string { return proto.CompactTextString(m) }
+func (*PropertiesReply) ProtoMessage()    {}
+func (*PropertiesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{16}
+}
+
+func (m *PropertiesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PropertiesReply.Unmarshal(m, b)
+}
+func (m *PropertiesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PropertiesReply.Marshal(b, m, deterministic)
+}
+func (m *PropertiesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PropertiesReply.Merge(m, src)
+}
+func (m *PropertiesReply) XXX_Size() int {
+	return xxx_messageInfo_PropertiesReply.Size(m)
+}
+func (m *PropertiesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PropertiesReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PropertiesReply proto.InternalMessageInfo
+
+func (m *PropertiesReply) GetHealTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetCloseTimeout() *duration.Duration {
+	if m != nil {
+		return m.CloseTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealRequestTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealRequestTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealRequestConnectTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealRequestConnectTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealRetryCount() int32 {
+	if m != nil {
+		return m.HealRetryCount
+	}
+	return 0
+}
+
+func (m *PropertiesReply) GetHealRetryBackoff() *Backoff {
+	if m != nil {
+		return m.HealRetryBackoff
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealRequestConnectCheckTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealRequestConnectCheckTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealForwarderTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealForwarderTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealDstNseWaitTimeout() *duration.Duration {
+	if m != nil {
+		return m.HealDstNseWaitTimeout
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealDstNseWaitBackoff() *Backoff {
+	if m != nil {
+		return m.HealDstNseWaitBackoff
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetHealEnabled() bool {
+	if m != nil {
+		return m.HealEnabled
+	}
+	return false
+}
+
+func (m *PropertiesReply) GetHealMakeBeforeBreak() bool {
+	if m != nil {
+		return m.HealMakeBeforeBreak
+	}
+	return false
+}
+
+func (m *PropertiesReply) GetHealHistorySize() int32 {
+	if m != nil {
+		return m.HealHistorySize
+	}
+	return 0
+}
+
+func (m *PropertiesReply) GetHealHistoryConnections() int32 {
+	if m != nil {
+		return m.HealHistoryConnections
+	}
+	return 0
+}
+
+func (m *PropertiesReply) GetEndpointFailureThreshold() int32 {
+	if m != nil {
+		return m.EndpointFailureThreshold
+	}
+	return 0
+}
+
+func (m *PropertiesReply) GetEndpointFailureWindow() *duration.Duration {
+	if m != nil {
+		return m.EndpointFailureWindow
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetEndpointQuarantine() *duration.Duration {
+	if m != nil {
+		return m.EndpointQuarantine
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetConfigFile() string {
+	if m != nil {
+		return m.ConfigFile
+	}
+	return ""
+}
+
+func (m *PropertiesReply) GetConnectionSnapshotInterval() *duration.Duration {
+	if m != nil {
+		return m.ConnectionSnapshotInterval
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetForwarderPolicies() []*ForwarderPolicy {
+	if m != nil {
+		return m.ForwarderPolicies
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetForwarderHeartbeatInterval() *duration.Duration {
+	if m != nil {
+		return m.ForwarderHeartbeatInterval
+	}
+	return nil
+}
+
+func (m *PropertiesReply) GetForwarderHeartbeatMissThreshold() int32 {
+	if m != nil {
+		return m.ForwarderHeartbeatMissThreshold
+	}
+	return 0
+}
+
+func (m *PropertiesReply) GetWireguardKeyRotationInterval() *duration.Duration {
+	if m != nil {
+		return m.WireguardKeyRotationInterval
+	}
+	return nil
+}
+
+// ForwarderPolicy configures forwarder selection for connections of network_service, the policy with empty
+// network_service applies to network services without their own policy.
+type ForwarderPolicy struct {
+	NetworkService       string            `protobuf:"bytes,1,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	Mechanisms           []string          `protobuf:"bytes,2,rep,name=mechanisms,proto3" json:"mechanisms,omitempty"`
+	Priorities           map[string]int32  `protobuf:"bytes,3,rep,name=priorities,proto3" json:"priorities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Labels               map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ForwarderPolicy) Reset()         { *m = ForwarderPolicy{} }
+func (m *ForwarderPolicy) String() string { return proto.CompactTextString(m) }
+func (*ForwarderPolicy) ProtoMessage()    {}
+func (*ForwarderPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{17}
+}
+
+func (m *ForwarderPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwarderPolicy.Unmarshal(m, b)
+}
+func (m *ForwarderPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwarderPolicy.Marshal(b, m, deterministic)
+}
+func (m *ForwarderPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwarderPolicy.Merge(m, src)
+}
+func (m *ForwarderPolicy) XXX_Size() int {
+	return xxx_messageInfo_ForwarderPolicy.Size(m)
+}
+func (m *ForwarderPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwarderPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwarderPolicy proto.InternalMessageInfo
+
+func (m *ForwarderPolicy) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *ForwarderPolicy) GetMechanisms() []string {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *ForwarderPolicy) GetPriorities() map[string]int32 {
+	if m != nil {
+		return m.Priorities
+	}
+	return nil
+}
+
+func (m *ForwarderPolicy) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type ModelEndpoint struct {
+	Endpoint             *registry.NSERegistration `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	SocketLocation       string                    `protobuf:"bytes,2,opt,name=socket_location,json=socketLocation,proto3" json:"socket_location,omitempty"`
+	Workspace            string                    `protobuf:"bytes,3,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ModelEndpoint) Reset()         { *m = ModelEndpoint{} }
+func (m *ModelEndpoint) String() string { return proto.CompactTextString(m) }
+func (*ModelEndpoint) ProtoMessage()    {}
+func (*ModelEndpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{18}
+}
+
+func (m *ModelEndpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelEndpoint.Unmarshal(m, b)
+}
+func (m *ModelEndpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelEndpoint.Marshal(b, m, deterministic)
+}
+func (m *ModelEndpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelEndpoint.Merge(m, src)
+}
+func (m *ModelEndpoint) XXX_Size() int {
+	return xxx_messageInfo_ModelEndpoint.Size(m)
+}
+func (m *ModelEndpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelEndpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelEndpoint proto.InternalMessageInfo
+
+func (m *ModelEndpoint) GetEndpoint() *registry.NSERegistration {
+	if m != nil {
+		return m.Endpoint
+	}
+	return nil
+}
+
+func (m *ModelEndpoint) GetSocketLocation() string {
+	if m != nil {
+		return m.SocketLocation
+	}
+	return ""
+}
+
+func (m *ModelEndpoint) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
+type ModelForwarder struct {
+	RegisteredName       string                  `protobuf:"bytes,1,opt,name=registered_name,json=registeredName,proto3" json:"registered_name,omitempty"`
+	SocketLocation       string                  `protobuf:"bytes,2,opt,name=socket_location,json=socketLocation,proto3" json:"socket_location,omitempty"`
+	LocalMechanisms      []*connection.Mechanism `protobuf:"bytes,3,rep,name=local_mechanisms,json=localMechanisms,proto3" json:"local_mechanisms,omitempty"`
+	RemoteMechanisms     []*connection.Mechanism `protobuf:"bytes,4,rep,name=remote_mechanisms,json=remoteMechanisms,proto3" json:"remote_mechanisms,omitempty"`
+	MechanismsConfigured bool                    `protobuf:"varint,5,opt,name=mechanisms_configured,json=mechanismsConfigured,proto3" json:"mechanisms_configured,omitempty"`
+	// draining is set while connections are migrated from the forwarder
+	Draining        bool                        `protobuf:"varint,6,opt,name=draining,proto3" json:"draining,omitempty"`
+	Implementation  string                      `protobuf:"bytes,7,opt,name=implementation,proto3" json:"implementation,omitempty"`
+	Version         string                      `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	ProtocolVersion uint32                      `protobuf:"varint,9,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    []*ModelMechanismCapability `protobuf:"bytes,10,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// max_cross_connects is a maximum amount of connections the forwarder could serve, 0 means unlimited
+	MaxCrossConnects     uint32            `protobuf:"varint,11,opt,name=max_cross_connects,json=maxCrossConnects,proto3" json:"max_cross_connects,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ModelForwarder) Reset()         { *m = ModelForwarder{} }
+func (m *ModelForwarder) String() string { return proto.CompactTextString(m) }
+func (*ModelForwarder) ProtoMessage()    {}
+func (*ModelForwarder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{19}
+}
+
+func (m *ModelForwarder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelForwarder.Unmarshal(m, b)
+}
+func (m *ModelForwarder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelForwarder.Marshal(b, m, deterministic)
+}
+func (m *ModelForwarder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelForwarder.Merge(m, src)
+}
+func (m *ModelForwarder) XXX_Size() int {
+	return xxx_messageInfo_ModelForwarder.Size(m)
+}
+func (m *ModelForwarder) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelForwarder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelForwarder proto.InternalMessageInfo
+
+func (m *ModelForwarder) GetRegisteredName() string {
+	if m != nil {
+		return m.RegisteredName
+	}
+	return ""
+}
+
+func (m *ModelForwarder) GetSocketLocation() string {
+	if m != nil {
+		return m.SocketLocation
+	}
+	return ""
+}
+
+func (m *ModelForwarder) GetLocalMechanisms() []*connection.Mechanism {
+	if m != nil {
+		return m.LocalMechanisms
+	}
+	return nil
+}
+
+func (m *ModelForwarder) GetRemoteMechanisms() []*connection.Mechanism {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
+func (m *ModelForwarder) GetMechanismsConfigured() bool {
+	if m != nil {
+		return m.MechanismsConfigured
+	}
+	return false
+}
+
+func (m *ModelForwarder) GetDraining() bool {
+	if m != nil {
+		return m.Draining
+	}
+	return false
+}
+
+func (m *ModelForwarder) GetImplementation() string {
+	if m != nil {
+		return m.Implementation
+	}
+	return ""
+}
+
+func (m *ModelForwarder) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ModelForwarder) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ModelForwarder) GetCapabilities() []*ModelMechanismCapability {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func (m *ModelForwarder) GetMaxCrossConnects() uint32 {
+	if m != nil {
+		return m.MaxCrossConnects
+	}
+	return 0
+}
+
+func (m *ModelForwarder) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+// ModelMechanismCapability is a mechanism type advertised by the forwarder on registration.
+type ModelMechanismCapability struct {
+	Type                 string                     `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Remote               bool                       `protobuf:"varint,2,opt,name=remote,proto3" json:"remote,omitempty"`
+	Parameters           []*ModelMechanismParameter `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ModelMechanismCapability) Reset()         { *m = ModelMechanismCapability{} }
+func (m *ModelMechanismCapability) String() string { return proto.CompactTextString(m) }
+func (*ModelMechanismCapability) ProtoMessage()    {}
+func (*ModelMechanismCapability) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{20}
+}
+
+func (m *ModelMechanismCapability) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelMechanismCapability.Unmarshal(m, b)
+}
+func (m *ModelMechanismCapability) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelMechanismCapability.Marshal(b, m, deterministic)
+}
+func (m *ModelMechanismCapability) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelMechanismCapability.Merge(m, src)
+}
+func (m *ModelMechanismCapability) XXX_Size() int {
+	return xxx_messageInfo_ModelMechanismCapability.Size(m)
+}
+func (m *ModelMechanismCapability) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelMechanismCapability.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelMechanismCapability proto.InternalMessageInfo
+
+func (m *ModelMechanismCapability) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ModelMechanismCapability) GetRemote() bool {
+	if m != nil {
+		return m.Remote
+	}
+	return false
+}
+
+func (m *ModelMechanismCapability) GetParameters() []*ModelMechanismParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+type ModelMechanismParameter struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Required             bool     `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModelMechanismParameter) Reset()         { *m = ModelMechanismParameter{} }
+func (m *ModelMechanismParameter) String() string { return proto.CompactTextString(m) }
+func (*ModelMechanismParameter) ProtoMessage()    {}
+func (*ModelMechanismParameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{21}
+}
+
+func (m *ModelMechanismParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelMechanismParameter.Unmarshal(m, b)
+}
+func (m *ModelMechanismParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelMechanismParameter.Marshal(b, m, deterministic)
+}
+func (m *ModelMechanismParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelMechanismParameter.Merge(m, src)
+}
+func (m *ModelMechanismParameter) XXX_Size() int {
+	return xxx_messageInfo_ModelMechanismParameter.Size(m)
+}
+func (m *ModelMechanismParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelMechanismParameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelMechanismParameter proto.InternalMessageInfo
+
+func (m *ModelMechanismParameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ModelMechanismParameter) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ModelMechanismParameter) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+type ModelClientConnection struct {
+	ConnectionId            string                                `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Request                 *networkservice.NetworkServiceRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Xcon                    *crossconnect.CrossConnect            `protobuf:"bytes,3,opt,name=xcon,proto3" json:"xcon,omitempty"`
+	RemoteNsm               *registry.NetworkServiceManager       `protobuf:"bytes,4,opt,name=remote_nsm,json=remoteNsm,proto3" json:"remote_nsm,omitempty"`
+	Endpoint                *registry.NSERegistration             `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	ForwarderRegisteredName string                                `protobuf:"bytes,6,opt,name=forwarder_registered_name,json=forwarderRegisteredName,proto3" json:"forwarder_registered_name,omitempty"`
+	ConnectionState         ClientConnectionState                 `protobuf:"varint,7,opt,name=connection_state,json=connectionState,proto3,enum=nsmdapi.ClientConnectionState" json:"connection_state,omitempty"`
+	ForwarderState          ForwarderState                        `protobuf:"varint,8,opt,name=forwarder_state,json=forwarderState,proto3,enum=nsmdapi.ForwarderState" json:"forwarder_state,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{}                              `json:"-"`
+	XXX_unrecognized        []byte                                `json:"-"`
+	XXX_sizecache           int32                                 `json:"-"`
+}
+
+func (m *ModelClientConnection) Reset()         { *m = ModelClientConnection{} }
+func (m *ModelClientConnection) String() string { return proto.CompactTextString(m) }
+func (*ModelClientConnection) ProtoMessage()    {}
+func (*ModelClientConnection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{22}
+}
+
+func (m *ModelClientConnection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelClientConnection.Unmarshal(m, b)
+}
+func (m *ModelClientConnection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelClientConnection.Marshal(b, m, deterministic)
+}
+func (m *ModelClientConnection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelClientConnection.Merge(m, src)
+}
+func (m *ModelClientConnection) XXX_Size() int {
+	return xxx_messageInfo_ModelClientConnection.Size(m)
+}
+func (m *ModelClientConnection) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelClientConnection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelClientConnection proto.InternalMessageInfo
+
+func (m *ModelClientConnection) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func (m *ModelClientConnection) GetRequest() *networkservice.NetworkServiceRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *ModelClientConnection) GetXcon() *crossconnect.CrossConnect {
+	if m != nil {
+		return m.Xcon
+	}
+	return nil
+}
+
+func (m *ModelClientConnection) GetRemoteNsm() *registry.NetworkServiceManager {
+	if m != nil {
+		return m.RemoteNsm
+	}
+	return nil
+}
+
+func (m *ModelClientConnection) GetEndpoint() *registry.NSERegistration {
+	if m != nil {
+		return m.Endpoint
+	}
+	return nil
+}
+
+func (m *ModelClientConnection) GetForwarderRegisteredName() string {
+	if m != nil {
+		return m.ForwarderRegisteredName
+	}
+	return ""
+}
+
+func (m *ModelClientConnection) GetConnectionState() ClientConnectionState {
+	if m != nil {
+		return m.ConnectionState
+	}
+	return ClientConnectionState_CLIENT_CONNECTION_READY
+}
+
+func (m *ModelClientConnection) GetForwarderState() ForwarderState {
+	if m != nil {
+		return m.ForwarderState
+	}
+	return ForwarderState_FORWARDER_STATE_NONE
+}
+
+type ModelEndpointCircuit struct {
+	EndpointName         string               `protobuf:"bytes,1,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	State                string               `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Failures             int32                `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`
+	Since                *timestamp.Timestamp `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ModelEndpointCircuit) Reset()         { *m = ModelEndpointCircuit{} }
+func (m *ModelEndpointCircuit) String() string { return proto.CompactTextString(m) }
+func (*ModelEndpointCircuit) ProtoMessage()    {}
+func (*ModelEndpointCircuit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{23}
+}
+
+func (m *ModelEndpointCircuit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelEndpointCircuit.Unmarshal(m, b)
+}
+func (m *ModelEndpointCircuit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelEndpointCircuit.Marshal(b, m, deterministic)
+}
+func (m *ModelEndpointCircuit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelEndpointCircuit.Merge(m, src)
+}
+func (m *ModelEndpointCircuit) XXX_Size() int {
+	return xxx_messageInfo_ModelEndpointCircuit.Size(m)
+}
+func (m *ModelEndpointCircuit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelEndpointCircuit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelEndpointCircuit proto.InternalMessageInfo
+
+func (m *ModelEndpointCircuit) GetEndpointName() string {
+	if m != nil {
+		return m.EndpointName
+	}
+	return ""
+}
+
+func (m *ModelEndpointCircuit) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ModelEndpointCircuit) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *ModelEndpointCircuit) GetSince() *timestamp.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+// ModelEvent holds model objects changed, every event except the initial state transfer holds a single object.
+type ModelEvent struct {
+	Type                 ModelEventType           `protobuf:"varint,1,opt,name=type,proto3,enum=nsmdapi.ModelEventType" json:"type,omitempty"`
+	Endpoints            []*ModelEndpoint         `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Forwarders           []*ModelForwarder        `protobuf:"bytes,3,rep,name=forwarders,proto3" json:"forwarders,omitempty"`
+	ClientConnections    []*ModelClientConnection `protobuf:"bytes,4,rep,name=client_connections,json=clientConnections,proto3" json:"client_connections,omitempty"`
+	EndpointCircuits     []*ModelEndpointCircuit  `protobuf:"bytes,5,rep,name=endpoint_circuits,json=endpointCircuits,proto3" json:"endpoint_circuits,omitempty"`
+	Timestamp            *timestamp.Timestamp     `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ModelEvent) Reset()         { *m = ModelEvent{} }
+func (m *ModelEvent) String() string { return proto.CompactTextString(m) }
+func (*ModelEvent) ProtoMessage()    {}
+func (*ModelEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{24}
+}
+
+func (m *ModelEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelEvent.Unmarshal(m, b)
+}
+func (m *ModelEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelEvent.Marshal(b, m, deterministic)
+}
+func (m *ModelEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelEvent.Merge(m, src)
+}
+func (m *ModelEvent) XXX_Size() int {
+	return xxx_messageInfo_ModelEvent.Size(m)
+}
+func (m *ModelEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelEvent proto.InternalMessageInfo
+
+func (m *ModelEvent) GetType() ModelEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ModelEventType_MODEL_INITIAL_STATE_TRANSFER
+}
+
+func (m *ModelEvent) GetEndpoints() []*ModelEndpoint {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+func (m *ModelEvent) GetForwarders() []*ModelForwarder {
+	if m != nil {
+		return m.Forwarders
+	}
+	return nil
+}
+
+func (m *ModelEvent) GetClientConnections() []*ModelClientConnection {
+	if m != nil {
+		return m.ClientConnections
+	}
+	return nil
+}
+
+func (m *ModelEvent) GetEndpointCircuits() []*ModelEndpointCircuit {
+	if m != nil {
+		return m.EndpointCircuits
+	}
+	return nil
+}
+
+func (m *ModelEvent) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+type MonitorModelRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MonitorModelRequest) Reset()         { *m = MonitorModelRequest{} }
+func (m *MonitorModelRequest) String() string { return proto.CompactTextString(m) }
+func (*MonitorModelRequest) ProtoMessage()    {}
+func (*MonitorModelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_084cb5dcc765b124, []int{25}
+}
+
+func (m *MonitorModelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonitorModelRequest.Unmarshal(m, b)
+}
+func (m *MonitorModelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonitorModelRequest.Marshal(b, m, deterministic)
+}
+func (m *MonitorModelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonitorModelRequest.Merge(m, src)
+}
+func (m *MonitorModelRequest) XXX_Size() int {
+	return xxx_messageInfo_MonitorModelRequest.Size(m)
+}
+func (m *MonitorModelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonitorModelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonitorModelRequest proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("nsmdapi.HealEventType", HealEventType_name, HealEventType_value)
+	proto.RegisterEnum("nsmdapi.ModelEventType", ModelEventType_name, ModelEventType_value)
+	proto.RegisterEnum("nsmdapi.ClientConnectionState", ClientConnectionState_name, ClientConnectionState_value)
+	proto.RegisterEnum("nsmdapi.ForwarderState", ForwarderState_name, ForwarderState_value)
+	proto.RegisterType((*ClientConnectionRequest)(nil), "nsmdapi.ClientConnectionRequest")
+	proto.RegisterType((*ClientConnectionReply)(nil), "nsmdapi.ClientConnectionReply")
+	proto.RegisterType((*DeleteConnectionRequest)(nil), "nsmdapi.DeleteConnectionRequest")
+	proto.RegisterType((*DeleteConnectionReply)(nil), "nsmdapi.DeleteConnectionReply")
+	proto.RegisterType((*EnumConnectionRequest)(nil), "nsmdapi.EnumConnectionRequest")
+	proto.RegisterType((*EnumConnectionReply)(nil), "nsmdapi.EnumConnectionReply")
+	proto.RegisterType((*ExplainEndpointSelectionRequest)(nil), "nsmdapi.ExplainEndpointSelectionRequest")
+	proto.RegisterType((*ExplainEndpointSelectionReply)(nil), "nsmdapi.ExplainEndpointSelectionReply")
+	proto.RegisterType((*MatchExplanation)(nil), "nsmdapi.MatchExplanation")
+	proto.RegisterType((*RouteExplanation)(nil), "nsmdapi.RouteExplanation")
+	proto.RegisterType((*HealEvent)(nil), "nsmdapi.HealEvent")
+	proto.RegisterType((*HealHistoryRequest)(nil), "nsmdapi.HealHistoryRequest")
+	proto.RegisterType((*HealHistoryReply)(nil), "nsmdapi.HealHistoryReply")
+	proto.RegisterType((*MonitorHealEventsRequest)(nil), "nsmdapi.MonitorHealEventsRequest")
+	proto.RegisterType((*PropertiesRequest)(nil), "nsmdapi.PropertiesRequest")
+	proto.RegisterType((*Backoff)(nil), "nsmdapi.Backoff")
+	proto.RegisterType((*PropertiesReply)(nil), "nsmdapi.PropertiesReply")
+	proto.RegisterType((*ForwarderPolicy)(nil), "nsmdapi.ForwarderPolicy")
+	proto.RegisterMapType((map[string]string)(nil), "nsmdapi.ForwarderPolicy.LabelsEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "nsmdapi.ForwarderPolicy.PrioritiesEntry")
+	proto.RegisterType((*ModelEndpoint)(nil), "nsmdapi.ModelEndpoint")
+	proto.RegisterType((*ModelForwarder)(nil), "nsmdapi.ModelForwarder")
+	proto.RegisterMapType((map[string]string)(nil), "nsmdapi.ModelForwarder.LabelsEntry")
+	proto.RegisterType((*ModelMechanismCapability)(nil), "nsmdapi.ModelMechanismCapability")
+	proto.RegisterType((*ModelMechanismParameter)(nil), "nsmdapi.ModelMechanismParameter")
+	proto.RegisterType((*ModelClientConnection)(nil), "nsmdapi.ModelClientConnection")
+	proto.RegisterType((*ModelEndpointCircuit)(nil), "nsmdapi.ModelEndpointCircuit")
+	proto.RegisterType((*ModelEvent)(nil), "nsmdapi.ModelEvent")
+	proto.RegisterType((*MonitorModelRequest)(nil), "nsmdapi.MonitorModelRequest")
+}
+
+func init() { proto.RegisterFile("nsmd.proto", fileDescriptor_084cb5dcc765b124) }
+
+var fileDescriptor_084cb5dcc765b124 = []byte{
+	// 2608 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4b, 0x73, 0x1b, 0xc7,
+	0x11, 0x16, 0x40, 0x82, 0x24, 0x9a, 0x24, 0xb0, 0x1c, 0xbe, 0x96, 0x30, 0x45, 0xd1, 0xf0, 0x23,
+	0x0a, 0x9d, 0x82, 0x1d, 0x2a, 0x89, 0x1f, 0x71, 0x64, 0x93, 0xc0, 0x4a, 0x82, 0x45, 0x80, 0xf2,
+	0x02, 0x8e, 0xca, 0x76, 0xb9, 0x36, 0x4b, 0x60, 0x48, 0x4c, 0xb8, 0xd8, 0x85, 0x67, 0x06, 0x92,
+	0xe0, 0x73, 0x0e, 0xa9, 0x4a, 0xe5, 0xe4, 0x1f, 0x90, 0x43, 0x2a, 0xbf, 0x24, 0xbf, 0x20, 0xa7,
+	0x5c, 0xf2, 0x07, 0x72, 0xc8, 0x39, 0x27, 0x57, 0xa5, 0xe6, 0xb1, 0x2f, 0x3c, 0x04, 0xba, 0x94,
+	0xdb, 0x4e, 0xf7, 0xd7, 0x3d, 0x3d, 0x3d, 0x3d, 0x3d, 0x3d, 0xbd, 0x00, 0x3e, 0xeb, 0x77, 0x2b,
+	0x03, 0x1a, 0xf0, 0x00, 0x2d, 0x8b, 0x6f, 0x77, 0x40, 0x4a, 0xdf, 0x5c, 0x11, 0xde, 0x1b, 0x5e,
+	0x54, 0x3a, 0x41, 0xff, 0x5d, 0x1f, 0xf3, 0xe7, 0x01, 0xbd, 0x66, 0x98, 0x3e, 0x23, 0x1d, 0xdc,
+	0xc7, 0xac, 0x37, 0x8d, 0xd4, 0x09, 0x7c, 0x4e, 0x03, 0x6f, 0xe0, 0xb9, 0x3e, 0x7e, 0xd7, 0x1d,
+	0x10, 0x41, 0xf0, 0x71, 0x87, 0x93, 0xc0, 0x4f, 0x7c, 0xaa, 0x79, 0x4a, 0xee, 0xff, 0x41, 0x3d,
+	0x0d, 0x18, 0xd3, 0x8a, 0x53, 0x03, 0x3d, 0x05, 0x7e, 0xf5, 0x29, 0xd2, 0x98, 0xb1, 0xa1, 0x9e,
+	0xe6, 0xcb, 0x57, 0x9f, 0x86, 0xe2, 0x2b, 0xc2, 0x38, 0x1d, 0x45, 0x1f, 0x5a, 0xf5, 0xe1, 0x80,
+	0x8f, 0x06, 0x98, 0xbd, 0xcb, 0x49, 0x1f, 0x33, 0xee, 0xf6, 0x07, 0xf1, 0x97, 0x46, 0x1c, 0x68,
+	0x44, 0x77, 0x48, 0x5d, 0xe9, 0xe8, 0xf0, 0x43, 0xf1, 0xcb, 0xef, 0xc3, 0x6e, 0xd5, 0x23, 0xd8,
+	0xe7, 0xd5, 0x68, 0x03, 0x6c, 0xfc, 0xed, 0x10, 0x33, 0x8e, 0xf6, 0x21, 0x2f, 0x0d, 0x1b, 0xb8,
+	0x1d, 0x6c, 0x66, 0x0e, 0x33, 0x77, 0xf3, 0x76, 0x4c, 0x28, 0xff, 0x23, 0x03, 0xdb, 0x93, 0x92,
+	0x03, 0x6f, 0xf4, 0x72, 0x39, 0x74, 0x08, 0xab, 0xbd, 0x80, 0xf1, 0x53, 0x97, 0xe1, 0x2e, 0xa1,
+	0x66, 0x56, 0xf2, 0x93, 0x24, 0xf4, 0x26, 0xac, 0x77, 0xa4, 0x62, 0x41, 0xa8, 0x11, 0x6a, 0x2e,
+	0x48, 0x4c, 0x9a, 0x88, 0xee, 0x42, 0xd1, 0x67, 0xfd, 0x16, 0xa6, 0xcf, 0x30, 0x6d, 0x05, 0x9d,
+	0x6b, 0xcc, 0xcd, 0x45, 0x89, 0x1b, 0x27, 0x6b, 0xa4, 0xb2, 0x55, 0x23, 0x73, 0x11, 0x32, 0x49,
+	0x16, 0xce, 0xa8, 0x61, 0x0f, 0x73, 0xfc, 0x63, 0x9d, 0xb1, 0x0b, 0xdb, 0x93, 0x82, 0x03, 0x6f,
+	0x24, 0x18, 0x96, 0x3f, 0xec, 0x4f, 0xe8, 0x2b, 0xdf, 0x83, 0xcd, 0x71, 0xc6, 0x14, 0xdf, 0x2d,
+	0xa4, 0xa7, 0xf9, 0x43, 0x06, 0xee, 0x58, 0x2f, 0x06, 0x9e, 0x4b, 0x7c, 0xcb, 0xef, 0x0e, 0x02,
+	0xe2, 0xf3, 0x16, 0xf6, 0xd2, 0x86, 0xfe, 0x0a, 0x20, 0x3e, 0x4b, 0xd2, 0xd2, 0xd5, 0xe3, 0x9d,
+	0x4a, 0x4c, 0xaa, 0x24, 0xa6, 0x4c, 0x20, 0xd1, 0x4f, 0xc1, 0x20, 0x57, 0x7e, 0x40, 0xb1, 0x83,
+	0xb5, 0x6a, 0x66, 0x66, 0xa5, 0x01, 0x45, 0x45, 0x0f, 0x67, 0x64, 0xe5, 0xff, 0x66, 0xe1, 0xf6,
+	0x6c, 0x33, 0xc4, 0x32, 0x4e, 0xa0, 0xa8, 0xc3, 0xda, 0xd1, 0x71, 0xad, 0x2d, 0x31, 0x2b, 0x51,
+	0x04, 0x37, 0x15, 0xa0, 0xa5, 0xf8, 0x76, 0xc1, 0x4f, 0x8d, 0xd1, 0x7d, 0xc8, 0xa7, 0x0d, 0x59,
+	0x3d, 0x3e, 0x9c, 0x25, 0x1c, 0x5a, 0x61, 0xc7, 0x22, 0xe8, 0x1c, 0xd0, 0x25, 0xf1, 0x38, 0xa6,
+	0xb8, 0x9b, 0x58, 0xd1, 0xc2, 0x0d, 0x15, 0x6d, 0x84, 0xb2, 0xd1, 0xaa, 0xd1, 0x3d, 0x58, 0xee,
+	0xbb, 0xbc, 0xd3, 0xc3, 0xcc, 0x5c, 0x94, 0x5a, 0xf6, 0x2a, 0x3a, 0x15, 0x56, 0x1a, 0x82, 0x2e,
+	0x3d, 0xe2, 0xcb, 0xb3, 0x65, 0x87, 0x48, 0xd4, 0x80, 0x0d, 0x26, 0x5d, 0x93, 0xb0, 0x42, 0x46,
+	0xdf, 0x4d, 0x8c, 0x30, 0x42, 0xd1, 0x90, 0x52, 0xfe, 0x3e, 0x03, 0xc6, 0xf8, 0x64, 0xe8, 0x2d,
+	0xc8, 0xc9, 0xe9, 0xb4, 0x8b, 0x8b, 0xb1, 0x5e, 0x09, 0xb5, 0x15, 0x17, 0xbd, 0x05, 0x05, 0x16,
+	0x0c, 0x69, 0x07, 0x3b, 0xca, 0xb8, 0xae, 0x3c, 0x7b, 0x2b, 0xf6, 0xba, 0xa2, 0x36, 0x14, 0x11,
+	0xfd, 0x1c, 0x96, 0x68, 0x30, 0xe4, 0x38, 0xf4, 0x55, 0xbc, 0x4a, 0x5b, 0x90, 0x93, 0xab, 0xd4,
+	0xc0, 0xf2, 0x9f, 0x32, 0x60, 0x8c, 0x33, 0xd1, 0xfb, 0xb0, 0xda, 0xc5, 0x8c, 0x13, 0x35, 0xd4,
+	0xb6, 0x6d, 0xc7, 0xb6, 0xd5, 0x62, 0xa6, 0x9d, 0x44, 0xbe, 0xea, 0xc6, 0x97, 0xff, 0x9e, 0x85,
+	0xfc, 0x23, 0xec, 0x7a, 0xd6, 0x33, 0xec, 0x73, 0xf4, 0x06, 0xac, 0xc7, 0x41, 0xee, 0x90, 0xae,
+	0x3e, 0xbb, 0x6b, 0x31, 0xb1, 0xde, 0x45, 0xbb, 0xb0, 0xdc, 0xc3, 0xae, 0xe7, 0x10, 0xe5, 0x93,
+	0xbc, 0xbd, 0x24, 0x86, 0xf5, 0x2e, 0xba, 0x0d, 0x20, 0x19, 0x8c, 0xbb, 0x1c, 0xeb, 0x3c, 0x94,
+	0x17, 0x94, 0x96, 0x20, 0xa0, 0x23, 0x58, 0x14, 0xd9, 0x55, 0x26, 0x9e, 0xc2, 0xf1, 0x4e, 0xe4,
+	0xa9, 0x68, 0xfa, 0xf6, 0x68, 0x80, 0x6d, 0x89, 0x41, 0x3f, 0x99, 0x3c, 0x12, 0x2a, 0x0b, 0x8d,
+	0x07, 0x7e, 0x09, 0x56, 0xa2, 0x48, 0x59, 0x92, 0x88, 0x68, 0x8c, 0x4c, 0x58, 0x76, 0x39, 0xc7,
+	0xfd, 0x01, 0x37, 0x97, 0x0f, 0x33, 0x77, 0x73, 0x76, 0x38, 0x44, 0x5b, 0x90, 0xc3, 0x94, 0x06,
+	0xd4, 0x5c, 0x91, 0x22, 0x6a, 0x80, 0x3e, 0x80, 0x7c, 0x74, 0x21, 0x98, 0x79, 0xb9, 0x05, 0xa5,
+	0xca, 0x55, 0x10, 0x5c, 0x79, 0xfa, 0x72, 0xba, 0x18, 0x5e, 0x56, 0xda, 0x21, 0xc2, 0x8e, 0xc1,
+	0xe5, 0x0f, 0x01, 0x89, 0x55, 0x3c, 0x22, 0x8c, 0x07, 0x74, 0x14, 0x26, 0x97, 0x9b, 0x78, 0xb3,
+	0x7c, 0x1f, 0x8c, 0x94, 0xa8, 0x48, 0x08, 0x47, 0xb0, 0x84, 0x85, 0x43, 0x98, 0x4c, 0x6a, 0xab,
+	0xc7, 0x68, 0xd2, 0x57, 0xb6, 0x46, 0x94, 0x3f, 0x01, 0xb3, 0x11, 0xf8, 0x84, 0x07, 0x34, 0xe2,
+	0xb1, 0x1f, 0x65, 0xc0, 0x26, 0x6c, 0x3c, 0xa1, 0xc1, 0x00, 0x53, 0x4e, 0x70, 0x28, 0x59, 0xfe,
+	0x6b, 0x06, 0x96, 0x4f, 0xdd, 0xce, 0x75, 0x70, 0x79, 0x29, 0x8e, 0x32, 0xf1, 0x09, 0x27, 0xae,
+	0xa7, 0xe3, 0x72, 0x6f, 0xc2, 0x29, 0x35, 0x7d, 0x4d, 0xda, 0x21, 0x12, 0x1d, 0x00, 0xf4, 0x87,
+	0x1e, 0x27, 0x03, 0x8f, 0x60, 0x75, 0x6f, 0x65, 0xec, 0x04, 0x05, 0xbd, 0x03, 0x0b, 0x7d, 0xf7,
+	0x85, 0xb9, 0x30, 0x4f, 0xa1, 0x40, 0xa1, 0x1d, 0x58, 0xfa, 0x3d, 0xe1, 0x1c, 0x53, 0x19, 0x3b,
+	0x19, 0x5b, 0x8f, 0xca, 0xff, 0x5e, 0x83, 0x62, 0xd2, 0x76, 0xe1, 0xbb, 0x8f, 0x61, 0x4d, 0x06,
+	0xa1, 0xd8, 0x9c, 0x60, 0xc8, 0xe7, 0x9b, 0xbc, 0x2a, 0xe0, 0x6d, 0x85, 0x46, 0xf7, 0xc5, 0x6d,
+	0x1a, 0x30, 0x1c, 0x89, 0x67, 0xe7, 0x89, 0xaf, 0x49, 0x7c, 0x28, 0xff, 0x18, 0xb6, 0xe4, 0xec,
+	0x54, 0xf9, 0x31, 0x52, 0x33, 0x77, 0x9d, 0x48, 0x88, 0x69, 0xef, 0x87, 0xca, 0xbe, 0x82, 0xfd,
+	0x94, 0x32, 0xbd, 0x6d, 0x91, 0xd2, 0xc5, 0x79, 0x4a, 0xf7, 0x12, 0x4a, 0xf5, 0x45, 0x16, 0xea,
+	0xbe, 0x0b, 0x86, 0xd6, 0xcd, 0xe9, 0xc8, 0xe9, 0x04, 0x43, 0x9d, 0x69, 0x73, 0x76, 0x41, 0x09,
+	0x71, 0x3a, 0xaa, 0x0a, 0x2a, 0xba, 0x0f, 0x28, 0x81, 0xbc, 0x50, 0x41, 0x21, 0xcf, 0xda, 0xea,
+	0xb1, 0x11, 0x05, 0xa6, 0x0e, 0x16, 0xdb, 0x88, 0xa4, 0xc3, 0xf0, 0xc1, 0x50, 0x9e, 0xba, 0x8a,
+	0x4e, 0x0f, 0x77, 0xae, 0xa3, 0xb5, 0x2c, 0xcf, 0x5b, 0xcb, 0xc1, 0xe4, 0x5a, 0xaa, 0x42, 0x43,
+	0xb8, 0xa0, 0x73, 0xd8, 0x91, 0xd3, 0x5c, 0x06, 0xf4, 0xb9, 0x4b, 0xbb, 0x98, 0x46, 0xaa, 0x57,
+	0xe6, 0xa9, 0x96, 0x5b, 0xf6, 0x20, 0x94, 0x0b, 0x15, 0xb6, 0x40, 0xba, 0xcf, 0xe9, 0x32, 0xee,
+	0xf8, 0x0c, 0x3b, 0xcf, 0x5d, 0x12, 0xbb, 0x3e, 0x3f, 0x4f, 0xe7, 0xb6, 0x90, 0xad, 0x31, 0xde,
+	0x64, 0xf8, 0xa9, 0x4b, 0x22, 0xb7, 0x7f, 0x36, 0x4d, 0x69, 0xe8, 0x53, 0x98, 0xe1, 0xd3, 0x31,
+	0x5d, 0xa1, 0x63, 0x5f, 0xd7, 0x91, 0x8e, 0x7d, 0xf7, 0xc2, 0xc3, 0x5d, 0x73, 0x55, 0x5e, 0x50,
+	0x32, 0x9c, 0x2d, 0x45, 0x42, 0xf7, 0xb4, 0x53, 0xfa, 0xee, 0x35, 0x76, 0x2e, 0xf0, 0xa5, 0x28,
+	0x58, 0x2e, 0x28, 0x76, 0xaf, 0xcd, 0x35, 0x09, 0xde, 0x14, 0xdc, 0x86, 0x7b, 0x8d, 0x4f, 0x25,
+	0xef, 0x54, 0xb0, 0xd0, 0x11, 0x6c, 0x48, 0xa1, 0x9e, 0x4a, 0x49, 0x0e, 0x23, 0xdf, 0x61, 0x73,
+	0x5d, 0xc6, 0x46, 0xb1, 0x17, 0xa7, 0xaa, 0x16, 0xf9, 0x0e, 0xa3, 0x0f, 0xc0, 0x4c, 0x61, 0xe3,
+	0xcc, 0xc2, 0xcc, 0x82, 0x14, 0xd9, 0x49, 0x88, 0xc4, 0xc5, 0x14, 0x43, 0x1f, 0x43, 0x29, 0x4c,
+	0xd4, 0xce, 0xa5, 0x4b, 0xbc, 0x21, 0xc5, 0x0e, 0xef, 0x51, 0xcc, 0x7a, 0x81, 0xd7, 0x35, 0x8b,
+	0x52, 0xd6, 0x0c, 0x11, 0x0f, 0x14, 0xa0, 0x1d, 0xf2, 0xd1, 0xe7, 0xb0, 0x3b, 0x21, 0xfd, 0x9c,
+	0xf8, 0xdd, 0xe0, 0xb9, 0x69, 0xcc, 0xdd, 0x9a, 0x31, 0xad, 0x4f, 0xa5, 0x1c, 0xfa, 0x0c, 0x36,
+	0x23, 0x95, 0xdf, 0x0e, 0x5d, 0xea, 0xfa, 0x9c, 0xf8, 0xd8, 0xdc, 0x98, 0x7b, 0x72, 0x43, 0xa9,
+	0xcf, 0x23, 0x21, 0x74, 0x07, 0x56, 0x3b, 0x81, 0x7f, 0x49, 0xae, 0x9c, 0x4b, 0xe2, 0x61, 0x13,
+	0xc9, 0xb4, 0x0b, 0x8a, 0xf4, 0x80, 0x78, 0x18, 0x7d, 0x0d, 0xfb, 0x89, 0xcc, 0xcc, 0x7c, 0x77,
+	0xc0, 0x7a, 0x01, 0x77, 0x88, 0xcf, 0x31, 0x7d, 0xe6, 0x7a, 0xe6, 0xe6, 0xbc, 0x59, 0x4b, 0xb1,
+	0x78, 0x4b, 0x4b, 0xd7, 0xb5, 0x30, 0x7a, 0x08, 0x28, 0x3e, 0x05, 0x83, 0xc0, 0x23, 0x1d, 0x82,
+	0x99, 0xb9, 0x25, 0xaf, 0x12, 0x33, 0x8a, 0xae, 0x28, 0xe0, 0x9f, 0x08, 0xc4, 0xc8, 0xde, 0xb8,
+	0x4c, 0x11, 0x08, 0x66, 0xc2, 0xca, 0x58, 0x51, 0x0f, 0xbb, 0x94, 0x5f, 0x60, 0x37, 0x61, 0xe5,
+	0xf6, 0x5c, 0x2b, 0x23, 0xf1, 0x47, 0xa1, 0x74, 0x64, 0xe5, 0x63, 0x28, 0x4f, 0x53, 0xde, 0x27,
+	0x8c, 0x25, 0x02, 0x61, 0x47, 0x06, 0xc2, 0x9d, 0x49, 0x3d, 0x0d, 0xc2, 0x58, 0x1c, 0x0f, 0xbf,
+	0x83, 0x3b, 0xcf, 0x09, 0xc5, 0x57, 0x43, 0x97, 0x76, 0x9d, 0x6b, 0x3c, 0x72, 0x68, 0xc0, 0x5d,
+	0x75, 0xeb, 0x85, 0xc6, 0xee, 0xce, 0x33, 0x76, 0x3f, 0xd2, 0xf0, 0x18, 0x8f, 0x6c, 0x2d, 0x1f,
+	0x9a, 0x5b, 0xfe, 0x57, 0x16, 0x8a, 0x63, 0x2e, 0x9b, 0x56, 0xa5, 0x64, 0xa6, 0x56, 0x29, 0xe2,
+	0x36, 0xc4, 0x9d, 0x9e, 0xeb, 0x13, 0xd6, 0x0f, 0x1f, 0x0a, 0x09, 0x0a, 0x7a, 0x04, 0x30, 0xa0,
+	0x24, 0xa0, 0x84, 0x93, 0xa8, 0x94, 0xbc, 0x3b, 0x6b, 0xa7, 0x2a, 0x4f, 0x22, 0xa8, 0xe5, 0x73,
+	0x3a, 0xb2, 0x13, 0xb2, 0xe8, 0x63, 0x58, 0xf2, 0xdc, 0x0b, 0xec, 0x85, 0x65, 0xf7, 0x9b, 0x33,
+	0xb5, 0x9c, 0x49, 0x98, 0xd2, 0xa0, 0x65, 0x4a, 0xbf, 0x11, 0xf7, 0x69, 0x4a, 0x39, 0x32, 0x60,
+	0xe1, 0x1a, 0x8f, 0xf4, 0xba, 0xc4, 0xa7, 0x28, 0x9e, 0x9e, 0xb9, 0xde, 0x10, 0xcb, 0xbb, 0x31,
+	0x67, 0xab, 0xc1, 0x47, 0xd9, 0x0f, 0x32, 0xa5, 0x0f, 0x61, 0x35, 0xa1, 0x75, 0x9e, 0x68, 0x3e,
+	0x21, 0x5a, 0xfe, 0x73, 0x06, 0xd6, 0x1b, 0x41, 0x17, 0x7b, 0x61, 0x91, 0x8a, 0x7e, 0x99, 0xa8,
+	0xec, 0xc2, 0x4b, 0x3c, 0x2e, 0x6c, 0x5b, 0x96, 0xad, 0xbe, 0xd5, 0xde, 0x45, 0x50, 0xb1, 0x27,
+	0x4c, 0xbe, 0x4f, 0x1d, 0x2f, 0xe8, 0x48, 0xa6, 0x9e, 0xac, 0xa0, 0xc8, 0x67, 0x9a, 0x9a, 0x7e,
+	0x3c, 0x2e, 0x8c, 0xbf, 0x51, 0xbf, 0xcf, 0x41, 0x41, 0xda, 0x13, 0xb9, 0x4d, 0x68, 0x56, 0xf3,
+	0xcb, 0x57, 0x92, 0xef, 0xf6, 0xa3, 0xdd, 0x8e, 0xc9, 0x4d, 0xb7, 0x8f, 0x6f, 0x6e, 0xc2, 0xa7,
+	0x60, 0x08, 0x84, 0xe7, 0x24, 0x82, 0x43, 0x6d, 0xfe, 0x76, 0xf2, 0x0d, 0xda, 0x08, 0xb9, 0x76,
+	0x51, 0xc2, 0x1b, 0x71, 0xe0, 0x9c, 0xc2, 0x06, 0xc5, 0xfd, 0x80, 0xe3, 0xa4, 0x8a, 0xc5, 0x97,
+	0xa9, 0x30, 0x14, 0x3e, 0xa1, 0xe3, 0x1e, 0x6c, 0xc7, 0xc2, 0x8e, 0x4a, 0x52, 0x43, 0x8a, 0xbb,
+	0xb2, 0x1e, 0x58, 0xb1, 0xb7, 0x62, 0x66, 0x35, 0xe2, 0x89, 0xba, 0xbb, 0x4b, 0x5d, 0xe2, 0x13,
+	0xff, 0x4a, 0xd6, 0x02, 0x2b, 0x76, 0x34, 0x46, 0x6f, 0x43, 0x81, 0xf4, 0x07, 0x1e, 0xee, 0x63,
+	0x5f, 0x1d, 0x22, 0x79, 0xbb, 0xe7, 0xed, 0x31, 0xaa, 0xa8, 0xcf, 0x9f, 0x61, 0xca, 0x04, 0x40,
+	0xd5, 0xe1, 0xe1, 0x50, 0x3c, 0xaf, 0xe5, 0xf9, 0xec, 0x04, 0x9e, 0x13, 0x42, 0xc4, 0x95, 0xbb,
+	0x6e, 0x17, 0x43, 0xfa, 0x6f, 0x35, 0xd4, 0x82, 0xb5, 0x8e, 0x3b, 0x70, 0x2f, 0x88, 0xa7, 0x0e,
+	0x0f, 0xc8, 0xc5, 0xbf, 0x1e, 0xbf, 0x36, 0xc5, 0x26, 0x46, 0xab, 0xad, 0x86, 0xd0, 0x91, 0x9d,
+	0x12, 0x43, 0x3f, 0x03, 0xd4, 0x77, 0x5f, 0x38, 0xb2, 0xef, 0x15, 0xde, 0x62, 0x4c, 0x5e, 0xa9,
+	0xeb, 0xb6, 0xd1, 0x77, 0x5f, 0x54, 0x05, 0x43, 0xdf, 0x5f, 0x0c, 0xfd, 0x3a, 0x3a, 0x65, 0x6b,
+	0x72, 0xba, 0x37, 0xd2, 0xd3, 0x45, 0x31, 0x33, 0xf5, 0x90, 0xbd, 0xc2, 0x29, 0xf9, 0x63, 0x06,
+	0xcc, 0x59, 0x0b, 0x42, 0x48, 0xbf, 0xaf, 0x94, 0x26, 0xf9, 0x2d, 0x2a, 0x67, 0xb5, 0xdf, 0xfa,
+	0xf9, 0xaa, 0x47, 0xe8, 0x53, 0x80, 0x81, 0x4b, 0xdd, 0x3e, 0xe6, 0x98, 0xc6, 0xef, 0xfc, 0xe9,
+	0x3e, 0x7b, 0x12, 0x02, 0xed, 0x84, 0x4c, 0xf9, 0x1b, 0xd8, 0x9d, 0x01, 0x13, 0x86, 0x24, 0x4e,
+	0x87, 0xfc, 0x8e, 0x8c, 0xcb, 0x26, 0x8c, 0x2b, 0xc1, 0x8a, 0x28, 0x0a, 0x89, 0x88, 0xb5, 0x05,
+	0x15, 0x43, 0xe1, 0xb8, 0xfc, 0x9f, 0x05, 0xd8, 0x96, 0xfa, 0xc7, 0xbb, 0x66, 0x37, 0x7b, 0xa3,
+	0x7e, 0x02, 0xcb, 0xba, 0xde, 0xd4, 0x15, 0xfc, 0x5b, 0x95, 0xb1, 0x6e, 0xe3, 0x58, 0x43, 0x45,
+	0x81, 0xed, 0x50, 0x0a, 0x55, 0x60, 0xf1, 0x45, 0x27, 0xf0, 0x75, 0xe1, 0x5e, 0xaa, 0xa4, 0x1a,
+	0xa2, 0xc9, 0x60, 0xb0, 0x25, 0x0e, 0xdd, 0x07, 0xd0, 0x07, 0xd1, 0x67, 0x7d, 0x5d, 0x99, 0xdf,
+	0x99, 0xf5, 0x10, 0x6f, 0xb8, 0xbe, 0x7b, 0x85, 0xa9, 0x9d, 0x57, 0x22, 0x4d, 0xd6, 0x4f, 0x65,
+	0xbb, 0xdc, 0xcd, 0xb3, 0xdd, 0x47, 0xb0, 0x17, 0x5f, 0xa2, 0xe3, 0xd9, 0x49, 0xbd, 0x87, 0x77,
+	0x23, 0x80, 0x9d, 0x4e, 0x53, 0x75, 0x30, 0x92, 0x35, 0x88, 0x7c, 0xb4, 0x2f, 0xcb, 0xb7, 0xf9,
+	0x41, 0x14, 0x09, 0xe3, 0xde, 0x97, 0x2f, 0x79, 0xbb, 0xd8, 0x49, 0x13, 0xd0, 0xa7, 0x50, 0x8c,
+	0xcd, 0x50, 0x9a, 0x56, 0xa4, 0xa6, 0xdd, 0xc9, 0xeb, 0x47, 0xa9, 0x28, 0x5c, 0xa6, 0xc6, 0xe5,
+	0xbf, 0x64, 0x60, 0x2b, 0x95, 0xff, 0xab, 0x84, 0x76, 0x86, 0x44, 0xbe, 0x61, 0xa3, 0xb2, 0x2c,
+	0x11, 0x55, 0x6b, 0x21, 0x51, 0x2e, 0x65, 0x0b, 0x72, 0x6a, 0x56, 0x7d, 0x62, 0xe4, 0x40, 0xc4,
+	0x97, 0xae, 0x0d, 0x99, 0xdc, 0xc7, 0x9c, 0x1d, 0x8d, 0xd1, 0x7b, 0x90, 0x63, 0xc4, 0xef, 0x60,
+	0x73, 0x71, 0xee, 0x3b, 0x5f, 0x01, 0xcb, 0x3f, 0x64, 0x01, 0x94, 0x85, 0xb2, 0x55, 0xf2, 0x4e,
+	0xe2, 0xb4, 0x25, 0xd7, 0x19, 0x43, 0x12, 0xed, 0x8c, 0x5f, 0x4c, 0x76, 0x69, 0x76, 0xc6, 0x24,
+	0xa6, 0x34, 0xe5, 0xde, 0x07, 0x88, 0xbc, 0x14, 0x1e, 0xd2, 0xdd, 0x19, 0x99, 0xc6, 0x4e, 0x40,
+	0x51, 0x03, 0x90, 0x6a, 0xff, 0xa6, 0xea, 0x71, 0x75, 0x2d, 0x1c, 0xa4, 0x15, 0x4c, 0x34, 0xa5,
+	0x37, 0x3a, 0x63, 0x14, 0x86, 0x3e, 0x83, 0x8d, 0x68, 0x0b, 0x3a, 0x6a, 0x5b, 0x98, 0x99, 0x93,
+	0xda, 0x6e, 0x4f, 0x5f, 0x85, 0xde, 0x3c, 0xdb, 0xc0, 0x69, 0x02, 0x4b, 0xf7, 0x58, 0x96, 0x7e,
+	0x4c, 0x8f, 0x65, 0x1b, 0x36, 0x75, 0xa3, 0x43, 0x4e, 0xa5, 0x4f, 0xec, 0x91, 0x03, 0xeb, 0xa9,
+	0x06, 0x12, 0x32, 0x60, 0xed, 0x91, 0x75, 0x72, 0xe6, 0xb4, 0xda, 0x27, 0x76, 0xdb, 0xaa, 0x19,
+	0xb7, 0xd0, 0x2e, 0x6c, 0x4a, 0xca, 0x49, 0xbb, 0x6d, 0x35, 0x9e, 0xb4, 0x9d, 0x07, 0x27, 0xf5,
+	0x33, 0xab, 0x66, 0x64, 0x10, 0x82, 0x82, 0x82, 0x7e, 0x51, 0xad, 0x5a, 0x56, 0xcd, 0xaa, 0x19,
+	0x59, 0x54, 0x84, 0x55, 0x49, 0xd3, 0xa0, 0x85, 0x23, 0xac, 0x0b, 0x81, 0x78, 0x86, 0x43, 0xd8,
+	0x6f, 0x9c, 0xd7, 0xac, 0x33, 0xa7, 0xde, 0xac, 0xb7, 0xeb, 0x6a, 0xaa, 0xb6, 0xe5, 0xb4, 0xed,
+	0x93, 0x66, 0xeb, 0x81, 0x65, 0x1b, 0xb7, 0xd0, 0x3a, 0xe4, 0x15, 0xe2, 0xa4, 0x26, 0xe6, 0x31,
+	0x60, 0x4d, 0x0d, 0xbf, 0x78, 0x52, 0x3b, 0x69, 0x5b, 0x46, 0x36, 0xa6, 0xd4, 0xac, 0x33, 0xab,
+	0x6d, 0x19, 0x0b, 0x47, 0xff, 0x9c, 0xf2, 0x87, 0x40, 0x1d, 0xae, 0xd7, 0x60, 0xb7, 0x7a, 0x56,
+	0xb7, 0x9a, 0x6d, 0xa7, 0x7a, 0xde, 0x6c, 0x5a, 0xd5, 0x76, 0xfd, 0xbc, 0xe9, 0xd8, 0xd6, 0x49,
+	0xed, 0x4b, 0xe3, 0x96, 0xb0, 0x65, 0x1a, 0xf3, 0xf3, 0x2f, 0xac, 0x56, 0xbb, 0xde, 0x7c, 0x68,
+	0x64, 0xd0, 0x3e, 0x98, 0x93, 0x88, 0x53, 0xfb, 0xfc, 0xb1, 0xd5, 0x34, 0xb2, 0xe8, 0x0d, 0xb8,
+	0x33, 0xc9, 0x15, 0x0e, 0xa8, 0x37, 0x1f, 0x3a, 0xa7, 0xd6, 0xc3, 0x7a, 0xd3, 0x58, 0x40, 0xb7,
+	0x61, 0x6f, 0x26, 0xc8, 0x58, 0x9c, 0xce, 0xae, 0x9e, 0x9d, 0xb7, 0x04, 0x3b, 0x77, 0x64, 0x41,
+	0x21, 0x7d, 0xf8, 0x91, 0x09, 0x5b, 0x0f, 0xce, 0xed, 0xa7, 0x27, 0x76, 0xcd, 0xb2, 0xb5, 0xf3,
+	0x9a, 0xe7, 0x4d, 0xcb, 0xb8, 0x85, 0xf6, 0x60, 0x7b, 0x9c, 0xa3, 0x56, 0x9a, 0x39, 0xfe, 0x21,
+	0x0b, 0x8b, 0xcd, 0x56, 0xa3, 0x86, 0xbe, 0x86, 0xdd, 0xb0, 0x0f, 0x30, 0x7e, 0x37, 0x1c, 0xce,
+	0x4c, 0x5c, 0x5a, 0xa2, 0x74, 0xf0, 0x12, 0x84, 0x68, 0x1f, 0x35, 0xa1, 0x90, 0xfe, 0xd3, 0x80,
+	0x62, 0x89, 0xa9, 0xff, 0x26, 0x4a, 0xfb, 0x33, 0xf9, 0x42, 0xdf, 0x57, 0xb0, 0xa3, 0xff, 0x75,
+	0xcc, 0xb6, 0x75, 0xc6, 0x5f, 0x94, 0xd2, 0xc1, 0x4b, 0x10, 0x42, 0xb7, 0x07, 0xe6, 0xac, 0x1f,
+	0x0b, 0x28, 0x7e, 0x3d, 0xcc, 0xf9, 0x05, 0x52, 0x7a, 0xfb, 0x06, 0xc8, 0x81, 0x37, 0x3a, 0xfe,
+	0x5b, 0x06, 0x56, 0x13, 0x9d, 0x4a, 0xf4, 0x08, 0x0a, 0x0f, 0x31, 0x4f, 0x52, 0x5e, 0x4b, 0xb5,
+	0x29, 0xd3, 0xcd, 0xd0, 0xd2, 0xde, 0x74, 0xa6, 0x58, 0xc7, 0x19, 0x6c, 0x4c, 0xb4, 0x30, 0x51,
+	0xb2, 0x82, 0x9b, 0xde, 0xde, 0x2c, 0x4d, 0x69, 0x8b, 0xbe, 0x97, 0x39, 0x7e, 0x0a, 0x05, 0x11,
+	0x26, 0x71, 0x5f, 0x10, 0x59, 0xb0, 0xfe, 0x10, 0xf3, 0x04, 0xa1, 0x14, 0x09, 0x4e, 0x74, 0x3e,
+	0x4b, 0xe6, 0x54, 0x9e, 0x70, 0x40, 0x0b, 0xd6, 0x54, 0xc5, 0xa3, 0xec, 0x41, 0x55, 0x58, 0xd3,
+	0x9f, 0x92, 0x8c, 0xf6, 0xc7, 0x2d, 0x4e, 0xe6, 0xa9, 0xd2, 0xe6, 0x94, 0x1b, 0xe2, 0xbd, 0xcc,
+	0xc5, 0x92, 0x4c, 0x7a, 0xf7, 0xfe, 0x37, 0x00, 0xad, 0x03, 0x38, 0x80, 0x35, 0x1e, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// NSMDClient is the client API for NSMD service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NSMDClient interface {
+	RequestClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ClientConnectionReply, error)
+	EnumConnection(ctx context.Context, in *EnumConnectionRequest, opts ...grpc.CallOption) (*EnumConnectionReply, error)
+	DeleteClientConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionReply, error)
+	ExplainEndpointSelection(ctx context.Context, in *ExplainEndpointSelectionRequest, opts ...grpc.CallOption) (*ExplainEndpointSelectionReply, error)
+}
+
+type nSMDClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNSMDClient(cc grpc.ClientConnInterface) NSMDClient {
+	return &nSMDClient{cc}
+}
+
+func (c *nSMDClient) RequestClientConnection(ctx context.Context, in *ClientConnectionRequest, opts ...grpc.CallOption) (*ClientConnectionReply, error) {
+	out := new(ClientConnectionReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/RequestClientConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMDClient) EnumConnection(ctx context.Context, in *EnumConnectionRequest, opts ...grpc.CallOption) (*EnumConnectionReply, error) {
+	out := new(EnumConnectionReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/EnumConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMDClient) DeleteClientConnection(ctx context.Context, in *DeleteConnectionRequest, opts ...grpc.CallOption) (*DeleteConnectionReply, error) {
+	out := new(DeleteConnectionReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/DeleteClientConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMDClient) ExplainEndpointSelection(ctx context.Context, in *ExplainEndpointSelectionRequest, opts ...grpc.CallOption) (*ExplainEndpointSelectionReply, error) {
+	out := new(ExplainEndpointSelectionReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.NSMD/ExplainEndpointSelection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NSMDServer is the server API for NSMD service.
+type NSMDServer interface {
+	RequestClientConnection(context.Context, *ClientConnectionRequest) (*ClientConnectionReply, error)
+	EnumConnection(context.Context, *EnumConnectionRequest) (*EnumConnectionReply, error)
+	DeleteClientConnection(context.Context, *DeleteConnectionRequest) (*DeleteConnectionReply, error)
+	ExplainEndpointSelection(context.Context, *ExplainEndpointSelectionRequest) (*ExplainEndpointSelectionReply, error)
+}
+
+// UnimplementedNSMDServer can be embedded to have forward compatible implementations.
+type UnimplementedNSMDServer struct {
+}
+
+func (*UnimplementedNSMDServer) RequestClientConnection(ctx context.Context, req *ClientConnectionRequest) (*ClientConnectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestClientConnection not implemented")
+}
+func (*UnimplementedNSMDServer) EnumConnection(ctx context.Context, req *EnumConnectionRequest) (*EnumConnectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnumConnection not implemented")
+}
+func (*UnimplementedNSMDServer) DeleteClientConnection(ctx context.Context, req *DeleteConnectionRequest) (*DeleteConnectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientConnection not implemented")
+}
+func (*UnimplementedNSMDServer) ExplainEndpointSelection(ctx context.Context, req *ExplainEndpointSelectionRequest) (*ExplainEndpointSelectionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainEndpointSelection not implemented")
+}
+
+func RegisterNSMDServer(s *grpc.Server, srv NSMDServer) {
+	s.RegisterService(&_NSMD_serviceDesc, srv)
+}
+
+func _NSMD_RequestClientConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMDServer).RequestClientConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.NSMD/RequestClientConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMDServer).RequestClientConnection(ctx, req.(*ClientConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMD_EnumConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnumConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMDServer).EnumConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.NSMD/EnumConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMDServer).EnumConnection(ctx, req.(*EnumConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMD_DeleteClientConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConnectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMDServer).DeleteClientConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.NSMD/DeleteClientConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMDServer).DeleteClientConnection(ctx, req.(*DeleteConnectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMD_ExplainEndpointSelection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainEndpointSelectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMDServer).ExplainEndpointSelection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.NSMD/ExplainEndpointSelection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMDServer).ExplainEndpointSelection(ctx, req.(*ExplainEndpointSelectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NSMD_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nsmdapi.NSMD",
+	HandlerType: (*NSMDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestClientConnection",
+			Handler:    _NSMD_RequestClientConnection_Handler,
+		},
+		{
+			MethodName: "EnumConnection",
+			Handler:    _NSMD_EnumConnection_Handler,
 		},
 		{
 			MethodName: "DeleteClientConnection",
 			Handler:    _NSMD_DeleteClientConnection_Handler,
 		},
+		{
+			MethodName: "ExplainEndpointSelection",
+			Handler:    _NSMD_ExplainEndpointSelection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nsmd.proto",
+}
+
+// HealHistoryClient is the client API for HealHistory service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HealHistoryClient interface {
+	GetHealHistory(ctx context.Context, in *HealHistoryRequest, opts ...grpc.CallOption) (*HealHistoryReply, error)
+	MonitorHealEvents(ctx context.Context, in *MonitorHealEventsRequest, opts ...grpc.CallOption) (HealHistory_MonitorHealEventsClient, error)
+}
+
+type healHistoryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHealHistoryClient(cc grpc.ClientConnInterface) HealHistoryClient {
+	return &healHistoryClient{cc}
+}
+
+func (c *healHistoryClient) GetHealHistory(ctx context.Context, in *HealHistoryRequest, opts ...grpc.CallOption) (*HealHistoryReply, error) {
+	out := new(HealHistoryReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.HealHistory/GetHealHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *healHistoryClient) MonitorHealEvents(ctx context.Context, in *MonitorHealEventsRequest, opts ...grpc.CallOption) (HealHistory_MonitorHealEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_HealHistory_serviceDesc.Streams[0], "/nsmdapi.HealHistory/MonitorHealEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &healHistoryMonitorHealEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HealHistory_MonitorHealEventsClient interface {
+	Recv() (*HealEvent, error)
+	grpc.ClientStream
+}
+
+type healHistoryMonitorHealEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *healHistoryMonitorHealEventsClient) Recv() (*HealEvent, error) {
+	m := new(HealEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// HealHistoryServer is the server API for HealHistory service.
+type HealHistoryServer interface {
+	GetHealHistory(context.Context, *HealHistoryRequest) (*HealHistoryReply, error)
+	MonitorHealEvents(*MonitorHealEventsRequest, HealHistory_MonitorHealEventsServer) error
+}
+
+// UnimplementedHealHistoryServer can be embedded to have forward compatible implementations.
+type UnimplementedHealHistoryServer struct {
+}
+
+func (*UnimplementedHealHistoryServer) GetHealHistory(ctx context.Context, req *HealHistoryRequest) (*HealHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHealHistory not implemented")
+}
+func (*UnimplementedHealHistoryServer) MonitorHealEvents(req *MonitorHealEventsRequest, srv HealHistory_MonitorHealEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method MonitorHealEvents not implemented")
+}
+
+func RegisterHealHistoryServer(s *grpc.Server, srv HealHistoryServer) {
+	s.RegisterService(&_HealHistory_serviceDesc, srv)
+}
+
+func _HealHistory_GetHealHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealHistoryServer).GetHealHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.HealHistory/GetHealHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealHistoryServer).GetHealHistory(ctx, req.(*HealHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HealHistory_MonitorHealEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorHealEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HealHistoryServer).MonitorHealEvents(m, &healHistoryMonitorHealEventsServer{stream})
+}
+
+type HealHistory_MonitorHealEventsServer interface {
+	Send(*HealEvent) error
+	grpc.ServerStream
+}
+
+type healHistoryMonitorHealEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *healHistoryMonitorHealEventsServer) Send(m *HealEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _HealHistory_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nsmdapi.HealHistory",
+	HandlerType: (*HealHistoryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHealHistory",
+			Handler:    _HealHistory_GetHealHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MonitorHealEvents",
+			Handler:       _HealHistory_MonitorHealEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nsmd.proto",
+}
+
+// NSMDPropertiesClient is the client API for NSMDProperties service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NSMDPropertiesClient interface {
+	GetProperties(ctx context.Context, in *PropertiesRequest, opts ...grpc.CallOption) (*PropertiesReply, error)
+}
+
+type nSMDPropertiesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNSMDPropertiesClient(cc grpc.ClientConnInterface) NSMDPropertiesClient {
+	return &nSMDPropertiesClient{cc}
+}
+
+func (c *nSMDPropertiesClient) GetProperties(ctx context.Context, in *PropertiesRequest, opts ...grpc.CallOption) (*PropertiesReply, error) {
+	out := new(PropertiesReply)
+	err := c.cc.Invoke(ctx, "/nsmdapi.NSMDProperties/GetProperties", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NSMDPropertiesServer is the server API for NSMDProperties service.
+type NSMDPropertiesServer interface {
+	GetProperties(context.Context, *PropertiesRequest) (*PropertiesReply, error)
+}
+
+// UnimplementedNSMDPropertiesServer can be embedded to have forward compatible implementations.
+type UnimplementedNSMDPropertiesServer struct {
+}
+
+func (*UnimplementedNSMDPropertiesServer) GetProperties(ctx context.Context, req *PropertiesRequest) (*PropertiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProperties not implemented")
+}
+
+func RegisterNSMDPropertiesServer(s *grpc.Server, srv NSMDPropertiesServer) {
+	s.RegisterService(&_NSMDProperties_serviceDesc, srv)
+}
+
+func _NSMDProperties_GetProperties_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PropertiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMDPropertiesServer).GetProperties(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nsmdapi.NSMDProperties/GetProperties",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMDPropertiesServer).GetProperties(ctx, req.(*PropertiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NSMDProperties_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nsmdapi.NSMDProperties",
+	HandlerType: (*NSMDPropertiesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProperties",
+			Handler:    _NSMDProperties_GetProperties_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nsmd.proto",
 }
+
+// ModelMonitorClient is the client API for ModelMonitor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ModelMonitorClient interface {
+	MonitorModel(ctx context.Context, in *MonitorModelRequest, opts ...grpc.CallOption) (ModelMonitor_MonitorModelClient, error)
+}
+
+type modelMonitorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewModelMonitorClient(cc grpc.ClientConnInterface) ModelMonitorClient {
+	return &modelMonitorClient{cc}
+}
+
+func (c *modelMonitorClient) MonitorModel(ctx context.Context, in *MonitorModelRequest, opts ...grpc.CallOption) (ModelMonitor_MonitorModelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ModelMonitor_serviceDesc.Streams[0], "/nsmdapi.ModelMonitor/MonitorModel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &modelMonitorMonitorModelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ModelMonitor_MonitorModelClient interface {
+	Recv() (*ModelEvent, error)
+	grpc.ClientStream
+}
+
+type modelMonitorMonitorModelClient struct {
+	grpc.ClientStream
+}
+
+func (x *modelMonitorMonitorModelClient) Recv() (*ModelEvent, error) {
+	m := new(ModelEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ModelMonitorServer is the server API for ModelMonitor service.
+type ModelMonitorServer interface {
+	MonitorModel(*MonitorModelRequest, ModelMonitor_MonitorModelServer) error
+}
+
+// UnimplementedModelMonitorServer can be embedded to have forward compatible implementations.
+type UnimplementedModelMonitorServer struct {
+}
+
+func (*UnimplementedModelMonitorServer) MonitorModel(req *MonitorModelRequest, srv ModelMonitor_MonitorModelServer) error {
+	return status.Errorf(codes.Unimplemented, "method MonitorModel not implemented")
+}
+
+func RegisterModelMonitorServer(s *grpc.Server, srv ModelMonitorServer) {
+	s.RegisterService(&_ModelMonitor_serviceDesc, srv)
+}
+
+func _ModelMonitor_MonitorModel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MonitorModelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ModelMonitorServer).MonitorModel(m, &modelMonitorMonitorModelServer{stream})
+}
+
+type ModelMonitor_MonitorModelServer interface {
+	Send(*ModelEvent) error
+	grpc.ServerStream
+}
+
+type modelMonitorMonitorModelServer struct {
+	grpc.ServerStream
+}
+
+func (x *modelMonitorMonitorModelServer) Send(m *ModelEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ModelMonitor_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nsmdapi.ModelMonitor",
+	HandlerType: (*ModelMonitorServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MonitorModel",
+			Handler:       _ModelMonitor_MonitorModel_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nsmd.proto",
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// Set-based label selector operators
+const (
+	// LabelSelectorOpIn requires the label value to be one of the requirement values
+	LabelSelectorOpIn = "In"
+	// LabelSelectorOpNotIn requires the label to be absent or to have a value not in the requirement values
+	LabelSelectorOpNotIn = "NotIn"
+	// LabelSelectorOpExists requires the label to be present
+	LabelSelectorOpExists = "Exists"
+	// LabelSelectorOpDoesNotExist requires the label to be absent
+	LabelSelectorOpDoesNotExist = "DoesNotExist"
+)
+
+// ParseSelectorTemplate - parses selector value, values may refer to the request connection labels with text/template syntax
+func ParseSelectorTemplate(value string) (*template.Template, error) {
+	return template.New("selector").Parse(value)
+}
+
+// ValidateSelector - checks all of the selector values are valid templates
+func ValidateSelector(selector map[string]string) error {
+	for key, value := range selector {
+		if _, err := ParseSelectorTemplate(value); err != nil {
+			return errors.Wrapf(err, "invalid selector template for key %q", key)
+		}
+	}
+	return nil
+}
+
+// IsValid - checks NetworkService selectors validation
+func (ns *NetworkService) IsValid() error {
+	if ns == nil {
+		return errors.New("NetworkService should not be nil")
+	}
+	for i, match := range ns.GetMatches() {
+		if err := match.IsValid(); err != nil {
+			return errors.Wrapf(err, "NetworkService %s match %d", ns.GetName(), i)
+		}
+	}
+	return nil
+}
+
+// IsValid - checks Match selectors validation
+func (m *Match) IsValid() error {
+	if m == nil {
+		return errors.New("Match should not be nil")
+	}
+	if err := ValidateSelector(m.GetSourceSelector()); err != nil {
+		return errors.Wrap(err, "source selector")
+	}
+	for _, requirement := range m.GetSourceSelectorExpressions() {
+		if err := requirement.IsValid(); err != nil {
+			return errors.Wrap(err, "source selector expression")
+		}
+	}
+	for i, destination := range m.GetRoutes() {
+		if err := destination.IsValid(); err != nil {
+			return errors.Wrapf(err, "route %d", i)
+		}
+	}
+	return nil
+}
+
+// IsValid - checks Destination selectors validation
+func (d *Destination) IsValid() error {
+	if d == nil {
+		return errors.New("Destination should not be nil")
+	}
+	if err := ValidateSelector(d.GetDestinationSelector()); err != nil {
+		return errors.Wrap(err, "destination selector")
+	}
+	for _, requirement := range d.GetDestinationSelectorExpressions() {
+		if err := requirement.IsValid(); err != nil {
+			return errors.Wrap(err, "destination selector expression")
+		}
+	}
+	return nil
+}
+
+// IsValid - checks LabelSelectorRequirement has a key, a known operator and values required by the operator
+func (r *LabelSelectorRequirement) IsValid() error {
+	if r == nil {
+		return errors.New("LabelSelectorRequirement should not be nil")
+	}
+	if r.GetKey() == "" {
+		return errors.New("LabelSelectorRequirement.Key is required and cannot be empty")
+	}
+	switch r.GetOperator() {
+	case LabelSelectorOpIn, LabelSelectorOpNotIn:
+		if len(r.GetValues()) == 0 {
+			return errors.Errorf("LabelSelectorRequirement.Values should not be empty for operator %s: %v", r.GetOperator(), r)
+		}
+	case LabelSelectorOpExists, LabelSelectorOpDoesNotExist:
+		if len(r.GetValues()) != 0 {
+			return errors.Errorf("LabelSelectorRequirement.Values should be empty for operator %s: %v", r.GetOperator(), r)
+		}
+	default:
+		return errors.Errorf("LabelSelectorRequirement.Operator %q is unknown: %v", r.GetOperator(), r)
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ type NetworkService struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
 	Matches              []*Match `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	SelectionStrategy    string   `protobuf:"bytes,4,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -81,12 +82,20 @@ func (m *NetworkService) GetMatches() []*Match {
 	return nil
 }
 
+func (m *NetworkService) GetSelectionStrategy() string {
+	if m != nil {
+		return m.SelectionStrategy
+	}
+	return ""
+}
+
 type Match struct {
-	SourceSelector       map[string]string `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Routes               []*Destination    `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	SourceSelector            map[string]string           `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Routes                    []*Destination              `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
+	SourceSelectorExpressions []*LabelSelectorRequirement `protobuf:"bytes,3,rep,name=source_selector_expressions,json=sourceSelectorExpressions,proto3" json:"source_selector_expressions,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                    `json:"-"`
+	XXX_unrecognized          []byte                      `json:"-"`
+	XXX_sizecache             int32                       `json:"-"`
 }
 
 func (m *Match) Reset()         { *m = Match{} }
@@ -128,12 +137,20 @@ func (m *Match) GetRoutes() []*Destination {
 	return nil
 }
 
+func (m *Match) GetSourceSelectorExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.SourceSelectorExpressions
+	}
+	return nil
+}
+
 type Destination struct {
-	DestinationSelector  map[string]string `protobuf:"bytes,1,rep,name=destination_selector,json=destinationSelector,proto3" json:"destination_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Weight               uint32            `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DestinationSelector            map[string]string           `protobuf:"bytes,1,rep,name=destination_selector,json=destinationSelector,proto3" json:"destination_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Weight                         uint32                      `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	DestinationSelectorExpressions []*LabelSelectorRequirement `protobuf:"bytes,3,rep,name=destination_selector_expressions,json=destinationSelectorExpressions,proto3" json:"destination_selector_expressions,omitempty"`
+	XXX_NoUnkeyedLiteral           struct{}                    `json:"-"`
+	XXX_unrecognized               []byte                      `json:"-"`
+	XXX_sizecache                  int32                       `json:"-"`
 }
 
 func (m *Destination) Reset()         { *m = Destination{} }
@@ -175,6 +192,69 @@ func (m *Destination) GetWeight() uint32 {
 	return 0
 }
 
+func (m *Destination) GetDestinationSelectorExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.DestinationSelectorExpressions
+	}
+	return nil
+}
+
+// LabelSelectorRequirement is a set-based label requirement, operator is one of In, NotIn, Exists, DoesNotExist
+type LabelSelectorRequirement struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator             string   `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelSelectorRequirement) Reset()         { *m = LabelSelectorRequirement{} }
+func (m *LabelSelectorRequirement) String() string { return proto.CompactTextString(m) }
+func (*LabelSelectorRequirement) ProtoMessage()    {}
+func (*LabelSelectorRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{3}
+}
+
+func (m *LabelSelectorRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelSelectorRequirement.Unmarshal(m, b)
+}
+func (m *LabelSelectorRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelSelectorRequirement.Marshal(b, m, deterministic)
+}
+func (m *LabelSelectorRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelSelectorRequirement.Merge(m, src)
+}
+func (m *LabelSelectorRequirement) XXX_Size() int {
+	return xxx_messageInfo_LabelSelectorRequirement.Size(m)
+}
+func (m *LabelSelectorRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelSelectorRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelSelectorRequirement proto.InternalMessageInfo
+
+func (m *LabelSelectorRequirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type NetworkServiceManager struct {
 	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Url                  string               `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
@@ -189,7 +269,7 @@ func (m *NetworkServiceManager) Reset()         { *m = NetworkServiceManager{} }
 func (m *NetworkServiceManager) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceManager) ProtoMessage()    {}
 func (*NetworkServiceManager) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{3}
+	return fileDescriptor_41af05d40a615591, []int{4}
 }
 
 func (m *NetworkServiceManager) XXX_Unmarshal(b []byte) error {
@@ -254,7 +334,7 @@ func (m *NetworkServiceEndpoint) Reset()         { *m = NetworkServiceEndpoint{}
 func (m *NetworkServiceEndpoint) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpoint) ProtoMessage()    {}
 func (*NetworkServiceEndpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{4}
+	return fileDescriptor_41af05d40a615591, []int{5}
 }
 
 func (m *NetworkServiceEndpoint) XXX_Unmarshal(b []byte) error {
@@ -328,7 +408,7 @@ func (m *FindNetworkServiceRequest) Reset()         { *m = FindNetworkServiceReq
 func (m *FindNetworkServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceRequest) ProtoMessage()    {}
 func (*FindNetworkServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{5}
+	return fileDescriptor_41af05d40a615591, []int{6}
 }
 
 func (m *FindNetworkServiceRequest) XXX_Unmarshal(b []byte) error {
@@ -370,7 +450,7 @@ func (m *FindNetworkServiceResponse) Reset()         { *m = FindNetworkServiceRe
 func (m *FindNetworkServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceResponse) ProtoMessage()    {}
 func (*FindNetworkServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{6}
+	return fileDescriptor_41af05d40a615591, []int{7}
 }
 
 func (m *FindNetworkServiceResponse) XXX_Unmarshal(b []byte) error {
@@ -432,7 +512,7 @@ func (m *NSERegistration) Reset()         { *m = NSERegistration{} }
 func (m *NSERegistration) String() string { return proto.CompactTextString(m) }
 func (*NSERegistration) ProtoMessage()    {}
 func (*NSERegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{7}
+	return fileDescriptor_41af05d40a615591, []int{8}
 }
 
 func (m *NSERegistration) XXX_Unmarshal(b []byte) error {
@@ -485,7 +565,7 @@ func (m *RemoveNSERequest) Reset()         { *m = RemoveNSERequest{} }
 func (m *RemoveNSERequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveNSERequest) ProtoMessage()    {}
 func (*RemoveNSERequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{8}
+	return fileDescriptor_41af05d40a615591, []int{9}
 }
 
 func (m *RemoveNSERequest) XXX_Unmarshal(b []byte) error {
@@ -524,7 +604,7 @@ func (m *NetworkServiceEndpointList) Reset()         { *m = NetworkServiceEndpoi
 func (m *NetworkServiceEndpointList) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpointList) ProtoMessage()    {}
 func (*NetworkServiceEndpointList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{9}
+	return fileDescriptor_41af05d40a615591, []int{10}
 }
 
 func (m *NetworkServiceEndpointList) XXX_Unmarshal(b []byte) error {
@@ -558,6 +638,7 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "registry.Match.SourceSelectorEntry")
 	proto.RegisterType((*Destination)(nil), "registry.Destination")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Destination.DestinationSelectorEntry")
+	proto.RegisterType((*LabelSelectorRequirement)(nil), "registry.LabelSelectorRequirement")
 	proto.RegisterType((*NetworkServiceManager)(nil), "registry.NetworkServiceManager")
 	proto.RegisterType((*NetworkServiceEndpoint)(nil), "registry.NetworkServiceEndpoint")
 	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceEndpoint.LabelsEntry")
@@ -572,58 +653,65 @@ func init() {
 func init() { proto.RegisterFile("registry.proto", fileDescriptor_41af05d40a615591) }
 
 var fileDescriptor_41af05d40a615591 = []byte{
-	// 811 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x6e, 0xd3, 0x40,
-	0x14, 0xd6, 0x24, 0x6d, 0x4a, 0x9f, 0x21, 0xa9, 0xa6, 0x6d, 0xea, 0x18, 0x10, 0x51, 0xca, 0x22,
-	0x48, 0x60, 0xaa, 0x20, 0x24, 0x60, 0x53, 0x4a, 0x9b, 0xb2, 0x20, 0x09, 0x92, 0x03, 0x42, 0x42,
-	0x48, 0x91, 0x9b, 0x0c, 0xa9, 0xa9, 0xff, 0xf0, 0x4c, 0x52, 0xdc, 0x1b, 0x70, 0x03, 0x0e, 0xc1,
-	0x1d, 0x58, 0xb2, 0xed, 0x3d, 0xb8, 0x04, 0xf2, 0x8c, 0x13, 0xdb, 0x89, 0xdd, 0x34, 0xea, 0xa6,
-	0x9a, 0x9f, 0xf7, 0xbe, 0xf7, 0xe6, 0xfb, 0x3e, 0xbf, 0x06, 0x8a, 0x1e, 0x19, 0x1a, 0x94, 0x79,
-	0xbe, 0xea, 0x7a, 0x0e, 0x73, 0xf0, 0xad, 0xc9, 0x5e, 0x91, 0x5d, 0xe6, 0xbb, 0x84, 0x3e, 0x25,
-	0x96, 0xcb, 0x7c, 0xf1, 0x57, 0xc4, 0x28, 0xd5, 0xf0, 0x86, 0x19, 0x16, 0xa1, 0x4c, 0xb7, 0xdc,
-	0x68, 0x25, 0x22, 0x6a, 0x06, 0x14, 0x3b, 0x84, 0x9d, 0x3b, 0xde, 0x59, 0x97, 0x78, 0x63, 0xa3,
-	0x4f, 0x30, 0x86, 0x15, 0x5b, 0xb7, 0x88, 0x8c, 0xaa, 0xa8, 0xbe, 0xae, 0xf1, 0x35, 0x96, 0x61,
-	0xcd, 0xd5, 0x7d, 0xd3, 0xd1, 0x07, 0x72, 0x8e, 0x1f, 0x4f, 0xb6, 0xf8, 0x11, 0xac, 0x59, 0x3a,
-	0xeb, 0x9f, 0x12, 0x2a, 0xe7, 0xab, 0xf9, 0xba, 0xd4, 0x28, 0xa9, 0xd3, 0x3e, 0xdb, 0xc1, 0x85,
-	0x36, 0xb9, 0xaf, 0xfd, 0x45, 0xb0, 0xca, 0x8f, 0x70, 0x0b, 0x4a, 0xd4, 0x19, 0x79, 0x7d, 0xd2,
-	0xa3, 0xc4, 0x24, 0x7d, 0xe6, 0x78, 0x32, 0xe2, 0xc9, 0xbb, 0x33, 0xc9, 0x6a, 0x97, 0x87, 0x75,
-	0xc3, 0xa8, 0xa6, 0xcd, 0x3c, 0x5f, 0x2b, 0xd2, 0xc4, 0x21, 0x7e, 0x02, 0x05, 0xcf, 0x19, 0x31,
-	0x42, 0xe5, 0x1c, 0x07, 0xd9, 0x8e, 0x40, 0x8e, 0x08, 0x65, 0x86, 0xad, 0x33, 0xc3, 0xb1, 0xb5,
-	0x30, 0x48, 0x39, 0x80, 0xcd, 0x14, 0x54, 0xbc, 0x01, 0xf9, 0x33, 0xe2, 0x87, 0xaf, 0x0e, 0x96,
-	0x78, 0x0b, 0x56, 0xc7, 0xba, 0x39, 0x22, 0xe1, 0x93, 0xc5, 0xe6, 0x55, 0xee, 0x05, 0xaa, 0x5d,
-	0x22, 0x90, 0x62, 0xd0, 0x58, 0x87, 0xad, 0x41, 0xb4, 0x9d, 0x7d, 0x94, 0x9a, 0xda, 0x4f, 0x7c,
-	0x9d, 0x7c, 0xdf, 0xe6, 0x60, 0xfe, 0x06, 0x97, 0xa1, 0x70, 0x4e, 0x8c, 0xe1, 0x29, 0xe3, 0xdd,
-	0xdc, 0xd1, 0xc2, 0x9d, 0x72, 0x0c, 0x72, 0x16, 0xd0, 0x52, 0x4f, 0xfa, 0x85, 0x60, 0x3b, 0x69,
-	0x84, 0xb6, 0x6e, 0xeb, 0x43, 0xe2, 0xa5, 0xfa, 0x61, 0x03, 0xf2, 0x23, 0xcf, 0x0c, 0x51, 0x82,
-	0x25, 0x3e, 0x84, 0x12, 0xf9, 0xe1, 0x1a, 0x9e, 0x60, 0x20, 0x70, 0x99, 0x9c, 0xaf, 0xa2, 0xba,
-	0xd4, 0x50, 0xd4, 0xa1, 0xe3, 0x0c, 0x4d, 0x22, 0xfc, 0x76, 0x32, 0xfa, 0xaa, 0x7e, 0x98, 0x58,
-	0x50, 0x2b, 0x46, 0x29, 0xc1, 0x61, 0xd0, 0x1e, 0x65, 0x3a, 0x23, 0xf2, 0x8a, 0x68, 0x8f, 0x6f,
-	0x6a, 0x97, 0x39, 0x28, 0x27, 0x5b, 0x6b, 0xda, 0x03, 0xd7, 0x31, 0x6c, 0xb6, 0xa4, 0x57, 0xf7,
-	0x60, 0xcb, 0x16, 0x38, 0x3d, 0x2a, 0x80, 0x7a, 0x3c, 0x3b, 0xcf, 0xc3, 0xb0, 0x9d, 0xa8, 0xd1,
-	0x09, 0xb0, 0xf6, 0xe1, 0xde, 0x6c, 0x86, 0x25, 0x68, 0x11, 0x99, 0xa2, 0xcf, 0x8a, 0x9d, 0x46,
-	0x1c, 0x07, 0x38, 0x82, 0x82, 0xa9, 0x9f, 0x10, 0x93, 0xca, 0xab, 0xdc, 0x0b, 0x8f, 0x23, 0x2f,
-	0xa4, 0x3f, 0x49, 0x6d, 0xf1, 0x70, 0xe1, 0x84, 0x30, 0x37, 0xe2, 0xa5, 0x10, 0xe3, 0x45, 0x79,
-	0x09, 0x52, 0x2c, 0x78, 0x29, 0xb5, 0xdb, 0x50, 0x39, 0x36, 0xec, 0x41, 0xb2, 0x05, 0x8d, 0x7c,
-	0x1f, 0x11, 0xca, 0x32, 0x69, 0x42, 0x59, 0x34, 0xd5, 0xfe, 0xe4, 0x41, 0x49, 0xc3, 0xa3, 0xae,
-	0x63, 0xd3, 0x84, 0x22, 0x28, 0xa9, 0xc8, 0x01, 0x94, 0x66, 0x4a, 0xf1, 0x5e, 0xa5, 0x86, 0x9c,
-	0xc5, 0x93, 0x56, 0x4c, 0xd6, 0xc7, 0x17, 0x20, 0x67, 0x48, 0x34, 0x99, 0x48, 0xaf, 0x23, 0xac,
-	0xec, 0x26, 0xd5, 0x54, 0xf3, 0x87, 0x3a, 0x94, 0x53, 0x05, 0xa6, 0xf8, 0x0b, 0x54, 0x66, 0x6b,
-	0x93, 0x50, 0x47, 0x2a, 0xaf, 0xf0, 0xe2, 0xd5, 0x45, 0x82, 0x6b, 0x3b, 0x76, 0xea, 0x39, 0x55,
-	0xbe, 0xc1, 0xdd, 0x2b, 0x9a, 0x4a, 0xd1, 0xfb, 0x79, 0x5c, 0x6f, 0xa9, 0xf1, 0x20, 0xab, 0x74,
-	0x88, 0x13, 0x37, 0xc4, 0xcf, 0x1c, 0x94, 0x3a, 0xdd, 0xa6, 0x26, 0x12, 0xc4, 0x54, 0x4b, 0x11,
-	0x07, 0x2d, 0x29, 0xce, 0x27, 0xd8, 0xc9, 0x10, 0xe7, 0xba, 0x3d, 0x6e, 0xa7, 0x52, 0x8f, 0x3f,
-	0xcf, 0xab, 0x3e, 0x61, 0x3e, 0x9c, 0x3b, 0x8b, 0x89, 0x2f, 0xa7, 0x13, 0x5f, 0xfb, 0x08, 0x1b,
-	0x1a, 0xb1, 0x9c, 0x31, 0xe1, 0x84, 0x88, 0x6f, 0xe2, 0x00, 0xee, 0x67, 0xd5, 0x8b, 0x7f, 0x1c,
-	0x4a, 0x3a, 0x24, 0xff, 0x48, 0x2e, 0x40, 0x49, 0x6f, 0xa4, 0x65, 0x50, 0x76, 0xb5, 0x95, 0xd0,
-	0x0d, 0xad, 0xd4, 0xf8, 0x87, 0x66, 0x47, 0x68, 0xa8, 0xb4, 0x8f, 0x0f, 0x41, 0x12, 0x6b, 0xe2,
-	0x75, 0xba, 0x4d, 0x5c, 0x89, 0x15, 0x49, 0xfa, 0x41, 0xc9, 0xbe, 0xc2, 0xef, 0xa0, 0xf4, 0x66,
-	0x64, 0x9e, 0xdd, 0x18, 0xa8, 0x8e, 0xf6, 0x10, 0xde, 0x87, 0xf5, 0x29, 0xff, 0x58, 0x89, 0x62,
-	0x67, 0x45, 0x51, 0xca, 0x73, 0xff, 0x5a, 0x9a, 0xc1, 0x6f, 0x9f, 0xc6, 0x05, 0xec, 0x24, 0x1f,
-	0x7b, 0x64, 0xd0, 0xbe, 0x33, 0x26, 0x9e, 0x8f, 0x7b, 0x80, 0xe7, 0x67, 0x00, 0xde, 0xbd, 0x7a,
-	0x42, 0x88, 0x6a, 0x0f, 0xaf, 0x33, 0x46, 0x1a, 0xbf, 0x11, 0x48, 0x1d, 0x6a, 0x4d, 0xe9, 0x7d,
-	0x1f, 0xa7, 0xb7, 0x8d, 0x17, 0xf9, 0x5d, 0x59, 0x14, 0x80, 0x5b, 0x70, 0xfb, 0x2d, 0x61, 0x53,
-	0x69, 0x71, 0x06, 0x09, 0xf1, 0x76, 0xb3, 0x6d, 0x77, 0x52, 0xe0, 0x59, 0xcf, 0xfe, 0x07, 0x00,
-	0x00, 0xff, 0xff, 0x10, 0xd8, 0x69, 0xfe, 0x5d, 0x0a, 0x00, 0x00,
+	// 913 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xd6, 0xda, 0x89, 0xdb, 0x1c, 0x83, 0x1d, 0xa6, 0x89, 0xb3, 0xde, 0xf2, 0x63, 0xb9, 0xbd,
+	0x08, 0x12, 0x35, 0x95, 0x11, 0x12, 0x70, 0x53, 0x42, 0xe3, 0x72, 0x41, 0x62, 0xa4, 0x35, 0x08,
+	0x09, 0x21, 0x99, 0x8d, 0x7d, 0x70, 0x97, 0xec, 0xce, 0x2c, 0x33, 0xe3, 0xb4, 0x9b, 0x37, 0xe0,
+	0x0d, 0x78, 0x07, 0x78, 0x06, 0x78, 0x05, 0xde, 0x83, 0x97, 0x40, 0x3b, 0x33, 0xf6, 0xee, 0x3a,
+	0xb3, 0x49, 0xd3, 0xde, 0x44, 0x73, 0x76, 0xce, 0x7c, 0xe7, 0x9b, 0xef, 0x3b, 0x67, 0x62, 0x68,
+	0x71, 0x5c, 0x84, 0x42, 0xf2, 0x74, 0x90, 0x70, 0x26, 0x19, 0xb9, 0xbb, 0x8a, 0x3d, 0x37, 0x91,
+	0x69, 0x82, 0xe2, 0x63, 0x8c, 0x13, 0x99, 0xea, 0xbf, 0x3a, 0xc7, 0xeb, 0x99, 0x1d, 0x19, 0xc6,
+	0x28, 0x64, 0x10, 0x27, 0xf9, 0x4a, 0x67, 0xf4, 0xff, 0x70, 0xa0, 0x35, 0x46, 0xf9, 0x82, 0xf1,
+	0xf3, 0x09, 0xf2, 0x8b, 0x70, 0x86, 0x84, 0xc0, 0x16, 0x0d, 0x62, 0x74, 0x9d, 0x9e, 0x73, 0xb8,
+	0xe3, 0xab, 0x35, 0x71, 0xe1, 0x4e, 0x12, 0xa4, 0x11, 0x0b, 0xe6, 0x6e, 0x4d, 0x7d, 0x5e, 0x85,
+	0xe4, 0x43, 0xb8, 0x13, 0x07, 0x72, 0xf6, 0x1c, 0x85, 0x5b, 0xef, 0xd5, 0x0f, 0x9b, 0xc3, 0xf6,
+	0x60, 0x4d, 0xf4, 0x34, 0xdb, 0xf0, 0x57, 0xfb, 0xe4, 0x11, 0x10, 0x81, 0x11, 0xce, 0x64, 0xc8,
+	0xe8, 0x54, 0x48, 0x1e, 0x48, 0x5c, 0xa4, 0xee, 0x96, 0xc2, 0x7b, 0x67, 0xbd, 0x33, 0x31, 0x1b,
+	0xfd, 0x3f, 0x6b, 0xb0, 0xad, 0x10, 0xc8, 0x09, 0xb4, 0x05, 0x5b, 0xf2, 0x19, 0x4e, 0x75, 0x16,
+	0xe3, 0xae, 0xa3, 0x6a, 0x3d, 0xd8, 0xa8, 0x35, 0x98, 0xa8, 0xb4, 0x89, 0xc9, 0x1a, 0x51, 0xc9,
+	0x53, 0xbf, 0x25, 0x4a, 0x1f, 0xc9, 0x23, 0x68, 0x70, 0xb6, 0x94, 0x28, 0xdc, 0x9a, 0x02, 0xd9,
+	0xcf, 0x41, 0x8e, 0x51, 0xc8, 0x90, 0x06, 0x19, 0x0d, 0xdf, 0x24, 0x91, 0x33, 0xb8, 0xbf, 0x51,
+	0x7c, 0x8a, 0x2f, 0x13, 0x8e, 0x42, 0x84, 0x8c, 0xae, 0x2e, 0xdd, 0xcf, 0x31, 0x4e, 0x82, 0x33,
+	0x8c, 0x56, 0xc5, 0x7c, 0xfc, 0x6d, 0x19, 0x72, 0x8c, 0x91, 0x4a, 0xbf, 0x5b, 0xe6, 0x31, 0xca,
+	0x41, 0xbc, 0x23, 0xb8, 0x67, 0x61, 0x4e, 0x76, 0xa1, 0x7e, 0x8e, 0xa9, 0x31, 0x22, 0x5b, 0x92,
+	0x3d, 0xd8, 0xbe, 0x08, 0xa2, 0x25, 0x1a, 0x17, 0x74, 0xf0, 0x45, 0xed, 0x33, 0xa7, 0xff, 0x77,
+	0x0d, 0x9a, 0x05, 0xfa, 0x24, 0x80, 0xbd, 0x79, 0x1e, 0x6e, 0x0a, 0x37, 0xb0, 0xde, 0xb9, 0xb8,
+	0x2e, 0x6b, 0x78, 0x6f, 0x7e, 0x75, 0x87, 0x74, 0xa0, 0xf1, 0x02, 0xc3, 0xc5, 0x73, 0xa9, 0xd8,
+	0xbc, 0xed, 0x9b, 0x88, 0x44, 0xd0, 0xb3, 0x95, 0x7e, 0x4d, 0xd9, 0xde, 0xb7, 0x94, 0x2e, 0x6a,
+	0xf7, 0x0c, 0xdc, 0x2a, 0xda, 0xb7, 0x12, 0xf0, 0x67, 0x70, 0xab, 0x38, 0x58, 0x70, 0x3c, 0xb8,
+	0xcb, 0x12, 0xe4, 0x41, 0x26, 0xa9, 0x86, 0x5a, 0xc7, 0x99, 0x2e, 0x0a, 0x56, 0xdf, 0x72, 0xc7,
+	0x37, 0x51, 0x36, 0x6b, 0xfb, 0xe5, 0x59, 0x3b, 0x0d, 0x68, 0xb0, 0x40, 0x6e, 0x1d, 0xb9, 0x5d,
+	0xa8, 0x2f, 0x79, 0x64, 0xc0, 0xb3, 0x25, 0x79, 0x0a, 0x6d, 0x7c, 0x99, 0x84, 0x5c, 0xcb, 0x9a,
+	0x4d, 0xb2, 0x5b, 0xef, 0x39, 0x87, 0xcd, 0xa1, 0x37, 0x58, 0x30, 0xb6, 0x88, 0x50, 0xcf, 0xf4,
+	0xd9, 0xf2, 0x97, 0xc1, 0x77, 0xab, 0x31, 0xf7, 0x5b, 0xf9, 0x91, 0xec, 0x63, 0x26, 0x80, 0x90,
+	0x81, 0x44, 0x33, 0x77, 0x3a, 0xe8, 0xff, 0x5b, 0x83, 0x4e, 0x99, 0xda, 0x88, 0xce, 0x13, 0x16,
+	0x52, 0x79, 0xcb, 0xe7, 0xe0, 0x31, 0xec, 0x51, 0x8d, 0x33, 0x15, 0x1a, 0x68, 0x4a, 0x03, 0x43,
+	0x74, 0xc7, 0x27, 0xb4, 0x54, 0x63, 0x9c, 0x61, 0x3d, 0x81, 0x77, 0x37, 0x4f, 0xc4, 0x5a, 0x16,
+	0x7d, 0x52, 0xf3, 0xec, 0x52, 0x9b, 0x70, 0x0a, 0xe0, 0x18, 0x1a, 0x51, 0x66, 0x9c, 0x70, 0xb7,
+	0x55, 0x53, 0x7d, 0x94, 0x37, 0x95, 0xfd, 0x4a, 0xba, 0xd7, 0x84, 0xee, 0x6c, 0x73, 0x36, 0xd7,
+	0xa5, 0x51, 0xd0, 0xc5, 0xfb, 0x1c, 0x9a, 0x85, 0xe4, 0x5b, 0xf5, 0xd3, 0x29, 0x74, 0x9f, 0x85,
+	0x74, 0x5e, 0xa6, 0x90, 0x35, 0x15, 0x0a, 0x59, 0x29, 0x93, 0x53, 0x25, 0x53, 0xff, 0x9f, 0x3a,
+	0x78, 0x36, 0x3c, 0x91, 0x30, 0x2a, 0x4a, 0x8e, 0x38, 0x65, 0x47, 0x8e, 0xa0, 0xbd, 0x51, 0x4a,
+	0x71, 0x6d, 0x0e, 0xdd, 0x2a, 0x9d, 0xfc, 0x56, 0xb9, 0x3e, 0xb9, 0x04, 0xb7, 0xc2, 0xa2, 0xd5,
+	0x20, 0x7f, 0x99, 0x63, 0x55, 0x93, 0x1c, 0x58, 0x9b, 0xdf, 0xf8, 0xd0, 0xb1, 0x1a, 0x2c, 0xc8,
+	0x4f, 0xd0, 0xdd, 0xac, 0x8d, 0xc6, 0x47, 0xe1, 0x6e, 0xa9, 0xe2, 0xbd, 0x9b, 0x0c, 0xf7, 0x0f,
+	0xa8, 0xf5, 0xbb, 0xf0, 0x7e, 0x85, 0xfb, 0xd7, 0x90, 0xb2, 0xf8, 0xfd, 0x69, 0xd1, 0xef, 0xe6,
+	0xf0, 0x83, 0xaa, 0xd2, 0x06, 0xa7, 0xd8, 0x10, 0xbf, 0xd7, 0xa0, 0x3d, 0x9e, 0x8c, 0x7c, 0x7d,
+	0x40, 0xbf, 0xd2, 0x16, 0x73, 0x9c, 0x5b, 0x9a, 0xf3, 0x03, 0x1c, 0x54, 0x98, 0xf3, 0xaa, 0x1c,
+	0xf7, 0xad, 0xd2, 0x93, 0x1f, 0xc1, 0xad, 0x52, 0xde, 0xbc, 0x3b, 0x37, 0x0b, 0xdf, 0xb1, 0x0b,
+	0xdf, 0xff, 0x1e, 0x76, 0x7d, 0x8c, 0xd9, 0x05, 0x2a, 0x41, 0xf4, 0x4c, 0x1c, 0xc1, 0x7b, 0x55,
+	0xf5, 0x8a, 0xc3, 0xe1, 0xd9, 0x21, 0xd5, 0x90, 0x5c, 0x82, 0x67, 0x27, 0x72, 0x12, 0x0a, 0x79,
+	0x7d, 0x2b, 0x39, 0x6f, 0xd8, 0x4a, 0xc3, 0xff, 0x9c, 0xcd, 0x27, 0xd4, 0x38, 0x9d, 0x92, 0xa7,
+	0xd0, 0xd4, 0x6b, 0xe4, 0xe3, 0xc9, 0x88, 0x74, 0x0b, 0x45, 0xca, 0xfd, 0xe0, 0x55, 0x6f, 0x91,
+	0x6f, 0xa0, 0xfd, 0xd5, 0x32, 0x3a, 0x7f, 0x63, 0xa0, 0x43, 0xe7, 0xb1, 0x43, 0x9e, 0xc0, 0xce,
+	0x5a, 0x7f, 0xe2, 0xe5, 0xb9, 0x9b, 0xa6, 0x78, 0x9d, 0x2b, 0xff, 0x5a, 0x46, 0xd9, 0xef, 0xcb,
+	0xe1, 0x25, 0x1c, 0x94, 0x2f, 0x7b, 0x1c, 0x8a, 0x19, 0xbb, 0x40, 0x9e, 0x92, 0x29, 0x90, 0xab,
+	0x6f, 0x00, 0x79, 0x70, 0xfd, 0x0b, 0xa1, 0xab, 0x3d, 0x7c, 0x95, 0x67, 0x64, 0xf8, 0x97, 0x03,
+	0xcd, 0xb1, 0x88, 0xd7, 0xf2, 0x7e, 0x5b, 0x94, 0xf7, 0x94, 0xdc, 0xd4, 0xef, 0xde, 0x4d, 0x09,
+	0xe4, 0x04, 0xde, 0xfa, 0x1a, 0xe5, 0xda, 0x5a, 0x52, 0x21, 0x82, 0xf7, 0xb0, 0x0a, 0xa8, 0xd8,
+	0x76, 0x67, 0x0d, 0x75, 0xea, 0x93, 0xff, 0x07, 0x00, 0xf6, 0xb7, 0x8c, 0x00, 0xc1, 0x0b, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
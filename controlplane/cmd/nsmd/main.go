@@ -21,6 +21,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 )
 
@@ -30,6 +31,16 @@ var version string
 const (
 	NsmdAPIAddressEnv      = "NSMD_API_ADDRESS"
 	NsmdAPIAddressDefaults = ":5001"
+	// NsmdLeastConnectionsServicesEnv - comma separated list of network services using least connections endpoint selection
+	NsmdLeastConnectionsServicesEnv = "NSMD_LEAST_CONNECTIONS_SERVICES"
+	// NsmdTopologyAwareSelectionEnv - enables preferring local and same zone endpoints for all network services
+	NsmdTopologyAwareSelectionEnv = "NSMD_TOPOLOGY_AWARE_SELECTION"
+	// NsmdTopologyLabelsEnv - comma separated key=value labels of the local node ordered from the closest, e.g. zone and region
+	NsmdTopologyLabelsEnv = "NSMD_TOPOLOGY_LABELS"
+	// NsmdAffinityServicesEnv - comma separated list of network services keeping client affinity to endpoints
+	NsmdAffinityServicesEnv = "NSMD_AFFINITY_SERVICES"
+	// NsmdAffinityLabelsEnv - comma separated list of connection labels used as client affinity key
+	NsmdAffinityLabelsEnv = "NSMD_AFFINITY_LABELS"
 )
 
 func main() {
@@ -53,6 +64,7 @@ func main() {
 	serviceRegistry := nsmd.NewServiceRegistry()
 
 	model := model.NewModel() // This is TCP gRPC server uri to access this NSMD via network.
+	configureSelectors(model)
 	defer serviceRegistry.Stop()
 	manager := nsm.NewNetworkServiceManager(span.Context(), model, serviceRegistry)
 
@@ -120,3 +132,54 @@ func getNsmdAPIAddress() string {
 	}
 	return result
 }
+
+func configureSelectors(m model.Model) {
+	if os.Getenv(NsmdTopologyAwareSelectionEnv) == "true" {
+		topology := getTopologyLabels()
+		logrus.Infof("Using topology aware endpoint selection with topology labels: %v", topology)
+		m.SetDefaultSelector(selector.NewTopologySelector(m, topology))
+	}
+	for _, ns := range splitEnv(NsmdLeastConnectionsServicesEnv) {
+		logrus.Infof("Using least connections endpoint selection for network service: %v", ns)
+		m.SetNetworkServiceSelector(ns, selector.NewLeastConnectionsSelector(m))
+	}
+	affinityLabels := splitEnv(NsmdAffinityLabelsEnv)
+	if len(affinityLabels) == 0 {
+		affinityLabels = selector.DefaultAffinityLabels()
+	}
+	m.GetSelector().SetStrategyContext(&selector.StrategyContext{
+		Connections:    m,
+		Nsm:            m,
+		AffinityLabels: affinityLabels,
+	})
+	for _, ns := range splitEnv(NsmdAffinityServicesEnv) {
+		logrus.Infof("Using affinity endpoint selection with labels %v for network service: %v", affinityLabels, ns)
+		m.SetNetworkServiceSelector(ns, selector.NewAffinitySelector(affinityLabels))
+	}
+}
+
+func splitEnv(env string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(env), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getTopologyLabels() []selector.TopologyLabel {
+	var topology []selector.TopologyLabel
+	for _, label := range splitEnv(NsmdTopologyLabelsEnv) {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			logrus.Errorf("Invalid topology label: %v", label)
+			continue
+		}
+		topology = append(topology, selector.TopologyLabel{
+			Key:   kv[0],
+			Value: kv[1],
+		})
+	}
+	return topology
+}
@@ -15,6 +15,7 @@
 package nsm
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
@@ -23,6 +24,8 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connectionstore"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/sdk/monitor/connectionmonitor"
@@ -63,8 +66,26 @@ const (
 	HealStateDstUpdate HealState = 4
 	// HealStateDstNmgrDown is a case when destination and/or Remote NSM is down: we need to heal NSE/Remote NSM.
 	HealStateDstNmgrDown HealState = 5
+	// HealStateForwarderDrain is a case when local Forwarder is drained: we need to re-program connection on another Forwarder.
+	HealStateForwarderDrain HealState = 6
 )
 
+var healStateNames = map[HealState]string{
+	HealStateDstDown:        "DstDown",
+	HealStateSrcDown:        "SrcDown",
+	HealStateForwarderDown:  "ForwarderDown",
+	HealStateDstUpdate:      "DstUpdate",
+	HealStateDstNmgrDown:    "DstNmgrDown",
+	HealStateForwarderDrain: "ForwarderDrain",
+}
+
+func (s HealState) String() string {
+	if name, ok := healStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("HealState(%d)", s)
+}
+
 // NetworkServiceRequestManager - allow to provide local and remote service interfaces.
 type NetworkServiceRequestManager interface {
 	LocalManager(clientConnection ClientConnection) networkservice.NetworkServiceServer
@@ -75,6 +96,20 @@ type NetworkServiceRequestManager interface {
 type NetworkServiceHealProcessor interface {
 	Heal(ctx context.Context, clientConnection ClientConnection, healState HealState)
 	CloseConnection(ctx context.Context, clientConnection ClientConnection) error
+	// RenegotiateWireguardKeys renegotiates Wireguard keys of the connection with Remote NSM after local or remote
+	// Forwarder rotated its key, the connection is kept if renegotiation fails
+	RenegotiateWireguardKeys(ctx context.Context, clientConnection ClientConnection)
+}
+
+// HealHistory - keeps a bounded history of heal events per client connection
+type HealHistory interface {
+	// Record stores event and sends it to subscribers
+	Record(event *nsmdapi.HealEvent)
+	// Get returns heal events of the connection from the oldest one
+	Get(connectionID string) []*nsmdapi.HealEvent
+	// Subscribe returns a channel receiving heal events of the connection, or of all connections for empty connectionID,
+	// returned function cancels subscription
+	Subscribe(connectionID string) (<-chan *nsmdapi.HealEvent, func())
 }
 
 // MonitorManager is an interface to provide access to different monitors
@@ -83,8 +118,9 @@ type MonitorManager interface {
 	LocalConnectionMonitor(workspace string) connectionmonitor.MonitorServer
 }
 
-//NetworkServiceManager - hold useful nsm structures
+// NetworkServiceManager - hold useful nsm structures
 type NetworkServiceManager interface {
+	// GetHealProperties - returns properties updated by config file reload, fields are read with Snapshot()
 	GetHealProperties() *properties.Properties
 	WaitForForwarder(ctx context.Context, duration time.Duration) error
 	RemoteConnectionLost(ctx context.Context, clientConnection ClientConnection)
@@ -96,14 +132,21 @@ type NetworkServiceManager interface {
 	Model() model.Model
 
 	NetworkServiceHealProcessor
+	HealHistory() HealHistory
 	ServiceRegistry() serviceregistry.ServiceRegistry
 	RestoreConnections(xcons []*crossconnect.CrossConnect, forwarder string, manager MonitorManager)
+	// SetConnectionStore sets store client connections are persisted to and restored from
+	SetConnectionStore(store *connectionstore.Store)
+	// DrainForwarder stops placing new connections on the forwarder and migrates its connections to other forwarders
+	DrainForwarder(ctx context.Context, forwarder string) error
 }
 
-//NetworkServiceEndpointManager - manages endpoints, TODO: Will be removed in next PRs.
+// NetworkServiceEndpointManager - manages endpoints, TODO: Will be removed in next PRs.
 type NetworkServiceEndpointManager interface {
 	GetEndpoint(ctx context.Context, requestConnection *connection.Connection, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) (*registry.NSERegistration, error)
 	CreateNSEClient(ctx context.Context, endpoint *registry.NSERegistration) (NetworkServiceClient, error)
 	IsLocalEndpoint(endpoint *registry.NSERegistration) bool
 	CheckUpdateNSE(ctx context.Context, reg *registry.NSERegistration) bool
+	// ExplainEndpoint describes how an endpoint would be selected for requestConnection, no connection is created
+	ExplainEndpoint(ctx context.Context, requestConnection *connection.Connection, ignoreEndpoints []string) (*nsmdapi.ExplainEndpointSelectionReply, error)
 }
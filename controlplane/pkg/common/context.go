@@ -43,6 +43,7 @@ const (
 	ignoredEndpoints      ContextKeyType = "IgnoredEndpoints"
 	workspaceName         ContextKeyType = "WorkspaceName"
 	remoteMechanisms      ContextKeyType = "RemoteMechanisms"
+	makeBeforeBreak       ContextKeyType = "MakeBeforeBreak"
 )
 
 // WithClientConnection -
@@ -293,3 +294,24 @@ func WorkspaceName(ctx context.Context) string {
 	}
 	return value.(string)
 }
+
+// WithMakeBeforeBreak -
+//   Wraps 'parent' in a new Context that requests programming of the new path
+//   before the previous one is torn down when connection is healed to another endpoint;
+//   using Context.Value(...) and returns the result.
+//
+func WithMakeBeforeBreak(parent context.Context) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithValue(parent, makeBeforeBreak, true)
+}
+
+// MakeBeforeBreak - Return true if make-before-break heal is requested
+func MakeBeforeBreak(ctx context.Context) bool {
+	value := ctx.Value(makeBeforeBreak)
+	if value == nil {
+		return false
+	}
+	return value.(bool)
+}
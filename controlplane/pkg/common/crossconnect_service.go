@@ -23,6 +23,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 )
 
 // ConnectionService makes basic Mechanism selection for the incoming connection
@@ -42,8 +43,13 @@ func (cce *сrossConnectService) Request(ctx context.Context, request *networkse
 	}
 
 	// 7.2.6.2.4 create cross connection
+	xconID := request.Connection.GetId()
+	if id := clientConnection.Xcon.GetId(); model.XconConnectionID(id) == xconID {
+		// Connection healed with make-before-break keeps the id of the cross connect it was switched to
+		xconID = id
+	}
 	dpAPIConnection := crossconnect.NewCrossConnect(
-		request.Connection.GetId(),
+		xconID,
 		endpoint.GetNetworkService().GetPayload(),
 		request.Connection,
 		endpointConnection,
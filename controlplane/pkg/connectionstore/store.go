@@ -0,0 +1,239 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connectionstore - persists client connections of the model, so nsmd could restore them after restart
+package connectionstore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// snapshotVersion - version of the snapshot file format
+const snapshotVersion = 1
+
+// Store - a file based store of client connections snapshot, every snapshot replaces the previous one as a whole
+type Store struct {
+	lock sync.Mutex
+	file string
+}
+
+type connectionRecord struct {
+	ConnectionID            string
+	Request                 []byte
+	Xcon                    []byte
+	RemoteNsm               []byte
+	Endpoint                []byte
+	ForwarderRegisteredName string
+	ConnectionState         model.ClientConnectionState
+	ForwarderState          model.ForwarderState
+}
+
+type snapshot struct {
+	Version     int
+	Connections []*connectionRecord
+}
+
+// NewStore - creates a store keeping snapshot in file
+func NewStore(file string) *Store {
+	return &Store{file: file}
+}
+
+// Save - replaces stored snapshot with connections, the snapshot is either fully written or not written at all
+func (s *Store) Save(connections []*model.ClientConnection) error {
+	data := &snapshot{Version: snapshotVersion}
+	for _, cc := range connections {
+		record, err := newConnectionRecord(cc)
+		if err != nil {
+			return err
+		}
+		data.Connections = append(data.Connections, record)
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize connections snapshot")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	tmpFile := s.file + "_tmp"
+	if err := writeFileSync(tmpFile, bytes); err != nil {
+		return err
+	}
+	// Rename is atomic, so a crash leaves either the previous or the new snapshot.
+	if err := os.Rename(tmpFile, s.file); err != nil {
+		return errors.Wrapf(err, "failed to replace connections snapshot %s", s.file)
+	}
+	if dir, err := os.Open(filepath.Dir(s.file)); err == nil {
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+	return nil
+}
+
+// Load - returns stored connections by their ids, empty map is returned if there is no snapshot yet
+func (s *Store) Load() (map[string]*model.ClientConnection, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	connections := map[string]*model.ClientConnection{}
+	bytes, err := ioutil.ReadFile(s.file)
+	if os.IsNotExist(err) {
+		logrus.Infof("No stored connections snapshot exists")
+		return connections, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read connections snapshot %s", s.file)
+	}
+
+	data := &snapshot{}
+	if err := json.Unmarshal(bytes, data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse connections snapshot %s", s.file)
+	}
+	if data.Version != snapshotVersion {
+		return nil, errors.Errorf("unsupported connections snapshot version %v", data.Version)
+	}
+	for _, record := range data.Connections {
+		cc, err := record.clientConnection()
+		if err != nil {
+			return nil, err
+		}
+		connections[cc.ConnectionID] = cc
+	}
+	return connections, nil
+}
+
+// Delete - removes stored snapshot
+func (s *Store) Delete() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_ = os.Remove(s.file)
+}
+
+func writeFileSync(file string, bytes []byte) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", file)
+	}
+	if _, err = f.Write(bytes); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", file)
+	}
+	return nil
+}
+
+func newConnectionRecord(cc *model.ClientConnection) (*connectionRecord, error) {
+	record := &connectionRecord{
+		ConnectionID:            cc.ConnectionID,
+		ForwarderRegisteredName: cc.ForwarderRegisteredName,
+		ConnectionState:         cc.ConnectionState,
+		ForwarderState:          cc.ForwarderState,
+	}
+	var err error
+	if cc.Request != nil {
+		if record.Request, err = marshal(cc.Request); err != nil {
+			return nil, err
+		}
+	}
+	if cc.Xcon != nil {
+		if record.Xcon, err = marshal(cc.Xcon); err != nil {
+			return nil, err
+		}
+	}
+	if cc.RemoteNsm != nil {
+		if record.RemoteNsm, err = marshal(cc.RemoteNsm); err != nil {
+			return nil, err
+		}
+	}
+	if cc.Endpoint != nil {
+		if record.Endpoint, err = marshal(cc.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+func (r *connectionRecord) clientConnection() (*model.ClientConnection, error) {
+	cc := &model.ClientConnection{
+		ConnectionID:            r.ConnectionID,
+		ForwarderRegisteredName: r.ForwarderRegisteredName,
+		ConnectionState:         r.ConnectionState,
+		ForwarderState:          r.ForwarderState,
+	}
+	if r.Request != nil {
+		cc.Request = &networkservice.NetworkServiceRequest{}
+		if err := unmarshal(r.Request, cc.Request); err != nil {
+			return nil, err
+		}
+	}
+	if r.Xcon != nil {
+		cc.Xcon = &crossconnect.CrossConnect{}
+		if err := unmarshal(r.Xcon, cc.Xcon); err != nil {
+			return nil, err
+		}
+	}
+	if r.RemoteNsm != nil {
+		cc.RemoteNsm = &registry.NetworkServiceManager{}
+		if err := unmarshal(r.RemoteNsm, cc.RemoteNsm); err != nil {
+			return nil, err
+		}
+	}
+	if r.Endpoint != nil {
+		cc.Endpoint = &registry.NSERegistration{}
+		if err := unmarshal(r.Endpoint, cc.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return cc, nil
+}
+
+func marshal(message proto.Message) ([]byte, error) {
+	bytes, err := proto.Marshal(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize connection")
+	}
+	if bytes == nil {
+		// Empty message should not be restored as nil one
+		bytes = []byte{}
+	}
+	return bytes, nil
+}
+
+func unmarshal(bytes []byte, message proto.Message) error {
+	if err := proto.Unmarshal(bytes, message); err != nil {
+		return errors.Wrap(err, "failed to deserialize connection")
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectionstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func newTestStore(g *WithT) (*Store, string, func()) {
+	dir, err := ioutil.TempDir("", "connectionstore")
+	g.Expect(err).To(BeNil())
+	file := filepath.Join(dir, "connections.snapshot")
+	return NewStore(file), file, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	g := NewWithT(t)
+	store, _, cleanup := newTestStore(g)
+	defer cleanup()
+
+	local := &model.ClientConnection{
+		ConnectionID: "1",
+		Request: &networkservice.NetworkServiceRequest{
+			Connection: &connection.Connection{
+				Id:             "1",
+				NetworkService: "golden-network",
+				Labels:         map[string]string{"app": "client"},
+			},
+		},
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &connection.Connection{
+				Id: "1",
+			},
+		},
+		Endpoint: &registry.NSERegistration{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"},
+		},
+		ForwarderRegisteredName: "forwarder",
+		ConnectionState:         model.ClientConnectionHealing,
+		ForwarderState:          model.ForwarderStateReady,
+	}
+	remote := &model.ClientConnection{
+		ConnectionID: "2",
+		RemoteNsm:    &registry.NetworkServiceManager{Name: "nsm-2"},
+	}
+	g.Expect(store.Save([]*model.ClientConnection{local, remote})).To(Succeed())
+
+	connections, err := store.Load()
+	g.Expect(err).To(BeNil())
+	g.Expect(connections).To(HaveLen(2))
+
+	restored := connections["1"]
+	g.Expect(proto.Equal(restored.Request, local.Request)).To(BeTrue())
+	g.Expect(proto.Equal(restored.Xcon, local.Xcon)).To(BeTrue())
+	g.Expect(proto.Equal(restored.Endpoint, local.Endpoint)).To(BeTrue())
+	g.Expect(restored.RemoteNsm).To(BeNil())
+	g.Expect(restored.ForwarderRegisteredName).To(Equal("forwarder"))
+	g.Expect(restored.ConnectionState).To(Equal(model.ClientConnectionHealing))
+	g.Expect(restored.ForwarderState).To(Equal(model.ForwarderStateReady))
+
+	restored = connections["2"]
+	g.Expect(restored.Request).To(BeNil())
+	g.Expect(restored.RemoteNsm.GetName()).To(Equal("nsm-2"))
+
+	// Next snapshot replaces the previous one
+	g.Expect(store.Save([]*model.ClientConnection{remote})).To(Succeed())
+	connections, err = store.Load()
+	g.Expect(err).To(BeNil())
+	g.Expect(connections).To(HaveLen(1))
+	g.Expect(connections).To(HaveKey("2"))
+}
+
+func TestStore_LoadMissing(t *testing.T) {
+	g := NewWithT(t)
+	store, _, cleanup := newTestStore(g)
+	defer cleanup()
+
+	connections, err := store.Load()
+	g.Expect(err).To(BeNil())
+	g.Expect(connections).To(BeEmpty())
+
+	g.Expect(store.Save(nil)).To(Succeed())
+	store.Delete()
+	connections, err = store.Load()
+	g.Expect(err).To(BeNil())
+	g.Expect(connections).To(BeEmpty())
+}
+
+func TestStore_LoadCorrupted(t *testing.T) {
+	g := NewWithT(t)
+	store, file, cleanup := newTestStore(g)
+	defer cleanup()
+
+	g.Expect(ioutil.WriteFile(file, []byte("{\"Version\": 1, \"Conn"), 0600)).To(Succeed())
+	_, err := store.Load()
+	g.Expect(err).NotTo(BeNil())
+
+	g.Expect(ioutil.WriteFile(file, []byte("{\"Version\": 100}"), 0600)).To(Succeed())
+	_, err = store.Load()
+	g.Expect(err).NotTo(BeNil())
+}
@@ -36,6 +36,7 @@ import (
 // ConnectionService makes basic Mechanism selection for the incoming connection
 type endpointSelectorService struct {
 	nseManager nsm.NetworkServiceEndpointManager
+	model      model.Model
 }
 
 func (cce *endpointSelectorService) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
@@ -92,6 +93,10 @@ func (cce *endpointSelectorService) Request(ctx context.Context, request *networ
 			logger.Errorf("NSM:(7.1.8) NSE respond with error: %v ", err)
 			lastError = err
 			ignoreEndpoints[endpoint.GetEndpointNSMName()] = endpoint
+			// Failure caused by our own timeout does not count against NSE
+			if parentCtx.Err() == nil {
+				cce.model.EndpointRequestFailed(ctx, endpoint)
+			}
 			span.Finish()
 			continue
 		}
@@ -101,6 +106,7 @@ func (cce *endpointSelectorService) Request(ctx context.Context, request *networ
 			clientConnection.RemoteNsm = endpoint.GetNetworkServiceManager()
 		}
 		// 7.1.9 We are fine with NSE connection and could continue.
+		cce.model.EndpointRequestSucceeded(ctx, endpoint.GetEndpointNSMName())
 		span.Finish()
 		return conn, nil
 	}
@@ -118,8 +124,9 @@ func (cce *endpointSelectorService) selectEndpoint(ctx context.Context, clientCo
 	var endpoint *registry.NSERegistration
 	if clientConnection.ConnectionState == model.ClientConnectionHealing {
 		// 7.1.2 Check previous endpoint, and it we will be able to contact it, it should be fine.
+		// Quarantined endpoint is treated as ignored one.
 		endpointName := clientConnection.Endpoint.GetEndpointNSMName()
-		if clientConnection.Endpoint != nil && ignoreEndpoints[endpointName] == nil {
+		if clientConnection.Endpoint != nil && ignoreEndpoints[endpointName] == nil && cce.model.AcquireEndpointTrial(ctx, endpointName) {
 			endpoint = clientConnection.Endpoint
 		} else {
 			// Ignored, we need to update DSTid.
@@ -276,8 +283,9 @@ func (cce *endpointSelectorService) checkTimeout(ctx context.Context, span spanh
 }
 
 // NewEndpointSelectorService - creates a service to select endpoint
-func NewEndpointSelectorService(nseManager nsm.NetworkServiceEndpointManager) networkservice.NetworkServiceServer {
+func NewEndpointSelectorService(nseManager nsm.NetworkServiceEndpointManager, model model.Model) networkservice.NetworkServiceServer {
 	return &endpointSelectorService{
 		nseManager: nseManager,
+		model:      model,
 	}
 }
@@ -17,18 +17,21 @@ package local
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
@@ -53,15 +56,10 @@ type forwarderService struct {
 }
 
 func (cce *forwarderService) selectForwarder(request *networkservice.NetworkServiceRequest) (*model.Forwarder, error) {
-	dp, err := cce.model.SelectForwarder(func(dp *model.Forwarder) bool {
-		for _, m := range request.GetRequestMechanismPreferences() {
-			if cce.findMechanism(dp.LocalMechanisms, m.GetType()) != nil {
-				return true
-			}
-		}
-		return false
+	return cce.model.SelectForwarderFor(&model.ForwarderRequest{
+		NetworkService: request.GetConnection().GetNetworkService(),
+		Mechanisms:     request.GetRequestMechanismPreferences(),
 	})
-	return dp, err
 }
 
 func (cce *forwarderService) findMechanism(mechanismPreferences []*connection.Mechanism, mechanismType string) *connection.Mechanism {
@@ -76,7 +74,8 @@ func (cce *forwarderService) findMechanism(mechanismPreferences []*connection.Me
 func (cce *forwarderService) updateMechanism(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) error {
 	conn := request.GetConnection()
 	// 5.x
-	for _, m := range request.GetRequestMechanismPreferences() {
+	policy := cce.model.GetForwarderPolicy(conn.GetNetworkService())
+	for _, m := range policy.OrderMechanisms(request.GetRequestMechanismPreferences()) {
 		if dpMechanism := cce.findMechanism(dp.LocalMechanisms, m.GetType()); dpMechanism != nil {
 			conn.Mechanism = m.Clone()
 			break
@@ -99,6 +98,7 @@ func (cce *forwarderService) Request(ctx context.Context, request *networkservic
 	span := spanhelper.GetSpanHelper(ctx)
 
 	clientConnection := common.ModelConnection(ctx)
+	previous := cce.makeBeforeBreakState(ctx, clientConnection)
 	// 3. get forwarder
 	if err := cce.serviceRegistry.WaitForForwarderAvailable(ctx, cce.model, ForwarderTimeout); err != nil {
 		logger.Errorf("Error waiting for forwarder: %v", err)
@@ -125,11 +125,70 @@ func (cce *forwarderService) Request(ctx context.Context, request *networkservic
 	if connErr != nil {
 		return conn, connErr
 	}
+	if previous != nil {
+		previous.updatePath(clientConnection, dp)
+	}
 
 	// We need to program forwarder.
 	return cce.programForwarder(ctx, conn, dp, clientConnection)
 }
 
+// makeBeforeBreakState holds the path of healing connection, which is still used by client while the new one is programmed
+type makeBeforeBreakState struct {
+	endpoint      *registry.NSERegistration
+	mechanismType string
+	interfaceName string
+	xconID        string
+	forwarder     string
+}
+
+func (cce *forwarderService) makeBeforeBreakState(ctx context.Context, clientConnection *model.ClientConnection) *makeBeforeBreakState {
+	if !common.MakeBeforeBreak(ctx) || clientConnection == nil ||
+		clientConnection.ConnectionState != model.ClientConnectionHealing ||
+		clientConnection.ForwarderState != model.ForwarderStateReady {
+		return nil
+	}
+	mechanism := clientConnection.Xcon.GetSource().GetMechanism()
+	interfaceName, ok := mechanism.GetParameters()[mechanismCommon.InterfaceNameKey]
+	if !ok {
+		return nil
+	}
+	return &makeBeforeBreakState{
+		endpoint:      clientConnection.Endpoint,
+		mechanismType: mechanism.GetType(),
+		interfaceName: interfaceName,
+		xconID:        clientConnection.Xcon.GetId(),
+		forwarder:     clientConnection.ForwarderRegisteredName,
+	}
+}
+
+// updatePath keeps the cross connect id and the interface name of the previous path if endpoint and forwarder are the
+// same, otherwise selects other ones, so forwarder creates the new path next to the previous one
+func (s *makeBeforeBreakState) updatePath(clientConnection *model.ClientConnection, dp *model.Forwarder) {
+	mechanism := clientConnection.Xcon.GetSource().GetMechanism()
+	if mechanism.GetType() != s.mechanismType || mechanism.GetParameters() == nil {
+		return
+	}
+	if s.endpoint.GetEndpointNSMName() == clientConnection.Endpoint.GetEndpointNSMName() && s.forwarder == dp.RegisteredName {
+		mechanism.GetParameters()[mechanismCommon.InterfaceNameKey] = s.interfaceName
+		return
+	}
+	mechanism.GetParameters()[mechanismCommon.InterfaceNameKey] = MakeBeforeBreakInterfaceName(s.interfaceName)
+	clientConnection.Xcon.Id = model.MakeBeforeBreakXconID(s.xconID)
+}
+
+// MakeBeforeBreakInterfaceName - returns an interface name differing from name, it toggles model.MakeBeforeBreakSuffix
+// so the names of the previous and the new paths alternate on every heal
+func MakeBeforeBreakInterfaceName(name string) string {
+	if strings.HasSuffix(name, model.MakeBeforeBreakSuffix) {
+		return strings.TrimSuffix(name, model.MakeBeforeBreakSuffix)
+	}
+	if maxLength := kernel.LinuxIfMaxLength - len(model.MakeBeforeBreakSuffix); len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return name + model.MakeBeforeBreakSuffix
+}
+
 // prepareRemoteMechanisms fills mechanism properties
 func (cce *forwarderService) prepareRemoteMechanisms(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) []*connection.Mechanism {
 	mechanisms := []*connection.Mechanism{}
@@ -140,6 +199,10 @@ func (cce *forwarderService) prepareRemoteMechanisms(request *networkservice.Net
 		case srv6.MECHANISM:
 			cce.prepareSRv6Mechanism(m, request)
 		case wireguard.MECHANISM:
+			if _, ok := m.GetParameters()[wireguard.SrcPublicKey]; !ok {
+				logrus.Warnf("NSM: Forwarder %s does not advertise its %s public key, mechanism skipped", dp.RegisteredName, m.GetType())
+				continue
+			}
 			cce.prepareWireguardMechanism(m, request)
 		}
 		mechanisms = append(mechanisms, m)
@@ -165,14 +228,7 @@ func (cce *forwarderService) prepareWireguardMechanism(m *connection.Mechanism,
 		parameters = map[string]string{}
 	}
 
-	key, err := wgtypes.GeneratePrivateKey()
-	if err != nil {
-		return m
-	}
-
-	parameters[wireguard.SrcPrivateKey] = key.String()
-	parameters[wireguard.SrcPublicKey] = key.PublicKey().String()
-
+	// The public key is advertised by the forwarder keeping the private one
 	parameters[wireguard.SrcPort] = wireguard.AssignPort(request.Connection.Id)
 
 	return m
@@ -12,6 +12,52 @@ type ModificationHandler struct {
 	AddFunc    func(ctx context.Context, new interface{})
 	UpdateFunc func(ctx context.Context, old interface{}, new interface{})
 	DeleteFunc func(ctx context.Context, del interface{})
+
+	// queue - if set, handlers are called one by one in the order of events instead of in their own goroutines
+	queue *eventQueue
+}
+
+// eventQueue - calls pushed functions one by one in the order they are pushed, push never blocks
+type eventQueue struct {
+	mtx     sync.Mutex
+	events  []func()
+	running bool
+}
+
+func (q *eventQueue) push(f func()) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.events = append(q.events, f)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+func (q *eventQueue) run() {
+	for {
+		q.mtx.Lock()
+		if len(q.events) == 0 {
+			q.running = false
+			q.mtx.Unlock()
+			return
+		}
+		f := q.events[0]
+		q.events[0] = nil
+		q.events = q.events[1:]
+		q.mtx.Unlock()
+
+		f()
+	}
+}
+
+func (h *ModificationHandler) dispatch(f func()) {
+	if h.queue != nil {
+		h.queue.push(f)
+		return
+	}
+	go f()
 }
 
 type cloneable interface {
@@ -99,9 +145,7 @@ func (b *baseDomain) resourceAdded(ctx context.Context, new cloneable) {
 	logrus.Infof("resourceAdded started: %v", new)
 
 	for _, h := range b.handlers {
-		if h.AddFunc != nil {
-			go h.AddFunc(ctx, new.clone())
-		}
+		h.added(ctx, new)
 	}
 	logrus.Infof("resourceAdded finished: %v", new)
 }
@@ -111,7 +155,9 @@ func (b *baseDomain) resourceUpdated(ctx context.Context, old, new cloneable) {
 
 	for _, h := range b.handlers {
 		if h.UpdateFunc != nil {
-			go h.UpdateFunc(ctx, old.clone(), new.clone())
+			h := h
+			old, new := old.clone(), new.clone()
+			h.dispatch(func() { h.UpdateFunc(ctx, old, new) })
 		}
 	}
 	logrus.Infof("resourceUpdated finished: %v", new)
@@ -122,20 +168,30 @@ func (b *baseDomain) resourceDeleted(ctx context.Context, del cloneable) {
 
 	for _, h := range b.handlers {
 		if h.DeleteFunc != nil {
-			go h.DeleteFunc(ctx, del.clone())
+			h := h
+			del := del.clone()
+			h.dispatch(func() { h.DeleteFunc(ctx, del) })
 		}
 	}
 	logrus.Infof("resourceDeleted finished: %v", del)
 }
 
+func (h *ModificationHandler) added(ctx context.Context, new cloneable) {
+	if h.AddFunc != nil {
+		new := new.clone()
+		h.dispatch(func() { h.AddFunc(ctx, new) })
+	}
+}
+
 func (b *baseDomain) addHandler(h *ModificationHandler) func() {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 
 	b.handlers = append(b.handlers, h)
 
+	// Existing resources are replayed to the added handler only, other handlers have already seen them.
 	for _, v := range b.innerMap {
-		b.resourceAdded(context.Background(), v)
+		h.added(context.Background(), v)
 	}
 
 	return func() {
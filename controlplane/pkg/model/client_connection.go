@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 
@@ -36,6 +37,24 @@ const (
 	ClientConnectionClosing ClientConnectionState = 5
 )
 
+// MakeBeforeBreakSuffix - a suffix toggled on the cross connect id and the interface name of the path programmed
+// next to the previous one by make-before-break heal
+const MakeBeforeBreakSuffix = "-mbb"
+
+// MakeBeforeBreakXconID - returns a cross connect id differing from id, it toggles MakeBeforeBreakSuffix so the ids
+// of the previous and the new paths alternate on every heal
+func MakeBeforeBreakXconID(id string) string {
+	if strings.HasSuffix(id, MakeBeforeBreakSuffix) {
+		return strings.TrimSuffix(id, MakeBeforeBreakSuffix)
+	}
+	return id + MakeBeforeBreakSuffix
+}
+
+// XconConnectionID - returns id of the client connection the cross connect with id belongs to
+func XconConnectionID(id string) string {
+	return strings.TrimSuffix(id, MakeBeforeBreakSuffix)
+}
+
 // ClientConnection struct in model that describes cross connect between NetworkServiceClient and NetworkServiceEndpoint
 type ClientConnection struct {
 	ConnectionID            string
@@ -150,6 +169,32 @@ func (d *clientConnectionDomain) GetAllClientConnections() []*ClientConnection {
 	return rv
 }
 
+// EndpointConnections returns the number of not closing client connections per network service endpoint name
+func (d *clientConnectionDomain) EndpointConnections() map[string]int {
+	rv := map[string]int{}
+	d.kvRange(func(_ string, value interface{}) bool {
+		cc := value.(*ClientConnection)
+		if cc.ConnectionState != ClientConnectionClosing {
+			rv[cc.Endpoint.GetNetworkServiceEndpoint().GetName()]++
+		}
+		return true
+	})
+	return rv
+}
+
+// ForwarderConnections returns the number of not closing client connections per forwarder registered name
+func (d *clientConnectionDomain) ForwarderConnections() map[string]int {
+	rv := map[string]int{}
+	d.kvRange(func(_ string, value interface{}) bool {
+		cc := value.(*ClientConnection)
+		if cc.ConnectionState != ClientConnectionClosing && cc.ForwarderRegisteredName != "" {
+			rv[cc.ForwarderRegisteredName]++
+		}
+		return true
+	})
+	return rv
+}
+
 func (d *clientConnectionDomain) DeleteClientConnection(ctx context.Context, connectionID string) {
 	d.delete(ctx, connectionID)
 }
@@ -280,3 +280,43 @@ func TestApplyChanges(t *testing.T) {
 	upd := ccd.GetClientConnection("1")
 	g.Expect(upd.RemoteNsm.Name).To(Equal("updatedMaster"))
 }
+
+func TestEndpointConnections(t *testing.T) {
+	g := NewWithT(t)
+
+	ccd := newClientConnectionDomain()
+	states := []ClientConnectionState{ClientConnectionReady, ClientConnectionHealing, ClientConnectionClosing}
+	for i, state := range states {
+		ccd.AddClientConnection(context.Background(), &ClientConnection{
+			ConnectionID: strconv.Itoa(i),
+			Endpoint: &registry.NSERegistration{
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+					Name: "endp1",
+				},
+			},
+			ConnectionState: state,
+		})
+	}
+	ccd.AddClientConnection(context.Background(), &ClientConnection{
+		ConnectionID: "endp2-connection",
+		Endpoint: &registry.NSERegistration{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+				Name: "endp2",
+			},
+		},
+	})
+
+	g.Expect(ccd.EndpointConnections()).To(Equal(map[string]int{
+		"endp1": 2,
+		"endp2": 1,
+	}))
+}
+
+func TestMakeBeforeBreakXconID(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(MakeBeforeBreakXconID("1")).To(Equal("1-mbb"))
+	g.Expect(MakeBeforeBreakXconID("1-mbb")).To(Equal("1"))
+	g.Expect(XconConnectionID("1-mbb")).To(Equal("1"))
+	g.Expect(XconConnectionID("1")).To(Equal("1"))
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// CircuitState - state of endpoint circuit breaker
+type CircuitState int8
+
+const (
+	// CircuitClosed - endpoint is selected as usual
+	CircuitClosed CircuitState = iota
+	// CircuitOpen - endpoint failed too many requests and is excluded from selection
+	CircuitOpen
+	// CircuitHalfOpen - cooldown is over, a single trial request is allowed to the endpoint
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreakerConfig - configures when endpoint is quarantined, zero FailureThreshold disables circuit breaker
+type CircuitBreakerConfig struct {
+	// FailureThreshold - amount of consecutive failed requests opening circuit
+	FailureThreshold int
+	// FailureWindow - failures are counted only if they happen within the window from the first one
+	FailureWindow time.Duration
+	// Cooldown - time circuit is open before a trial request is allowed
+	Cooldown time.Duration
+}
+
+// EndpointCircuit - structure in Model that describes consecutive request failures of network service endpoint
+type EndpointCircuit struct {
+	EndpointName   registry.EndpointNSMName
+	NetworkService string
+	State          CircuitState
+	Failures       int
+	FirstFailure   time.Time
+	// Since - time of the last state change
+	Since time.Time
+}
+
+func (c *EndpointCircuit) clone() cloneable {
+	if c == nil {
+		return nil
+	}
+	rv := *c
+	return &rv
+}
+
+type circuitDomain struct {
+	baseDomain
+	circuitMtx sync.Mutex
+	config     CircuitBreakerConfig
+	now        func() time.Time
+}
+
+func newCircuitDomain() circuitDomain {
+	return circuitDomain{
+		baseDomain: newBase(),
+		now:        time.Now,
+	}
+}
+
+// SetCircuitBreakerConfig - configures circuit breaker of endpoints
+func (d *circuitDomain) SetCircuitBreakerConfig(config CircuitBreakerConfig) {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	d.config = config
+}
+
+// GetEndpointCircuit - returns circuit of endpoint, nil if endpoint has no failures
+func (d *circuitDomain) GetEndpointCircuit(name registry.EndpointNSMName) *EndpointCircuit {
+	v, _ := d.load(string(name))
+	if v != nil {
+		return v.(*EndpointCircuit)
+	}
+	return nil
+}
+
+// GetEndpointCircuits - returns circuits of all endpoints with failures
+func (d *circuitDomain) GetEndpointCircuits() []*EndpointCircuit {
+	var rv []*EndpointCircuit
+	d.kvRange(func(key string, value interface{}) bool {
+		rv = append(rv, value.(*EndpointCircuit))
+		return true
+	})
+	return rv
+}
+
+// EndpointRequestFailed - counts failed request to endpoint, opens circuit if there are too many of them
+func (d *circuitDomain) EndpointRequestFailed(ctx context.Context, endpoint *registry.NSERegistration) {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	if d.config.FailureThreshold <= 0 {
+		return
+	}
+
+	now := d.now()
+	name := endpoint.GetEndpointNSMName()
+	circuit := d.GetEndpointCircuit(name)
+	if circuit == nil {
+		circuit = &EndpointCircuit{
+			EndpointName:   name,
+			NetworkService: endpoint.GetNetworkServiceEndpoint().GetNetworkServiceName(),
+			State:          CircuitClosed,
+			Since:          now,
+		}
+	}
+
+	switch circuit.State {
+	case CircuitClosed:
+		if circuit.Failures == 0 || now.Sub(circuit.FirstFailure) > d.config.FailureWindow {
+			circuit.Failures = 0
+			circuit.FirstFailure = now
+		}
+		circuit.Failures++
+		if circuit.Failures >= d.config.FailureThreshold {
+			d.open(circuit, now)
+		}
+	default:
+		circuit.Failures++
+		d.open(circuit, now)
+	}
+	d.store(ctx, string(name), circuit)
+}
+
+func (d *circuitDomain) open(circuit *EndpointCircuit, now time.Time) {
+	logrus.Warnf("Opening circuit of endpoint %v after %v failed requests", circuit.EndpointName, circuit.Failures)
+	circuit.State = CircuitOpen
+	circuit.Since = now
+}
+
+// EndpointRequestSucceeded - closes circuit of endpoint
+func (d *circuitDomain) EndpointRequestSucceeded(ctx context.Context, name registry.EndpointNSMName) {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	if d.GetEndpointCircuit(name) != nil {
+		d.delete(ctx, string(name))
+	}
+}
+
+// IsEndpointAvailable - returns false if endpoint should be excluded from selection, endpoint with circuit open
+// or half-open is available again after cooldown, but it has to acquire a trial once it is selected
+func (d *circuitDomain) IsEndpointAvailable(name registry.EndpointNSMName) bool {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	return d.isAvailable(d.GetEndpointCircuit(name), d.now())
+}
+
+func (d *circuitDomain) isAvailable(circuit *EndpointCircuit, now time.Time) bool {
+	return circuit == nil || circuit.State == CircuitClosed || now.Sub(circuit.Since) >= d.config.Cooldown
+}
+
+// AcquireEndpointTrial - returns true if request to the selected endpoint is allowed, circuit is half-opened and
+// a single trial is allowed after cooldown
+func (d *circuitDomain) AcquireEndpointTrial(ctx context.Context, name registry.EndpointNSMName) bool {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	now := d.now()
+	circuit := d.GetEndpointCircuit(name)
+	if !d.isAvailable(circuit, now) {
+		return false
+	}
+	if circuit == nil || circuit.State == CircuitClosed {
+		return true
+	}
+	// Open circuit is half-opened after cooldown, half-open circuit allows another trial if the previous one
+	// has not been reported within cooldown.
+	circuit.State = CircuitHalfOpen
+	circuit.Since = now
+	d.store(ctx, string(name), circuit)
+	return true
+}
+
+// DeleteEndpointCircuit - deletes circuit of endpoint, which is not registered anymore
+func (d *circuitDomain) DeleteEndpointCircuit(ctx context.Context, name registry.EndpointNSMName) {
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	if d.GetEndpointCircuit(name) != nil {
+		d.delete(ctx, string(name))
+	}
+}
+
+// PruneEndpointCircuits - deletes circuits of network service endpoints, which are not registered anymore
+func (d *circuitDomain) PruneEndpointCircuits(ctx context.Context, networkService string, endpoints []registry.EndpointNSMName) {
+	registered := map[registry.EndpointNSMName]bool{}
+	for _, name := range endpoints {
+		registered[name] = true
+	}
+
+	d.circuitMtx.Lock()
+	defer d.circuitMtx.Unlock()
+
+	for _, circuit := range d.GetEndpointCircuits() {
+		if circuit.NetworkService == networkService && !registered[circuit.EndpointName] {
+			logrus.Infof("Deleting circuit of not registered endpoint %v", circuit.EndpointName)
+			d.delete(ctx, string(circuit.EndpointName))
+		}
+	}
+}
+
+// SetEndpointCircuitModificationHandler - sets handler of endpoint circuit changes
+func (d *circuitDomain) SetEndpointCircuitModificationHandler(h *ModificationHandler) func() {
+	return d.addHandler(h)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestEndpoint(name, networkService string) *registry.NSERegistration {
+	return &registry.NSERegistration{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name:               name,
+			NetworkServiceName: networkService,
+		},
+		NetworkServiceManager: &registry.NetworkServiceManager{
+			Url: "nsm1",
+		},
+	}
+}
+
+func newTestCircuitDomain(clock *testClock) *circuitDomain {
+	cd := newCircuitDomain()
+	cd.now = clock.Now
+	cd.SetCircuitBreakerConfig(CircuitBreakerConfig{
+		FailureThreshold: 3,
+		FailureWindow:    time.Minute,
+		Cooldown:         30 * time.Second,
+	})
+	return &cd
+}
+
+func TestEndpointCircuit_Opens(t *testing.T) {
+	g := NewWithT(t)
+	clock := &testClock{now: time.Unix(0, 0)}
+	cd := newTestCircuitDomain(clock)
+	endpoint := newTestEndpoint("nse1", "ns1")
+	name := endpoint.GetEndpointNSMName()
+	ctx := context.Background()
+
+	cd.EndpointRequestFailed(ctx, endpoint)
+	cd.EndpointRequestFailed(ctx, endpoint)
+	g.Expect(cd.GetEndpointCircuit(name).State).To(Equal(CircuitClosed))
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeTrue())
+
+	cd.EndpointRequestFailed(ctx, endpoint)
+	g.Expect(cd.GetEndpointCircuit(name).State).To(Equal(CircuitOpen))
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeFalse())
+	g.Expect(cd.GetEndpointCircuits()).To(HaveLen(1))
+}
+
+func TestEndpointCircuit_FailuresOutsideWindow(t *testing.T) {
+	g := NewWithT(t)
+	clock := &testClock{now: time.Unix(0, 0)}
+	cd := newTestCircuitDomain(clock)
+	endpoint := newTestEndpoint("nse1", "ns1")
+	name := endpoint.GetEndpointNSMName()
+	ctx := context.Background()
+
+	cd.EndpointRequestFailed(ctx, endpoint)
+	cd.EndpointRequestFailed(ctx, endpoint)
+	clock.now = clock.now.Add(2 * time.Minute)
+	cd.EndpointRequestFailed(ctx, endpoint)
+
+	circuit := cd.GetEndpointCircuit(name)
+	g.Expect(circuit.State).To(Equal(CircuitClosed))
+	g.Expect(circuit.Failures).To(Equal(1))
+}
+
+func TestEndpointCircuit_HalfOpen(t *testing.T) {
+	g := NewWithT(t)
+	clock := &testClock{now: time.Unix(0, 0)}
+	cd := newTestCircuitDomain(clock)
+	endpoint := newTestEndpoint("nse1", "ns1")
+	name := endpoint.GetEndpointNSMName()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		cd.EndpointRequestFailed(ctx, endpoint)
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	// Availability check does not take the trial.
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeTrue())
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeTrue())
+	g.Expect(cd.GetEndpointCircuit(name).State).To(Equal(CircuitOpen))
+
+	g.Expect(cd.AcquireEndpointTrial(ctx, name)).To(BeTrue())
+	g.Expect(cd.GetEndpointCircuit(name).State).To(Equal(CircuitHalfOpen))
+	// Only a single trial is allowed.
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeFalse())
+	g.Expect(cd.AcquireEndpointTrial(ctx, name)).To(BeFalse())
+
+	// Failed trial opens circuit again.
+	cd.EndpointRequestFailed(ctx, endpoint)
+	g.Expect(cd.GetEndpointCircuit(name).State).To(Equal(CircuitOpen))
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeFalse())
+
+	// Succeeded trial closes circuit.
+	clock.now = clock.now.Add(30 * time.Second)
+	g.Expect(cd.AcquireEndpointTrial(ctx, name)).To(BeTrue())
+	cd.EndpointRequestSucceeded(ctx, name)
+	g.Expect(cd.GetEndpointCircuit(name)).To(BeNil())
+	g.Expect(cd.IsEndpointAvailable(name)).To(BeTrue())
+}
+
+func TestEndpointCircuit_Prune(t *testing.T) {
+	g := NewWithT(t)
+	clock := &testClock{now: time.Unix(0, 0)}
+	cd := newTestCircuitDomain(clock)
+	ctx := context.Background()
+
+	nse1 := newTestEndpoint("nse1", "ns1")
+	nse2 := newTestEndpoint("nse2", "ns1")
+	nse3 := newTestEndpoint("nse3", "ns2")
+	for _, endpoint := range []*registry.NSERegistration{nse1, nse2, nse3} {
+		cd.EndpointRequestFailed(ctx, endpoint)
+	}
+
+	// Circuits of other network services are kept
+	cd.PruneEndpointCircuits(ctx, "ns1", []registry.EndpointNSMName{nse2.GetEndpointNSMName()})
+	g.Expect(cd.GetEndpointCircuit(nse1.GetEndpointNSMName())).To(BeNil())
+	g.Expect(cd.GetEndpointCircuit(nse2.GetEndpointNSMName())).NotTo(BeNil())
+	g.Expect(cd.GetEndpointCircuit(nse3.GetEndpointNSMName())).NotTo(BeNil())
+
+	cd.DeleteEndpointCircuit(ctx, nse3.GetEndpointNSMName())
+	g.Expect(cd.GetEndpointCircuits()).To(HaveLen(1))
+}
+
+func TestModel_DeleteEndpointDeletesCircuit(t *testing.T) {
+	g := NewWithT(t)
+	m := NewModel()
+	m.SetCircuitBreakerConfig(CircuitBreakerConfig{FailureThreshold: 1})
+	endpoint := newTestEndpoint("nse1", "ns1")
+	m.AddEndpoint(context.Background(), &Endpoint{Endpoint: endpoint})
+
+	m.EndpointRequestFailed(context.Background(), endpoint)
+	g.Expect(m.GetEndpointCircuit(endpoint.GetEndpointNSMName())).NotTo(BeNil())
+
+	m.DeleteEndpoint(context.Background(), "nse1")
+	g.Expect(m.GetEndpointCircuit(endpoint.GetEndpointNSMName())).To(BeNil())
+}
+
+func TestEndpointCircuit_Disabled(t *testing.T) {
+	g := NewWithT(t)
+	cd := newCircuitDomain()
+	endpoint := newTestEndpoint("nse1", "ns1")
+
+	for i := 0; i < 10; i++ {
+		cd.EndpointRequestFailed(context.Background(), endpoint)
+	}
+	g.Expect(cd.GetEndpointCircuit(endpoint.GetEndpointNSMName())).To(BeNil())
+	g.Expect(cd.IsEndpointAvailable(endpoint.GetEndpointNSMName())).To(BeTrue())
+}
@@ -102,6 +102,8 @@ func TestGetEndpointsByNs(t *testing.T) {
 	for i := 0; i < amount; i++ {
 		g.Expect(expected[i]).To(BeTrue())
 	}
+
+	g.Expect(ed.GetAllEndpoints()).To(HaveLen(amount))
 }
 
 func TestDeleteEndpoint(t *testing.T) {
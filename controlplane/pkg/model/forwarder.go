@@ -26,6 +26,34 @@ type Forwarder struct {
 	LocalMechanisms      []*connection.Mechanism
 	RemoteMechanisms     []*connection.Mechanism
 	MechanismsConfigured bool
+	// Draining is set while connections are migrated from the forwarder, no new connections are placed on it
+	Draining bool
+
+	// Implementation, Version and the rest are advertised by forwarder on registration
+	Implementation  string
+	Version         string
+	ProtocolVersion uint32
+	// Capabilities are mechanism types forwarder supports, empty if not advertised
+	Capabilities []*MechanismCapability
+	// MaxCrossConnects is a maximum amount of connections forwarder could serve, 0 means unlimited
+	MaxCrossConnects uint32
+	Labels           map[string]string
+	// LivenessToken is issued to forwarder on registration, it binds the liveness stream to the forwarder
+	LivenessToken string
+}
+
+// MechanismCapability describes mechanism type supported by forwarder
+type MechanismCapability struct {
+	Type       string
+	Remote     bool
+	Parameters []*MechanismParameter
+}
+
+// MechanismParameter describes parameter of mechanism supported by forwarder
+type MechanismParameter struct {
+	Name     string
+	Type     string
+	Required bool
 }
 
 // Clone returns pointer to copy of Forwarder
@@ -44,13 +72,79 @@ func (d *Forwarder) clone() cloneable {
 		rm = append(rm, m.Clone())
 	}
 
+	var capabilities []*MechanismCapability
+	for _, c := range d.Capabilities {
+		capability := &MechanismCapability{
+			Type:   c.Type,
+			Remote: c.Remote,
+		}
+		for _, p := range c.Parameters {
+			parameter := *p
+			capability.Parameters = append(capability.Parameters, &parameter)
+		}
+		capabilities = append(capabilities, capability)
+	}
+
+	var labels map[string]string
+	if d.Labels != nil {
+		labels = make(map[string]string, len(d.Labels))
+		for k, v := range d.Labels {
+			labels[k] = v
+		}
+	}
+
 	return &Forwarder{
 		RegisteredName:       d.RegisteredName,
 		SocketLocation:       d.SocketLocation,
 		LocalMechanisms:      lm,
 		RemoteMechanisms:     rm,
 		MechanismsConfigured: d.MechanismsConfigured,
+		Draining:             d.Draining,
+		Implementation:       d.Implementation,
+		Version:              d.Version,
+		ProtocolVersion:      d.ProtocolVersion,
+		Capabilities:         capabilities,
+		MaxCrossConnects:     d.MaxCrossConnects,
+		Labels:               labels,
+		LivenessToken:        d.LivenessToken,
+	}
+}
+
+// SupportsMechanism returns true if forwarder supports mechanism type, forwarders not advertising capabilities
+// are considered to support any mechanism they are configured with
+func (d *Forwarder) SupportsMechanism(mechanismType string, remote bool) bool {
+	if len(d.Capabilities) == 0 {
+		return true
 	}
+	for _, c := range d.Capabilities {
+		if c.Type == mechanismType && c.Remote == remote {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsConnections returns true if forwarder is configured with and supports mechanisms of all connections
+func (d *Forwarder) SupportsConnections(connections ...*connection.Connection) bool {
+	for _, conn := range connections {
+		if conn == nil {
+			continue
+		}
+		mechanisms := d.LocalMechanisms
+		if conn.IsRemote() {
+			mechanisms = d.RemoteMechanisms
+		}
+		mechanismType := conn.GetMechanism().GetType()
+		if findMechanism(mechanisms, mechanismType) == nil || !d.SupportsMechanism(mechanismType, conn.IsRemote()) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasCapacity returns true if forwarder serving connections could serve one more
+func (d *Forwarder) HasCapacity(connections int) bool {
+	return d.MaxCrossConnects == 0 || connections < int(d.MaxCrossConnects)
 }
 
 // SetLocalMechanisms sets forwarder local mechanisms
@@ -95,6 +189,15 @@ func (d *forwarderDomain) GetForwarder(name string) *Forwarder {
 	return nil
 }
 
+func (d *forwarderDomain) GetAllForwarders() []*Forwarder {
+	var rv []*Forwarder
+	d.kvRange(func(_ string, value interface{}) bool {
+		rv = append(rv, value.(*Forwarder))
+		return true
+	})
+	return rv
+}
+
 func (d *forwarderDomain) DeleteForwarder(ctx context.Context, name string) {
 	d.delete(ctx, name)
 }
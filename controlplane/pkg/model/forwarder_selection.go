@@ -0,0 +1,200 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+)
+
+// ForwarderPolicy - configures selection of forwarders for connections of a network service
+type ForwarderPolicy struct {
+	// Mechanisms - mechanism types in order of preference, requested mechanisms not listed here follow in the
+	// requested order
+	Mechanisms []string
+	// Priorities - administrative priority of forwarders by registered name, higher is preferred, default is 0
+	Priorities map[string]int
+	// Labels - labels forwarders are required to be registered with
+	Labels map[string]string
+}
+
+// ForwarderRequest - describes connection forwarder is selected for
+type ForwarderRequest struct {
+	NetworkService string
+	// Mechanisms - requested mechanisms in order of client preference
+	Mechanisms []*connection.Mechanism
+	// Remote - mechanisms are matched against remote mechanisms of forwarders instead of local ones
+	Remote bool
+}
+
+// OrderMechanisms - returns requested mechanisms ordered by policy preference
+func (p *ForwarderPolicy) OrderMechanisms(requested []*connection.Mechanism) []*connection.Mechanism {
+	rv := make([]*connection.Mechanism, 0, len(requested))
+	for _, mechanismType := range p.GetMechanisms() {
+		for _, m := range requested {
+			if m.GetType() == mechanismType {
+				rv = append(rv, m)
+			}
+		}
+	}
+	for _, m := range requested {
+		if !containsMechanismType(p.GetMechanisms(), m.GetType()) {
+			rv = append(rv, m)
+		}
+	}
+	return rv
+}
+
+// GetMechanisms - returns preferred mechanism types, nil for nil policy
+func (p *ForwarderPolicy) GetMechanisms() []string {
+	if p == nil {
+		return nil
+	}
+	return p.Mechanisms
+}
+
+// GetPriority - returns administrative priority of forwarder, 0 for nil policy
+func (p *ForwarderPolicy) GetPriority(forwarder string) int {
+	if p == nil {
+		return 0
+	}
+	return p.Priorities[forwarder]
+}
+
+// Matches - returns true if forwarder has all the labels required by policy, any forwarder matches nil policy
+func (p *ForwarderPolicy) Matches(forwarder *Forwarder) bool {
+	if p == nil {
+		return true
+	}
+	for k, v := range p.Labels {
+		if value, ok := forwarder.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// forwarderScore - forwarders are compared by the rank of the best supported mechanism, then by priority, then by
+// amount of connections, the name makes the selection deterministic
+type forwarderScore struct {
+	forwarder     *Forwarder
+	mechanismRank int
+	priority      int
+	connections   int
+}
+
+func (s *forwarderScore) betterThan(other *forwarderScore) bool {
+	if s.mechanismRank != other.mechanismRank {
+		return s.mechanismRank < other.mechanismRank
+	}
+	if s.priority != other.priority {
+		return s.priority > other.priority
+	}
+	if s.connections != other.connections {
+		return s.connections < other.connections
+	}
+	return s.forwarder.RegisteredName < other.forwarder.RegisteredName
+}
+
+// SetForwarderPolicies - replaces forwarder selection policies by network service, the policy of empty network
+// service is used for the rest of network services
+func (m *model) SetForwarderPolicies(policies map[string]*ForwarderPolicy) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.forwarderPolicies = policies
+}
+
+// GetForwarderPolicy - returns forwarder selection policy of network service, nil if there is no one
+func (m *model) GetForwarderPolicy(networkService string) *ForwarderPolicy {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if policy, ok := m.forwarderPolicies[networkService]; ok {
+		return policy
+	}
+	return m.forwarderPolicies[""]
+}
+
+// SelectForwarderFor - selects the best scored forwarder supporting any of requested mechanisms
+func (m *model) SelectForwarderFor(request *ForwarderRequest) (*Forwarder, error) {
+	scores := m.scoreForwarders(request)
+	if len(scores) == 0 {
+		return nil, errors.New("no appropriate forwarders found")
+	}
+	return scores[0].forwarder, nil
+}
+
+// scoreForwarders - returns not draining forwarders matching policy labels, having free capacity and supporting any
+// of requested mechanisms, the best one first
+func (m *model) scoreForwarders(request *ForwarderRequest) []*forwarderScore {
+	policy := m.GetForwarderPolicy(request.NetworkService)
+	mechanisms := policy.OrderMechanisms(request.Mechanisms)
+	connections := m.ForwarderConnections()
+
+	var scores []*forwarderScore
+	for _, forwarder := range m.GetAllForwarders() {
+		if forwarder.Draining || !policy.Matches(forwarder) || !forwarder.HasCapacity(connections[forwarder.RegisteredName]) {
+			continue
+		}
+		supported := forwarder.LocalMechanisms
+		if request.Remote {
+			supported = forwarder.RemoteMechanisms
+		}
+		rank := -1
+		for i, mechanism := range mechanisms {
+			if findMechanism(supported, mechanism.GetType()) != nil && forwarder.SupportsMechanism(mechanism.GetType(), request.Remote) {
+				rank = i
+				break
+			}
+		}
+		if rank < 0 {
+			continue
+		}
+		scores = append(scores, &forwarderScore{
+			forwarder:     forwarder,
+			mechanismRank: rank,
+			priority:      policy.GetPriority(forwarder.RegisteredName),
+			connections:   connections[forwarder.RegisteredName],
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].betterThan(scores[j])
+	})
+	return scores
+}
+
+func findMechanism(mechanisms []*connection.Mechanism, mechanismType string) *connection.Mechanism {
+	for _, m := range mechanisms {
+		if m.GetType() == mechanismType {
+			return m
+		}
+	}
+	return nil
+}
+
+func containsMechanismType(mechanismTypes []string, mechanismType string) bool {
+	for _, t := range mechanismTypes {
+		if t == mechanismType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/memif"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+)
+
+func newTestSelectionModel() Model {
+	m := NewModel()
+	m.AddForwarder(context.Background(), &Forwarder{
+		RegisteredName: "vppagent",
+		LocalMechanisms: []*connection.Mechanism{
+			{Type: memif.MECHANISM},
+			{Type: kernel.MECHANISM},
+		},
+		RemoteMechanisms: []*connection.Mechanism{
+			{Type: vxlan.MECHANISM},
+		},
+	})
+	m.AddForwarder(context.Background(), &Forwarder{
+		RegisteredName: "kernel",
+		LocalMechanisms: []*connection.Mechanism{
+			{Type: kernel.MECHANISM},
+		},
+		RemoteMechanisms: []*connection.Mechanism{
+			{Type: vxlan.MECHANISM},
+		},
+	})
+	return m
+}
+
+func mechanisms(types ...string) []*connection.Mechanism {
+	var rv []*connection.Mechanism
+	for _, t := range types {
+		rv = append(rv, &connection.Mechanism{Type: t})
+	}
+	return rv
+}
+
+func selectForwarderName(g *WithT, m Model, request *ForwarderRequest) string {
+	forwarder, err := m.SelectForwarderFor(request)
+	g.Expect(err).To(BeNil())
+	return forwarder.RegisteredName
+}
+
+func TestSelectForwarderForMechanismRank(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	for i := 0; i < 10; i++ {
+		g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+			Mechanisms: mechanisms(memif.MECHANISM, kernel.MECHANISM),
+		})).To(Equal("vppagent"))
+	}
+
+	// Policy preference order takes precedence over the requested one
+	m.SetForwarderPolicies(map[string]*ForwarderPolicy{
+		"": {Mechanisms: []string{kernel.MECHANISM}},
+	})
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(memif.MECHANISM, kernel.MECHANISM),
+	})).To(Equal("kernel"))
+
+	_, err := m.SelectForwarderFor(&ForwarderRequest{
+		Mechanisms: mechanisms("SRIOV_INTERFACE"),
+	})
+	g.Expect(err.Error()).To(ContainSubstring("no appropriate forwarders found"))
+}
+
+func TestSelectForwarderForPriority(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	// Equally scored forwarders are ordered by name
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		NetworkService: "golden-network",
+		Mechanisms:     mechanisms(kernel.MECHANISM),
+	})).To(Equal("kernel"))
+
+	m.SetForwarderPolicies(map[string]*ForwarderPolicy{
+		"golden-network": {Priorities: map[string]int{"vppagent": 1}},
+	})
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		NetworkService: "golden-network",
+		Mechanisms:     mechanisms(kernel.MECHANISM),
+	})).To(Equal("vppagent"))
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		NetworkService: "secure-intranet",
+		Mechanisms:     mechanisms(kernel.MECHANISM),
+	})).To(Equal("kernel"))
+
+	// Priority is applied to remote mechanisms as well
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		NetworkService: "golden-network",
+		Mechanisms:     mechanisms(vxlan.MECHANISM),
+		Remote:         true,
+	})).To(Equal("vppagent"))
+}
+
+func TestSelectForwarderForLoad(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	m.AddClientConnection(context.Background(), &ClientConnection{
+		ConnectionID:            "1",
+		ForwarderRegisteredName: "kernel",
+		ConnectionState:         ClientConnectionReady,
+	})
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(kernel.MECHANISM),
+	})).To(Equal("vppagent"))
+
+	// Closing connections are not counted
+	m.ApplyClientConnectionChanges(context.Background(), "1", func(cc *ClientConnection) {
+		cc.ConnectionState = ClientConnectionClosing
+	})
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(kernel.MECHANISM),
+	})).To(Equal("kernel"))
+}
+
+func TestForwarderPolicyOrderMechanisms(t *testing.T) {
+	g := NewWithT(t)
+
+	requested := mechanisms(memif.MECHANISM, vxlan.MECHANISM, kernel.MECHANISM)
+
+	var policy *ForwarderPolicy
+	g.Expect(policy.OrderMechanisms(requested)).To(Equal(requested))
+
+	policy = &ForwarderPolicy{Mechanisms: []string{kernel.MECHANISM, "SRIOV_INTERFACE"}}
+	g.Expect(policy.OrderMechanisms(requested)).To(Equal(mechanisms(kernel.MECHANISM, memif.MECHANISM, vxlan.MECHANISM)))
+}
+
+func TestSelectForwarderForSkipsDraining(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	vppagent := m.GetForwarder("vppagent")
+	vppagent.Draining = true
+	m.UpdateForwarder(context.Background(), vppagent)
+	g.Expect(m.GetForwarder("vppagent").Draining).To(BeTrue())
+
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(memif.MECHANISM, kernel.MECHANISM),
+	})).To(Equal("kernel"))
+
+	_, err := m.SelectForwarderFor(&ForwarderRequest{
+		Mechanisms: mechanisms(memif.MECHANISM),
+	})
+	g.Expect(err.Error()).To(ContainSubstring("no appropriate forwarders found"))
+}
+
+func TestSelectForwarderForCapabilities(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	// Forwarder advertising capabilities is selected only for the advertised mechanisms
+	vppagent := m.GetForwarder("vppagent")
+	vppagent.Capabilities = []*MechanismCapability{
+		{Type: kernel.MECHANISM},
+		{Type: vxlan.MECHANISM, Remote: true},
+	}
+	m.UpdateForwarder(context.Background(), vppagent)
+
+	_, err := m.SelectForwarderFor(&ForwarderRequest{
+		Mechanisms: mechanisms(memif.MECHANISM),
+	})
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(vxlan.MECHANISM),
+		Remote:     true,
+	})).To(Equal("kernel"))
+}
+
+func TestSelectForwarderForCapacityAndLabels(t *testing.T) {
+	g := NewWithT(t)
+	m := newTestSelectionModel()
+
+	kernelForwarder := m.GetForwarder("kernel")
+	kernelForwarder.MaxCrossConnects = 1
+	kernelForwarder.Labels = map[string]string{"zone": "secure"}
+	m.UpdateForwarder(context.Background(), kernelForwarder)
+
+	m.SetForwarderPolicies(map[string]*ForwarderPolicy{
+		"secure": {Labels: map[string]string{"zone": "secure"}},
+	})
+	request := &ForwarderRequest{
+		NetworkService: "secure",
+		Mechanisms:     mechanisms(kernel.MECHANISM),
+	}
+	g.Expect(selectForwarderName(g, m, request)).To(Equal("kernel"))
+
+	m.AddClientConnection(context.Background(), &ClientConnection{
+		ConnectionID:            "1",
+		ForwarderRegisteredName: "kernel",
+	})
+	_, err := m.SelectForwarderFor(request)
+	g.Expect(err).NotTo(BeNil())
+
+	// Network services without policy labels could use any forwarder having capacity
+	g.Expect(selectForwarderName(g, m, &ForwarderRequest{
+		Mechanisms: mechanisms(kernel.MECHANISM),
+	})).To(Equal("vppagent"))
+}
@@ -49,6 +49,10 @@ func TestAddAndGetDp(t *testing.T) {
 
 	g.Expect(fmt.Sprintf("%p", getDp.LocalMechanisms)).ToNot(Equal(fmt.Sprintf("%p", dp.LocalMechanisms)))
 	g.Expect(fmt.Sprintf("%p", getDp.RemoteMechanisms)).ToNot(Equal(fmt.Sprintf("%p", dp.RemoteMechanisms)))
+
+	all := dd.GetAllForwarders()
+	g.Expect(all).To(HaveLen(1))
+	g.Expect(all[0].RegisteredName).To(Equal(dp.RegisteredName))
 }
 
 func TestDeleteDp(t *testing.T) {
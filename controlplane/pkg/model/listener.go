@@ -9,11 +9,15 @@ type Listener interface {
 	EndpointDeleted(ctx context.Context, endpoint *Endpoint)
 
 	ForwarderAdded(ctx context.Context, forwarder *Forwarder)
+	ForwarderUpdated(ctx context.Context, forwarder *Forwarder)
 	ForwarderDeleted(ctx context.Context, forwarder *Forwarder)
 
 	ClientConnectionAdded(ctx context.Context, clientConnection *ClientConnection)
 	ClientConnectionDeleted(ctx context.Context, clientConnection *ClientConnection)
 	ClientConnectionUpdated(ctx context.Context, old, new *ClientConnection)
+
+	EndpointCircuitUpdated(ctx context.Context, circuit *EndpointCircuit)
+	EndpointCircuitDeleted(ctx context.Context, circuit *EndpointCircuit)
 }
 
 // ListenerImpl is empty implementation of Listener
@@ -31,6 +35,9 @@ func (ListenerImpl) EndpointDeleted(ctx context.Context, endpoint *Endpoint) {}
 // ForwarderAdded will be called when Forwarder is added to model, accept pointer to copy
 func (ListenerImpl) ForwarderAdded(ctx context.Context, forwarder *Forwarder) {}
 
+// ForwarderUpdated will be called when Forwarder in model is updated
+func (ListenerImpl) ForwarderUpdated(ctx context.Context, forwarder *Forwarder) {}
+
 // ForwarderDeleted will be called when Forwarder in model is deleted
 func (ListenerImpl) ForwarderDeleted(ctx context.Context, forwarder *Forwarder) {}
 
@@ -43,3 +50,9 @@ func (ListenerImpl) ClientConnectionUpdated(ctx context.Context, old, new *Clien
 // ClientConnectionDeleted will be called when ClientConnection in model is deleted
 func (ListenerImpl) ClientConnectionDeleted(ctx context.Context, clientConnection *ClientConnection) {
 }
+
+// EndpointCircuitUpdated will be called when EndpointCircuit is added to model or updated
+func (ListenerImpl) EndpointCircuitUpdated(ctx context.Context, circuit *EndpointCircuit) {}
+
+// EndpointCircuitDeleted will be called when EndpointCircuit is deleted from model, since endpoint succeeded request
+func (ListenerImpl) EndpointCircuitDeleted(ctx context.Context, circuit *EndpointCircuit) {}
@@ -2,9 +2,13 @@ package model
 
 import (
 	"context"
+	"reflect"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 )
 
 type testListener struct {
@@ -27,6 +31,10 @@ func (t *testListener) ForwarderAdded(ctx context.Context, forwarder *Forwarder)
 	t.Done()
 }
 
+func (t *testListener) ForwarderUpdated(ctx context.Context, forwarder *Forwarder) {
+	t.Done()
+}
+
 func (t *testListener) ForwarderDeleted(ctx context.Context, forwarder *Forwarder) {
 	t.Done()
 }
@@ -43,10 +51,19 @@ func (t *testListener) ClientConnectionUpdated(ctx context.Context, old, new *Cl
 	t.Done()
 }
 
+func (t *testListener) EndpointCircuitUpdated(ctx context.Context, circuit *EndpointCircuit) {
+	t.Done()
+}
+
+func (t *testListener) EndpointCircuitDeleted(ctx context.Context, circuit *EndpointCircuit) {
+	t.Done()
+}
+
 func TestModelListener(t *testing.T) {
 	m := NewModel()
+	m.SetCircuitBreakerConfig(CircuitBreakerConfig{FailureThreshold: 1})
 	ln := testListener{}
-	ln.Add(8)
+	ln.Add(11)
 	m.AddListener(&ln)
 
 	m.AddEndpoint(context.Background(), &Endpoint{})
@@ -54,12 +71,19 @@ func TestModelListener(t *testing.T) {
 	m.DeleteEndpoint(context.Background(), "")
 
 	m.AddForwarder(context.Background(), &Forwarder{})
+	m.UpdateForwarder(context.Background(), &Forwarder{})
 	m.DeleteForwarder(context.Background(), "")
 
 	m.AddClientConnection(context.Background(), &ClientConnection{})
 	m.UpdateClientConnection(context.Background(), &ClientConnection{})
 	m.DeleteClientConnection(context.Background(), "")
 
+	m.EndpointRequestFailed(context.Background(), &registry.NSERegistration{
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{},
+		NetworkServiceManager:  &registry.NetworkServiceManager{},
+	})
+	m.EndpointRequestSucceeded(context.Background(), ":")
+
 	doneCh := make(chan struct{})
 	go func() {
 		ln.Wait()
@@ -73,3 +97,79 @@ func TestModelListener(t *testing.T) {
 		t.Fatal("not all listeners have been emitted")
 	}
 }
+
+type recordingListener struct {
+	ListenerImpl
+	sync.Mutex
+	added   []string
+	updated []string
+}
+
+func (l *recordingListener) ClientConnectionAdded(ctx context.Context, clientConnection *ClientConnection) {
+	l.Lock()
+	defer l.Unlock()
+	l.added = append(l.added, clientConnection.ConnectionID)
+}
+
+func (l *recordingListener) ClientConnectionUpdated(ctx context.Context, old, new *ClientConnection) {
+	l.Lock()
+	defer l.Unlock()
+	l.updated = append(l.updated, new.ForwarderRegisteredName)
+}
+
+func (l *recordingListener) events() ([]string, []string) {
+	l.Lock()
+	defer l.Unlock()
+	return append([]string{}, l.added...), append([]string{}, l.updated...)
+}
+
+func TestModelListenerReplay(t *testing.T) {
+	m := NewModel()
+	m.AddClientConnection(context.Background(), &ClientConnection{ConnectionID: "1"})
+
+	first := &recordingListener{}
+	m.AddListener(first)
+	second := &recordingListener{}
+	m.AddListener(second)
+
+	for _, ln := range []*recordingListener{first, second} {
+		deadline := time.Now().Add(5 * time.Second)
+		for added, _ := ln.events(); len(added) == 0; added, _ = ln.events() {
+			if time.Now().After(deadline) {
+				t.Fatal("existing connection is not replayed to listener")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// Existing connection is replayed to the added listener only
+	time.Sleep(100 * time.Millisecond)
+	if added, _ := first.events(); len(added) != 1 {
+		t.Fatalf("existing connection is replayed to previous listener: %v", added)
+	}
+}
+
+func TestModelListenerOrder(t *testing.T) {
+	m := NewModel()
+	ln := &recordingListener{}
+	m.AddListener(ln)
+
+	m.AddClientConnection(context.Background(), &ClientConnection{ConnectionID: "1"})
+	var expected []string
+	for i := 0; i < 100; i++ {
+		name := strconv.Itoa(i)
+		m.UpdateClientConnection(context.Background(), &ClientConnection{ConnectionID: "1", ForwarderRegisteredName: name})
+		expected = append(expected, name)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for _, updated := ln.events(); len(updated) < len(expected); _, updated = ln.events() {
+		if time.Now().After(deadline) {
+			t.Fatal("not all updates have been emitted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, updated := ln.events(); !reflect.DeepEqual(updated, expected) {
+		t.Fatalf("updates are emitted out of order: %v", updated)
+	}
+}
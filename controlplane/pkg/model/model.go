@@ -16,22 +16,39 @@ type Model interface {
 
 	AddEndpoint(ctx context.Context, endpoint *Endpoint)
 	GetEndpoint(name string) *Endpoint
+	GetAllEndpoints() []*Endpoint
 	UpdateEndpoint(ctx context.Context, endpoint *Endpoint)
 	DeleteEndpoint(ctx context.Context, name string)
 
 	GetForwarder(name string) *Forwarder
+	GetAllForwarders() []*Forwarder
 	AddForwarder(ctx context.Context, forwarder *Forwarder)
 	UpdateForwarder(ctx context.Context, forwarder *Forwarder)
 	DeleteForwarder(ctx context.Context, name string)
 	SelectForwarder(forwarderSelector func(dp *Forwarder) bool) (*Forwarder, error)
+	SelectForwarderFor(request *ForwarderRequest) (*Forwarder, error)
+	SetForwarderPolicies(policies map[string]*ForwarderPolicy)
+	GetForwarderPolicy(networkService string) *ForwarderPolicy
 
 	AddClientConnection(ctx context.Context, clientConnection *ClientConnection)
 	GetClientConnection(connectionID string) *ClientConnection
 	GetAllClientConnections() []*ClientConnection
+	EndpointConnections() map[string]int
+	ForwarderConnections() map[string]int
 	UpdateClientConnection(ctx context.Context, clientConnection *ClientConnection)
 	DeleteClientConnection(ctx context.Context, connectionID string)
 	ApplyClientConnectionChanges(ctx context.Context, connectionID string, changeFunc func(*ClientConnection)) *ClientConnection
 
+	SetCircuitBreakerConfig(config CircuitBreakerConfig)
+	GetEndpointCircuit(name registry.EndpointNSMName) *EndpointCircuit
+	GetEndpointCircuits() []*EndpointCircuit
+	EndpointRequestFailed(ctx context.Context, endpoint *registry.NSERegistration)
+	EndpointRequestSucceeded(ctx context.Context, name registry.EndpointNSMName)
+	IsEndpointAvailable(name registry.EndpointNSMName) bool
+	AcquireEndpointTrial(ctx context.Context, name registry.EndpointNSMName) bool
+	DeleteEndpointCircuit(ctx context.Context, name registry.EndpointNSMName)
+	PruneEndpointCircuits(ctx context.Context, networkService string, endpoints []registry.EndpointNSMName)
+
 	ConnectionID() string
 	CorrectIDGenerator(id string)
 
@@ -42,22 +59,38 @@ type Model interface {
 	SetNsm(nsm *registry.NetworkServiceManager)
 	GetNsm() *registry.NetworkServiceManager
 
-	GetSelector() selector.Selector
+	GetSelector() selector.NetworkServiceSelector
+	SetNetworkServiceSelector(networkService string, s selector.Selector)
+	SetDefaultSelector(s selector.Selector)
 }
 
 type model struct {
 	endpointDomain
 	forwarderDomain
 	clientConnectionDomain
+	circuitDomain
 
 	lastConnectionID uint64
 	mtx              sync.RWMutex
-	selector         selector.Selector
+	selector         selector.NetworkServiceSelector
 	nsm              *registry.NetworkServiceManager
 	listeners        map[Listener]func()
+
+	forwarderPolicies map[string]*ForwarderPolicy
+}
+
+// DeleteEndpoint - deletes endpoint together with its circuit
+func (m *model) DeleteEndpoint(ctx context.Context, name string) {
+	if endpoint := m.GetEndpoint(name); endpoint != nil {
+		m.DeleteEndpointCircuit(ctx, endpoint.Endpoint.GetEndpointNSMName())
+	}
+	m.endpointDomain.DeleteEndpoint(ctx, name)
 }
 
+// AddListener - adds listener notified of model changes, existing endpoints, forwarders, client connections and circuits
+// are notified as added to it. Listener is notified of changes one by one in the order they happen.
 func (m *model) AddListener(listener Listener) {
+	queue := &eventQueue{}
 	endpListenerDelete := m.SetEndpointModificationHandler(&ModificationHandler{
 		AddFunc: func(ctx context.Context, new interface{}) {
 			listener.EndpointAdded(ctx, new.(*Endpoint))
@@ -68,15 +101,20 @@ func (m *model) AddListener(listener Listener) {
 		DeleteFunc: func(ctx context.Context, del interface{}) {
 			listener.EndpointDeleted(ctx, del.(*Endpoint))
 		},
+		queue: queue,
 	})
 
 	dpListenerDelete := m.SetForwarderModificationHandler(&ModificationHandler{
 		AddFunc: func(ctx context.Context, new interface{}) {
 			listener.ForwarderAdded(ctx, new.(*Forwarder))
 		},
+		UpdateFunc: func(ctx context.Context, old interface{}, new interface{}) {
+			listener.ForwarderUpdated(ctx, new.(*Forwarder))
+		},
 		DeleteFunc: func(ctx context.Context, del interface{}) {
 			listener.ForwarderDeleted(ctx, del.(*Forwarder))
 		},
+		queue: queue,
 	})
 
 	ccListenerDelete := m.SetClientConnectionModificationHandler(&ModificationHandler{
@@ -89,12 +127,26 @@ func (m *model) AddListener(listener Listener) {
 		DeleteFunc: func(ctx context.Context, del interface{}) {
 			listener.ClientConnectionDeleted(ctx, del.(*ClientConnection))
 		},
+		queue: queue,
+	})
+	circuitListenerDelete := m.SetEndpointCircuitModificationHandler(&ModificationHandler{
+		AddFunc: func(ctx context.Context, new interface{}) {
+			listener.EndpointCircuitUpdated(ctx, new.(*EndpointCircuit))
+		},
+		UpdateFunc: func(ctx context.Context, old interface{}, new interface{}) {
+			listener.EndpointCircuitUpdated(ctx, new.(*EndpointCircuit))
+		},
+		DeleteFunc: func(ctx context.Context, del interface{}) {
+			listener.EndpointCircuitDeleted(ctx, del.(*EndpointCircuit))
+		},
+		queue: queue,
 	})
 	m.mtx.Lock()
 	m.listeners[listener] = func() {
 		endpListenerDelete()
 		dpListenerDelete()
 		ccListenerDelete()
+		circuitListenerDelete()
 	}
 	m.mtx.Unlock()
 }
@@ -119,13 +171,19 @@ func (m *model) ListenerCount() int {
 
 // NewModel returns new instance of Model
 func NewModel() Model {
-	return &model{
+	m := &model{
 		clientConnectionDomain: newClientConnectionDomain(),
 		endpointDomain:         newEndpointDomain(),
 		forwarderDomain:        newForwarderDomain(),
-		selector:               selector.NewMatchSelector(),
+		circuitDomain:          newCircuitDomain(),
+		selector:               selector.NewNetworkServiceSelector(selector.NewMatchSelector()),
 		listeners:              make(map[Listener]func()),
 	}
+	m.selector.SetStrategyContext(&selector.StrategyContext{
+		Connections: m,
+		Nsm:         m,
+	})
+	return m
 }
 
 func (m *model) ConnectionID() string {
@@ -163,6 +221,16 @@ func (m *model) SetNsm(nsm *registry.NetworkServiceManager) {
 	m.nsm = nsm
 }
 
-func (m *model) GetSelector() selector.Selector {
+func (m *model) GetSelector() selector.NetworkServiceSelector {
 	return m.selector
 }
+
+// SetNetworkServiceSelector overrides the selector used for networkService endpoints, nil restores the default one
+func (m *model) SetNetworkServiceSelector(networkService string, s selector.Selector) {
+	m.selector.SetNetworkServiceSelector(networkService, s)
+}
+
+// SetDefaultSelector overrides the selector used for network services without their own selector
+func (m *model) SetDefaultSelector(s selector.Selector) {
+	m.selector.SetDefaultSelector(s)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nseregistry
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// The legacy format of version 1 is a line per record with tab separated escaped values and base64 encoded
+// registrations. It is only read to migrate the existing registry files.
+
+func unescape(s string) string {
+	s = strings.Replace(s, "\\t,", "\t", -1)
+	s = strings.Replace(s, "\\n,", "\n", -1)
+	s = strings.Replace(s, "\\r,", "\r", -1)
+	return s
+}
+
+func restore(inputS string) []string {
+	ts := strings.TrimSpace(inputS)
+	segments := strings.SplitN(ts, "\t", -1)
+	for idx, sm := range segments {
+		segments[idx] = unescape(sm)
+	}
+	return segments
+}
+
+// loadLegacyRegistry - reads registry of the legacy format, records failed to decode are skipped
+func loadLegacyRegistry(input io.Reader) (clients []string, nses map[string]NSEEntry, err error) {
+	nses = map[string]NSEEntry{}
+
+	reader := bufio.NewReader(input)
+	for {
+		r, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, nil, readErr
+		}
+		if r == "" {
+			// End of file
+			break
+		}
+		if !strings.HasSuffix(r, "\n") {
+			logrus.Errorf("Skipping torn legacy registry file line: %v", r)
+			break
+		}
+		values := restore(r)
+		if values[0] == ClientRegistered && len(values) == 2 {
+			clients = append(clients, values[1])
+		} else if values[0] == NSERegistered && len(values) == 4 {
+			bytes, decodeErr := base64.StdEncoding.DecodeString(values[3])
+			if decodeErr != nil {
+				logrus.Errorf("Failed to decode NSE registration %v", decodeErr)
+				continue
+			}
+			nseReg := &registry.NSERegistration{}
+			if decodeErr = proto.Unmarshal(bytes, nseReg); decodeErr != nil {
+				logrus.Errorf("Failed to decode nse registration message %v", decodeErr)
+				continue
+			}
+			nses[values[1]] = NSEEntry{
+				Workspace: values[2],
+				NseReg:    nseReg,
+			}
+		} else {
+			logrus.Errorf("Unknown registry file line: %v", r)
+		}
+	}
+	return clients, nses, nil
+}
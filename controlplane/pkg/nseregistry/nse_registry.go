@@ -2,13 +2,18 @@ package nseregistry
 
 import (
 	"bufio"
-	"encoding/base64"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
@@ -17,11 +22,28 @@ import (
 const (
 	ClientRegistered = "CLE"
 	NSERegistered    = "NSE"
+	// ClientDeleted - journal record of deleted client workspace along with all its NSEs
+	ClientDeleted = "DCLE"
+	// NSEDeleted - journal record of deleted NSE
+	NSEDeleted = "DNSE"
+)
+
+const (
+	// journalMagic - first field of the journal header line
+	journalMagic = "NSEREGISTRY"
+	// JournalVersion - version of the journal format, files without header are of the legacy version 1
+	JournalVersion = 2
+	// compactionRecords - amount of journal records triggering compaction, if most of them are obsolete
+	compactionRecords = 1000
 )
 
 type NSERegistry struct {
 	lock sync.Mutex
 	file string
+	// prepared is set once the file is checked to be a journal of the current version
+	prepared bool
+	// records is an amount of records in the journal
+	records int
 }
 
 type NSEEntry struct {
@@ -29,44 +51,77 @@ type NSEEntry struct {
 	NseReg    *registry.NSERegistration
 }
 
+// journalRecord - a single journal operation, stored as JSON prefixed by its checksum
+type journalRecord struct {
+	Op           string `json:"op"`
+	Workspace    string `json:"workspace,omitempty"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	Registration []byte `json:"registration,omitempty"`
+}
+
+// loadState describes the journal file as it was loaded
+type loadState struct {
+	exists  bool
+	legacy  bool
+	records int
+	skipped int
+}
+
 func NewNSERegistry(file string) *NSERegistry {
 	return &NSERegistry{file: file}
 }
 
-func store(values ...string) string {
-	res := ""
-	for _, s := range values {
-		if len(res) > 0 {
-			res += "\t"
-		}
-		ss := strings.Replace(s, "\t", "\\t,", -1)
-		ss = strings.Replace(ss, "\n", "\\n,", -1) // Just in case
-		ss = strings.Replace(ss, "\r", "\\r,", -1) // Just in case
-		res += ss
-	}
-	return res
+func journalHeader() string {
+	return fmt.Sprintf("%s\t%d", journalMagic, JournalVersion)
 }
-func unescape(s string) string {
-	s = strings.Replace(s, "\\t", "\t", -1)
-	s = strings.Replace(s, "\\n", "\n", -1)
-	s = strings.Replace(s, "\\r", "\r", -1)
-	return s
+
+// encodeRecord - returns journal line of the record: checksum of the JSON data followed by the data itself
+func encodeRecord(record *journalRecord) (string, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to serialize %s record", record.Op)
+	}
+	return fmt.Sprintf("%08x\t%s\n", crc32.ChecksumIEEE(data), data), nil
 }
-func restore(inputS string) []string {
-	ts := strings.TrimSpace(inputS)
-	segments := strings.SplitN(ts, "\t", -1)
-	for idx, sm := range segments {
-		segments[idx] = unescape(sm)
+
+// decodeRecord - parses journal line, returns error for torn or corrupted lines
+func decodeRecord(line string) (*journalRecord, error) {
+	if !strings.HasSuffix(line, "\n") {
+		return nil, errors.New("record is not terminated")
+	}
+	segments := strings.SplitN(strings.TrimSuffix(line, "\n"), "\t", 2)
+	if len(segments) != 2 {
+		return nil, errors.New("record has no checksum")
+	}
+	checksum, err := strconv.ParseUint(segments[0], 16, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "record has invalid checksum")
 	}
-	return segments
+	if uint32(checksum) != crc32.ChecksumIEEE([]byte(segments[1])) {
+		return nil, errors.New("record checksum mismatch")
+	}
+	record := &journalRecord{}
+	if err := json.Unmarshal([]byte(segments[1]), record); err != nil {
+		return nil, errors.Wrap(err, "failed to parse record")
+	}
+	return record, nil
 }
 
 /**
-We adding a registration for client.
+We adding a record into the journal.
 */
-func (reg *NSERegistry) writeLine(op ...string) error {
+func (reg *NSERegistry) appendRecord(record *journalRecord) error {
 	reg.lock.Lock()
 	defer reg.lock.Unlock()
+
+	if err := reg.prepare(); err != nil {
+		return err
+	}
+	line, err := encodeRecord(record)
+	if err != nil {
+		return err
+	}
+
 	f, err := os.OpenFile(reg.file, os.O_APPEND|os.O_WRONLY|os.O_SYNC|os.O_CREATE, 0600)
 	if err != nil {
 		logrus.Errorf("Failed to store Client information")
@@ -75,73 +130,103 @@ func (reg *NSERegistry) writeLine(op ...string) error {
 
 	defer f.Close()
 
-	if _, err = f.WriteString(store(op...) + "\n"); err != nil {
+	if _, err = f.WriteString(line); err != nil {
 		return err
 	}
 	_ = f.Sync()
-	return nil
-}
-func (reg *NSERegistry) AppendClientRequest(workspace string) error {
-	return reg.writeLine(ClientRegistered, workspace)
-}
+	reg.records++
 
-func (reg *NSERegistry) AppendNSERegRequest(workspace string, nseReg *registry.NSERegistration) error {
-	return reg.writeLine(NSERegistered, nseReg.GetNetworkServiceEndpoint().GetName(), workspace, reg.storeNSEBase64(nseReg)) // Few workspaces could contain few NSEs
+	return reg.compactIfRequired()
 }
 
-func (reg *NSERegistry) storeNSEBase64(nseReg *registry.NSERegistration) string {
-	bytes, err := proto.Marshal(nseReg)
+// prepare - makes sure the file is a journal of the current version without torn records, so records could be appended
+func (reg *NSERegistry) prepare() error {
+	if reg.prepared {
+		return nil
+	}
+	clients, nses, state, err := reg.loadRegistry()
 	if err != nil {
-		logrus.Errorf("Failed to serialize NSE passed %v", err)
+		return err
+	}
+	if !state.exists || state.legacy || state.skipped > 0 {
+		// Migrate legacy format or drop torn records by rewriting the journal
+		logrus.Infof("Rewriting NSE registry journal %s: exists %v, legacy %v, skipped records %v", reg.file, state.exists, state.legacy, state.skipped)
+		if err := reg.save(clients, nses); err != nil {
+			return err
+		}
+	} else {
+		reg.records = state.records
 	}
-	strData := base64.StdEncoding.EncodeToString(bytes)
-	return strData
+	reg.prepared = true
+	return nil
 }
 
-func (reg *NSERegistry) DeleteNSE(endpointid string) error {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	clients, nses, err := reg.loadRegistry()
+// compactIfRequired - rewrites the journal with only actual records, if it grew too big
+func (reg *NSERegistry) compactIfRequired() error {
+	if reg.records < compactionRecords {
+		return nil
+	}
+	clients, nses, _, err := reg.loadRegistry()
 	if err != nil {
 		return err
 	}
-	delete(nses, endpointid)
+	if live := len(clients) + len(nses); reg.records < 2*live {
+		return nil
+	}
+	logrus.Infof("Compacting NSE registry journal %s", reg.file)
+	return reg.save(clients, nses)
+}
 
-	return reg.Save(clients, nses)
+func (reg *NSERegistry) AppendClientRequest(workspace string) error {
+	return reg.appendRecord(&journalRecord{
+		Op:        ClientRegistered,
+		Workspace: workspace,
+	})
 }
 
-/**
-Delete client workspace and all NSEs registered.
-*/
-func (reg *NSERegistry) DeleteClient(workspace string) error {
-	reg.lock.Lock()
-	defer reg.lock.Unlock()
-	clients, nses, err := reg.loadRegistry()
+func (reg *NSERegistry) AppendNSERegRequest(workspace string, nseReg *registry.NSERegistration) error {
+	data, err := proto.Marshal(nseReg)
 	if err != nil {
+		logrus.Errorf("Failed to serialize NSE passed %v", err)
 		return err
 	}
-	for idx, ws := range clients {
-		if ws == workspace {
-			clients = append(clients[:idx], clients[idx+1:]...)
-		}
-	}
+	return reg.appendRecord(&journalRecord{
+		Op:           NSERegistered,
+		Workspace:    workspace, // Few workspaces could contain few NSEs
+		Endpoint:     nseReg.GetNetworkServiceEndpoint().GetName(),
+		Registration: data,
+	})
+}
 
-	for endpointId, entry := range nses {
-		if entry.Workspace == workspace {
-			delete(nses, endpointId)
-		}
-	}
+func (reg *NSERegistry) DeleteNSE(endpointid string) error {
+	return reg.appendRecord(&journalRecord{
+		Op:       NSEDeleted,
+		Endpoint: endpointid,
+	})
+}
 
-	return reg.Save(clients, nses)
+/**
+Delete client workspace and all NSEs registered.
+*/
+func (reg *NSERegistry) DeleteClient(workspace string) error {
+	return reg.appendRecord(&journalRecord{
+		Op:        ClientDeleted,
+		Workspace: workspace,
+	})
 }
 
 func (reg *NSERegistry) LoadRegistry() (clients []string, nses map[string]NSEEntry, err error) {
 	reg.lock.Lock()
 	defer reg.lock.Unlock()
-	return reg.loadRegistry()
+
+	if err = reg.prepare(); err != nil {
+		return nil, nil, err
+	}
+	clients, nses, _, err = reg.loadRegistry()
+	return clients, nses, err
 }
 
-func (reg *NSERegistry) loadRegistry() (clients []string, nses map[string]NSEEntry, res_err error) {
+func (reg *NSERegistry) loadRegistry() (clients []string, nses map[string]NSEEntry, state loadState, resErr error) {
 	nses = map[string]NSEEntry{}
 
 	f, err := os.OpenFile(reg.file, os.O_RDONLY, 0600)
@@ -150,41 +235,43 @@ func (reg *NSERegistry) loadRegistry() (clients []string, nses map[string]NSEEnt
 		return
 	}
 	defer f.Close()
+	state.exists = true
 
 	reader := bufio.NewReader(f)
+	header, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		resErr = err
+		return
+	}
+	if version, ok := parseHeader(header); !ok {
+		// No header, the file is of the legacy format
+		state.legacy = true
+		clients, nses, resErr = loadLegacyRegistry(io.MultiReader(strings.NewReader(header), reader))
+		return
+	} else if version != JournalVersion {
+		resErr = errors.Errorf("unsupported NSE registry journal version %v", version)
+		return
+	}
+
 	for {
-		r, err := reader.ReadString('\n')
-		if err != nil {
-			if err != io.EOF {
-				res_err = err
-				return
-			}
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			resErr = err
+			return
+		}
+		if line == "" {
 			// End of file
 			break
 		}
-		values := restore(r)
-		logrus.Printf("Values: %v", values)
-		if len(values) > 0 {
-			if values[0] == ClientRegistered && len(values) == 2 {
-				clients = append(clients, values[1])
-			} else if values[0] == NSERegistered && len(values) == 4 {
-				entry := NSEEntry{}
-				entry.Workspace = values[2]
-				strData := values[3]
-				bytes, err := base64.StdEncoding.DecodeString(strData)
-				if err != nil {
-					logrus.Errorf("Failed to decode NSE registration %v", err)
-				}
-				nseReg := &registry.NSERegistration{}
-				err = proto.Unmarshal(bytes, nseReg)
-				if err != nil {
-					logrus.Errorf("Failed to decode nse registration message %v", err)
-				}
-				entry.NseReg = nseReg
-				nses[values[1]] = entry
-			} else {
-				logrus.Errorf("Unknown registry file line: %v", r)
-			}
+		state.records++
+		record, recordErr := decodeRecord(line)
+		if recordErr == nil {
+			recordErr = applyRecord(record, &clients, nses)
+		}
+		if recordErr != nil {
+			// Torn write or corrupted data, the rest of the journal is still valid
+			logrus.Errorf("Skipping NSE registry record %v: %v", state.records, recordErr)
+			state.skipped++
 		}
 	}
 	logrus.Infof("Clients: %v", clients)
@@ -193,35 +280,131 @@ func (reg *NSERegistry) loadRegistry() (clients []string, nses map[string]NSEEnt
 	return
 }
 
+func parseHeader(line string) (int, bool) {
+	segments := strings.Split(strings.TrimSpace(line), "\t")
+	if len(segments) != 2 || segments[0] != journalMagic {
+		return 0, false
+	}
+	version, err := strconv.Atoi(segments[1])
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+func applyRecord(record *journalRecord, clients *[]string, nses map[string]NSEEntry) error {
+	switch record.Op {
+	case ClientRegistered:
+		*clients = append(*clients, record.Workspace)
+	case ClientDeleted:
+		*clients = removeWorkspace(*clients, record.Workspace)
+		for endpointID, entry := range nses {
+			if entry.Workspace == record.Workspace {
+				delete(nses, endpointID)
+			}
+		}
+	case NSERegistered:
+		nseReg := &registry.NSERegistration{}
+		if err := proto.Unmarshal(record.Registration, nseReg); err != nil {
+			return errors.Wrap(err, "failed to decode nse registration message")
+		}
+		nses[record.Endpoint] = NSEEntry{
+			Workspace: record.Workspace,
+			NseReg:    nseReg,
+		}
+	case NSEDeleted:
+		delete(nses, record.Endpoint)
+	default:
+		return errors.Errorf("unknown record operation %v", record.Op)
+	}
+	return nil
+}
+
+func removeWorkspace(clients []string, workspace string) []string {
+	rv := clients[:0]
+	for _, ws := range clients {
+		if ws != workspace {
+			rv = append(rv, ws)
+		}
+	}
+	return rv
+}
+
 /**
 Saves memory model info file
 */
 func (reg *NSERegistry) Save(clients []string, nses map[string]NSEEntry) error {
-	tmpFile := reg.file + "_tmp"
-	f, err := os.OpenFile(tmpFile, os.O_APPEND|os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
-	if err != nil {
-		logrus.Errorf("Failed to store Client information")
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	if err := reg.save(clients, nses); err != nil {
 		return err
 	}
+	reg.prepared = true
+	return nil
+}
 
+// save - writes a compacted journal with clients and nses, replacing the existing one
+func (reg *NSERegistry) save(clients []string, nses map[string]NSEEntry) error {
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(journalHeader() + "\n")
+
+	records := 0
 	for _, workspace := range clients {
-		if _, err = f.WriteString(store(ClientRegistered, workspace) + "\n"); err != nil {
+		line, err := encodeRecord(&journalRecord{Op: ClientRegistered, Workspace: workspace})
+		if err != nil {
 			return err
 		}
+		buffer.WriteString(line)
+		records++
 	}
 
-	for endpointId, entry := range nses {
-		if _, err = f.WriteString(store(NSERegistered, endpointId, entry.Workspace, reg.storeNSEBase64(entry.NseReg)) + "\n"); err != nil {
+	for endpointID, entry := range nses {
+		data, err := proto.Marshal(entry.NseReg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to serialize NSE %s", endpointID)
+		}
+		line, err := encodeRecord(&journalRecord{
+			Op:           NSERegistered,
+			Workspace:    entry.Workspace,
+			Endpoint:     endpointID,
+			Registration: data,
+		})
+		if err != nil {
 			return err
 		}
+		buffer.WriteString(line)
+		records++
 	}
-	if err = f.Close(); err != nil {
+
+	tmpFile := reg.file + "_tmp"
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
+	if err != nil {
+		logrus.Errorf("Failed to store Client information")
+		return err
+	}
+	if _, err = f.Write(buffer.Bytes()); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
 		return err
 	}
 	// Now we need to replace existing file with new one.
-	return os.Rename(tmpFile, reg.file)
+	if err = os.Rename(tmpFile, reg.file); err != nil {
+		return err
+	}
+	reg.records = records
+	return nil
 }
 
 func (reg *NSERegistry) Delete() {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
 	_ = os.Remove(reg.file)
+	reg.prepared = false
+	reg.records = 0
 }
@@ -0,0 +1,212 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connectionstore"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
+)
+
+// SetConnectionStore - sets store client connections are persisted to, connections stored by the previous run
+// are loaded to be restored along with forwarder state
+func (srv *networkServiceManager) SetConnectionStore(store *connectionstore.Store) {
+	stored, err := store.Load()
+	if err != nil {
+		logrus.Errorf("Failed to load connections snapshot, restoring connections from forwarder state only: %v", err)
+		stored = map[string]*model.ClientConnection{}
+	}
+	logrus.Infof("Loaded %v stored connections", len(stored))
+
+	srv.storedMtx.Lock()
+	defer srv.storedMtx.Unlock()
+
+	srv.connectionStore = store
+	srv.storedConnections = stored
+}
+
+// takeStoredConnection - returns stored connection with id if it is not restored yet
+func (srv *networkServiceManager) takeStoredConnection(id string) *model.ClientConnection {
+	srv.storedMtx.Lock()
+	defer srv.storedMtx.Unlock()
+
+	stored := srv.storedConnections[id]
+	delete(srv.storedConnections, id)
+	return stored
+}
+
+// takeForwarderStoredConnections - returns stored connections not restored yet which were programmed by forwarder or
+// by a forwarder not registered anymore
+func (srv *networkServiceManager) takeForwarderStoredConnections(forwarder string) []*model.ClientConnection {
+	srv.storedMtx.Lock()
+	defer srv.storedMtx.Unlock()
+
+	var rv []*model.ClientConnection
+	for id, stored := range srv.storedConnections {
+		if stored.ForwarderRegisteredName == forwarder || srv.model.GetForwarder(stored.ForwarderRegisteredName) == nil {
+			rv = append(rv, stored)
+			delete(srv.storedConnections, id)
+		}
+	}
+	return rv
+}
+
+// requeueStoredConnection - returns stored connection back to be restored, unless it is restored already
+func (srv *networkServiceManager) requeueStoredConnection(stored *model.ClientConnection) {
+	srv.storedMtx.Lock()
+	defer srv.storedMtx.Unlock()
+
+	if _, ok := srv.storedConnections[stored.GetID()]; !ok {
+		srv.storedConnections[stored.GetID()] = stored
+	}
+}
+
+// restoreLostConnection - restores stored connection missing in forwarder and heals it to program forwarder again
+func (srv *networkServiceManager) restoreLostConnection(ctx context.Context, stored *model.ClientConnection, forwarder string, manager nsm.MonitorManager) {
+	srv.model.CorrectIDGenerator(stored.GetID())
+	span := spanhelper.FromContext(ctx, "restoreLostConnection")
+	defer span.Finish()
+	span.LogObject("forwarder", forwarder)
+	span.LogObject("stored", stored)
+
+	if srv.model.GetClientConnection(stored.GetID()) != nil {
+		return
+	}
+	if src := stored.GetConnectionSource(); src == nil || src.IsRemote() || stored.Request == nil {
+		// Remote NSM will heal its side of connection and request it again.
+		span.Logger().Infof("Dropping stored connection %v lost by forwarder", stored.GetID())
+		return
+	}
+	fwd := srv.model.GetForwarder(forwarder)
+	if fwd == nil {
+		// Forwarder is unregistered while connections are restored, connection is restored by the next one.
+		span.Logger().Infof("Forwarder %v is not registered, stored connection %v is kept to be restored later", forwarder, stored.GetID())
+		srv.requeueStoredConnection(stored)
+		return
+	}
+
+	workspaceName := stored.GetConnectionSource().GetMechanism().GetParameters()[mechanismCommon.Workspace]
+	monitor := manager.LocalConnectionMonitor(workspaceName)
+	if monitor == nil {
+		span.LogError(errors.Errorf("failed to restore stored connection %v, workspace %v is not found", stored.GetID(), workspaceName))
+		return
+	}
+
+	span.Logger().Infof("Restoring stored connection %v lost by forwarder", stored.GetID())
+	cc := stored
+	cc.ForwarderRegisteredName = fwd.RegisteredName
+	cc.ForwarderState = model.ForwarderStateNone
+	cc.ConnectionState = model.ClientConnectionReady
+	cc.Monitor = monitor
+	srv.model.AddClientConnection(span.Context(), cc)
+
+	srv.Heal(span.Context(), cc, nsm.HealStateForwarderDown)
+}
+
+// persistConnections - saves client connections to the store whenever they are changed, saves are done
+// no more often than once per ConnectionSnapshotInterval
+func (srv *networkServiceManager) persistConnections() {
+	srv.storedMtx.Lock()
+	store := srv.connectionStore
+	srv.storedMtx.Unlock()
+	if store == nil {
+		return
+	}
+
+	listener := &connectionChangeListener{
+		changed: make(chan struct{}, 1),
+	}
+	srv.model.AddListener(listener)
+	defer srv.model.RemoveListener(listener)
+
+	listener.notify()
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-listener.changed:
+		}
+		interval := srv.props.Snapshot().ConnectionSnapshotInterval
+		if interval > 0 {
+			if err := store.Save(srv.connectionsSnapshot()); err != nil {
+				logrus.Errorf("Failed to save connections snapshot: %v", err)
+			}
+		} else {
+			// Snapshot is disabled, stale one should not be restored. Check again later in case it is enabled
+			// by config reload.
+			store.Delete()
+			interval = time.Minute
+			listener.notify()
+		}
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// connectionsSnapshot - returns connections to be persisted, connections stored but not restored yet are kept
+func (srv *networkServiceManager) connectionsSnapshot() []*model.ClientConnection {
+	var rv []*model.ClientConnection
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.ConnectionState != model.ClientConnectionClosing {
+			rv = append(rv, cc)
+		}
+	}
+
+	srv.storedMtx.Lock()
+	defer srv.storedMtx.Unlock()
+
+	for _, stored := range srv.storedConnections {
+		rv = append(rv, stored)
+	}
+	return rv
+}
+
+// connectionChangeListener - notifies client connections are changed, notifications are coalesced until received
+type connectionChangeListener struct {
+	model.ListenerImpl
+	changed chan struct{}
+}
+
+func (l *connectionChangeListener) notify() {
+	select {
+	case l.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (l *connectionChangeListener) ClientConnectionAdded(_ context.Context, _ *model.ClientConnection) {
+	l.notify()
+}
+
+func (l *connectionChangeListener) ClientConnectionUpdated(_ context.Context, _, _ *model.ClientConnection) {
+	l.notify()
+}
+
+func (l *connectionChangeListener) ClientConnectionDeleted(_ context.Context, _ *model.ClientConnection) {
+	l.notify()
+}
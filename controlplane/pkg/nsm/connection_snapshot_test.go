@@ -0,0 +1,212 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connectionstore"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+	"github.com/networkservicemesh/networkservicemesh/sdk/monitor/connectionmonitor"
+)
+
+func storedIDs(connections []*model.ClientConnection) []string {
+	var ids []string
+	for _, cc := range connections {
+		ids = append(ids, cc.GetID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// healRecorder records heal states requested by restore
+type healRecorder struct {
+	nsm.NetworkServiceHealProcessor
+	states []nsm.HealState
+}
+
+func (r *healRecorder) Heal(_ context.Context, _ nsm.ClientConnection, healState nsm.HealState) {
+	r.states = append(r.states, healState)
+}
+
+type monitorManagerStub struct {
+	nsm.MonitorManager
+}
+
+func (stub *monitorManagerStub) LocalConnectionMonitor(workspace string) connectionmonitor.MonitorServer {
+	return &updateRecorder{}
+}
+
+func TestConnectionSnapshot(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "nsm-snapshot")
+	g.Expect(err).To(BeNil())
+	defer func() { _ = os.RemoveAll(dir) }()
+	store := connectionstore.NewStore(filepath.Join(dir, "connections.snapshot"))
+	g.Expect(store.Save([]*model.ClientConnection{
+		{ConnectionID: "1", ForwarderRegisteredName: "fw-1"},
+		{ConnectionID: "2", ForwarderRegisteredName: "fw-1"},
+		{ConnectionID: "3", ForwarderRegisteredName: "fw-gone"},
+		{ConnectionID: "4", ForwarderRegisteredName: "fw-2"},
+	})).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mdl := model.NewModel()
+	mdl.AddForwarder(ctx, &model.Forwarder{RegisteredName: "fw-1"})
+	mdl.AddForwarder(ctx, &model.Forwarder{RegisteredName: "fw-2"})
+	props := properties.NewNsmProperties()
+	props.ConnectionSnapshotInterval = 10 * time.Millisecond
+	srv := &networkServiceManager{
+		model: mdl,
+		props: props,
+		ctx:   ctx,
+	}
+	srv.SetConnectionStore(store)
+
+	g.Expect(srv.takeStoredConnection("1").GetID()).To(Equal("1"))
+	g.Expect(srv.takeStoredConnection("1")).To(BeNil())
+	// Connections of not registered forwarder are restored by any forwarder
+	g.Expect(storedIDs(srv.takeForwarderStoredConnections("fw-1"))).To(Equal([]string{"2", "3"}))
+
+	mdl.AddClientConnection(ctx, &model.ClientConnection{ConnectionID: "5", ForwarderRegisteredName: "fw-1"})
+	go srv.persistConnections()
+
+	// Connection "4" is not restored yet, so it is kept in snapshot
+	g.Eventually(func() []string {
+		stored, err := store.Load()
+		g.Expect(err).To(BeNil())
+		var ids []string
+		for id := range stored {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		return ids
+	}, time.Second).Should(Equal([]string{"4", "5"}))
+
+	mdl.DeleteClientConnection(ctx, "5")
+	g.Eventually(func() int {
+		stored, err := store.Load()
+		g.Expect(err).To(BeNil())
+		return len(stored)
+	}, time.Second).Should(Equal(1))
+}
+
+func TestRestoreLostConnectionForwarderGone(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+	srv := &networkServiceManager{
+		model:             model.NewModel(),
+		props:             properties.NewNsmProperties(),
+		ctx:               ctx,
+		storedConnections: map[string]*model.ClientConnection{},
+	}
+	stored := &model.ClientConnection{
+		ConnectionID:            "1",
+		ForwarderRegisteredName: "fw-gone",
+		Xcon: &crossconnect.CrossConnect{
+			Id:     "1",
+			Source: &connection.Connection{Id: "1"},
+		},
+		Request: &networkservice.NetworkServiceRequest{},
+	}
+
+	srv.restoreLostConnection(ctx, stored, "fw-gone", nil)
+	g.Expect(srv.model.GetClientConnection("1")).To(BeNil())
+	g.Expect(srv.takeStoredConnection("1")).To(Equal(stored))
+}
+
+func TestRestoreXconnectionDiffersFromStored(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+	xcon := func(ip string) *crossconnect.CrossConnect {
+		return &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &connection.Connection{
+				Id:             "1",
+				NetworkService: networkServiceName,
+				Mechanism:      &connection.Mechanism{Type: kernel.MECHANISM},
+				State:          connection.State_UP,
+			},
+			Destination: &connection.Connection{
+				Id:             "2",
+				NetworkService: networkServiceName,
+				Mechanism: &connection.Mechanism{
+					Type:       kernel.MECHANISM,
+					Parameters: map[string]string{kernel.WorkspaceNSEName: "nse-1"},
+				},
+				Context: &connectioncontext.ConnectionContext{
+					IpContext: &connectioncontext.IPContext{SrcIpAddr: ip},
+				},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name     string
+		storedIP string
+		healed   []nsm.HealState
+	}{
+		{name: "same", storedIP: "10.0.0.1/30", healed: []nsm.HealState{nsm.HealStateDstNmgrDown}},
+		{name: "differs", storedIP: "10.0.0.5/30", healed: []nsm.HealState{nsm.HealStateDstUpdate}},
+	} {
+		healer := &healRecorder{}
+		srv := &networkServiceManager{
+			NetworkServiceHealProcessor: healer,
+			serviceRegistry:             &serviceRegistryStub{discoveryClient: &discoveryClientStub{}},
+			model:                       model.NewModel(),
+			props:                       properties.NewNsmProperties(),
+			ctx:                         ctx,
+		}
+		srv.model.AddForwarder(ctx, &model.Forwarder{RegisteredName: "fw-1"})
+		srv.model.AddEndpoint(ctx, &model.Endpoint{
+			Endpoint: &registry.NSERegistration{
+				NetworkService:         &registry.NetworkService{Name: networkServiceName},
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse-1"},
+			},
+		})
+		stored := &model.ClientConnection{
+			ConnectionID:            "1",
+			ForwarderRegisteredName: "fw-1",
+			Xcon:                    xcon(tc.storedIP),
+			Request:                 &networkservice.NetworkServiceRequest{},
+		}
+
+		srv.restoreXconnection(ctx, xcon("10.0.0.1/30"), stored, logrus.New(), "fw-1", &monitorManagerStub{})
+		g.Expect(healer.states).To(Equal(tc.healed), tc.name)
+		g.Expect(srv.model.GetClientConnection("1").Request).To(Equal(stored.Request), tc.name)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
+)
+
+// drainCheckInterval - interval of checking connections left on the draining forwarder
+var drainCheckInterval = 100 * time.Millisecond
+
+// DrainForwarder - marks forwarder as draining, so no new connections are placed on it, and migrates its connections
+// one by one to other forwarders. Returns once there are no connections left on the forwarder, or ctx is done.
+func (srv *networkServiceManager) DrainForwarder(ctx context.Context, forwarder string) error {
+	span := spanhelper.FromContext(ctx, "DrainForwarder")
+	defer span.Finish()
+	ctx = span.Context()
+	span.LogValue("forwarder", forwarder)
+
+	dp := srv.model.GetForwarder(forwarder)
+	if dp == nil {
+		err := errors.Errorf("forwarder %s is not registered", forwarder)
+		span.LogError(err)
+		return err
+	}
+	if !dp.Draining {
+		dp.Draining = true
+		srv.model.UpdateForwarder(ctx, dp)
+	}
+
+	migrated := map[string]bool{}
+	failed := map[string]error{}
+	for {
+		left, pending := 0, 0
+		for _, cc := range srv.model.GetAllClientConnections() {
+			if cc.ForwarderRegisteredName != forwarder || cc.ConnectionState == model.ClientConnectionClosing {
+				continue
+			}
+			left++
+			if failed[cc.GetID()] != nil {
+				continue
+			}
+			// Connections being requested or healed are migrated once they are ready
+			if migrated[cc.GetID()] || cc.ConnectionState != model.ClientConnectionReady {
+				pending++
+				continue
+			}
+			migrated[cc.GetID()] = true
+			// Failed connection is left on the forwarder, the rest of connections are still migrated
+			if err := srv.migrateConnection(ctx, cc, dp); err != nil {
+				span.LogError(err)
+				failed[cc.GetID()] = err
+			}
+		}
+		if left == 0 {
+			span.Logger().Infof("Forwarder %s is drained", forwarder)
+			return nil
+		}
+		if pending == 0 && len(failed) > 0 {
+			err := drainError(forwarder, failed)
+			span.LogError(err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			err := errors.Wrapf(ctx.Err(), "forwarder %s still has %d connections", forwarder, left)
+			span.LogError(err)
+			return err
+		case <-time.After(drainCheckInterval):
+		}
+	}
+}
+
+// drainError - returns error listing connections failed to be migrated from the forwarder
+func drainError(forwarder string, failed map[string]error) error {
+	ids := make([]string, 0, len(failed))
+	for id := range failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, failed[id].Error())
+	}
+	return errors.Errorf("failed to migrate %d connections from forwarder %s: %s", len(failed), forwarder, strings.Join(msgs, "; "))
+}
+
+// migrateConnection - heals cc on another forwarder supporting its mechanisms and waits for cc to leave the draining
+// forwarder. Path on the draining forwarder is closed once the new one is programmed, except for connections of remote
+// clients: Remote NSM has to request them again, so their path is closed first and healed by Remote NSM.
+func (srv *networkServiceManager) migrateConnection(ctx context.Context, cc *model.ClientConnection, dp *model.Forwarder) error {
+	span := spanhelper.FromContext(ctx, "migrateConnection")
+	defer span.Finish()
+	ctx = span.Context()
+	span.LogObject("connection", cc)
+
+	if other, _ := srv.model.SelectForwarder(func(fwd *model.Forwarder) bool {
+		return fwd.MechanismsConfigured && !fwd.Draining && fwd.SupportsConnections(cc.GetConnectionSource(), cc.GetConnectionDestination())
+	}); other == nil {
+		return errors.Errorf("no forwarder to migrate connection %s from %s to", cc.GetID(), dp.RegisteredName)
+	}
+
+	if cc.GetConnectionSource().IsRemote() {
+		if err := srv.closeForwarderXcon(ctx, cc, dp); err != nil {
+			span.LogError(err)
+		}
+		srv.Heal(ctx, cc, nsm.HealStateForwarderDown)
+	} else {
+		srv.Heal(ctx, cc, nsm.HealStateForwarderDrain)
+	}
+
+	for {
+		actual := srv.model.GetClientConnection(cc.GetID())
+		if actual == nil || actual.ForwarderRegisteredName != dp.RegisteredName {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "connection %s is not migrated from %s", cc.GetID(), dp.RegisteredName)
+		case <-time.After(drainCheckInterval):
+		}
+	}
+}
+
+// closeForwarderXcon - releases resources of cc on the draining forwarder before it is programmed on another one
+func (srv *networkServiceManager) closeForwarderXcon(ctx context.Context, cc *model.ClientConnection, dp *model.Forwarder) error {
+	if cc.ForwarderState == model.ForwarderStateNone {
+		return nil
+	}
+	forwarderClient, conn, err := srv.serviceRegistry.ForwarderConnection(ctx, dp)
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		defer func() { _ = conn.Close() }()
+	}
+	if _, err := forwarderClient.Close(ctx, cc.Xcon); err != nil {
+		return errors.Wrapf(err, "failed to close connection %s on forwarder %s", cc.GetID(), dp.RegisteredName)
+	}
+	srv.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+		modelCC.ForwarderState = model.ForwarderStateNone
+	})
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/memif"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+const forwarder2Name = "forwarder-2"
+
+func newDrainTestData() (*healTestData, *networkServiceManager, func()) {
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Minute
+	data.healProcessor.props.HealForwarderTimeout = time.Second
+	data.healProcessor.eventCh = make(chan healEvent, 1)
+	data.healProcessor.healCancellers = map[string]func(){}
+	go data.healProcessor.serve()
+
+	srv := &networkServiceManager{
+		NetworkServiceHealProcessor: data.healProcessor,
+		serviceRegistry:             data.serviceRegistry,
+		model:                       data.model,
+	}
+	return data, srv, func() { close(data.healProcessor.eventCh) }
+}
+
+func (data *healTestData) addDrainedConnection(id, mechanismType string) {
+	nse := data.createEndpoint(nse1Name, localNSMName)
+	xcon := data.createCrossConnection(false, false, id, "dst-"+id)
+	xcon.Id = id
+	xcon.GetSource().Mechanism = &connection.Mechanism{
+		Type:       mechanismType,
+		Parameters: map[string]string{mechanismCommon.InterfaceNameKey: "nsm-" + id},
+	}
+	xcon.GetDestination().Mechanism = &connection.Mechanism{Type: kernel.MECHANISM}
+	request := data.createRequest(false)
+	request.Connection.Id = id
+	cc := data.createClientConnection(id, xcon, nse, localNSMName, forwarder1Name, request)
+	cc.ConnectionState = model.ClientConnectionReady
+	cc.ForwarderState = model.ForwarderStateReady
+	data.model.AddClientConnection(context.Background(), cc)
+}
+
+func (data *healTestData) addTargetForwarder(mechanismTypes ...string) {
+	forwarder := &model.Forwarder{
+		RegisteredName:       forwarder2Name,
+		MechanismsConfigured: true,
+	}
+	for _, mechanismType := range mechanismTypes {
+		forwarder.LocalMechanisms = append(forwarder.LocalMechanisms, &connection.Mechanism{Type: mechanismType})
+	}
+	data.model.AddForwarder(context.Background(), forwarder)
+	data.connectionManager.forwarder = forwarder2Name
+}
+
+func TestDrainForwarder(t *testing.T) {
+	g := NewWithT(t)
+	data, srv, stop := newDrainTestData()
+	defer stop()
+
+	data.addTargetForwarder(kernel.MECHANISM)
+	data.addDrainedConnection("1", kernel.MECHANISM)
+	data.addDrainedConnection("2", kernel.MECHANISM)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	g.Expect(srv.DrainForwarder(ctx, forwarder1Name)).To(BeNil())
+
+	g.Expect(data.model.GetForwarder(forwarder1Name).Draining).To(BeTrue())
+	// Previous paths are closed under their own ids after connections are programmed on the other forwarder
+	g.Expect(data.serviceRegistry.forwarderClient.closed).To(HaveLen(2))
+	for _, closed := range data.serviceRegistry.forwarderClient.closed {
+		g.Expect(closed.GetId()).To(Or(Equal("1"), Equal("2")))
+	}
+	for _, cc := range data.model.GetAllClientConnections() {
+		g.Expect(cc.ForwarderRegisteredName).To(Equal(forwarder2Name))
+		g.Expect(cc.ConnectionState).To(Equal(model.ClientConnectionReady))
+		g.Expect(cc.Xcon.GetId()).To(Equal(model.MakeBeforeBreakXconID(cc.GetID())))
+		g.Expect(data.healProcessor.history.Get(cc.GetID())[0].HealState).To(Equal("ForwarderDrain"))
+	}
+	g.Expect(data.model.ForwarderConnections()[forwarder1Name]).To(BeZero())
+}
+
+func TestDrainForwarder_UnsupportedMechanism(t *testing.T) {
+	g := NewWithT(t)
+	data, srv, stop := newDrainTestData()
+	defer stop()
+
+	data.addTargetForwarder(kernel.MECHANISM)
+	data.addDrainedConnection("1", memif.MECHANISM)
+	data.addDrainedConnection("2", kernel.MECHANISM)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := srv.DrainForwarder(ctx, forwarder1Name)
+	g.Expect(err.Error()).To(ContainSubstring("failed to migrate 1 connections"))
+	g.Expect(err.Error()).To(ContainSubstring("no forwarder to migrate connection 1"))
+
+	// Failure of one connection does not stop migration of the rest
+	g.Expect(data.model.GetClientConnection("1").ForwarderRegisteredName).To(Equal(forwarder1Name))
+	g.Expect(data.model.GetClientConnection("2").ForwarderRegisteredName).To(Equal(forwarder2Name))
+	g.Expect(data.serviceRegistry.forwarderClient.closed).To(HaveLen(1))
+}
+
+func TestDrainForwarder_NoOtherForwarder(t *testing.T) {
+	g := NewWithT(t)
+	data, srv, stop := newDrainTestData()
+	defer stop()
+
+	data.addDrainedConnection("1", kernel.MECHANISM)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := srv.DrainForwarder(ctx, forwarder1Name)
+	g.Expect(err.Error()).To(ContainSubstring("no forwarder to migrate connection 1"))
+
+	g.Expect(data.serviceRegistry.forwarderClient.closed).To(BeEmpty())
+	g.Expect(data.model.GetClientConnection("1").ForwarderRegisteredName).To(Equal(forwarder1Name))
+}
+
+func TestDrainForwarder_NotRegistered(t *testing.T) {
+	g := NewWithT(t)
+	_, srv, stop := newDrainTestData()
+	defer stop()
+
+	err := srv.DrainForwarder(context.Background(), forwarder2Name)
+	g.Expect(err.Error()).To(ContainSubstring("is not registered"))
+}
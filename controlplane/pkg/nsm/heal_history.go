@@ -0,0 +1,187 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+)
+
+// healSubscriberBufferSize - amount of heal events queued for a slow subscriber before events are dropped
+const healSubscriberBufferSize = 100
+
+type healSubscriber struct {
+	connectionID string
+	events       chan *nsmdapi.HealEvent
+}
+
+// healHistory keeps last size events for each of last connections connections
+type healHistory struct {
+	sync.Mutex
+	size        int
+	connections int
+
+	events      map[string][]*nsmdapi.HealEvent
+	order       []string
+	subscribers map[*healSubscriber]bool
+}
+
+func newHealHistory(size, connections int) nsm.HealHistory {
+	return &healHistory{
+		size:        size,
+		connections: connections,
+		events:      make(map[string][]*nsmdapi.HealEvent),
+		subscribers: make(map[*healSubscriber]bool),
+	}
+}
+
+func (h *healHistory) Record(event *nsmdapi.HealEvent) {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.size > 0 {
+		id := event.GetConnectionId()
+		events, ok := h.events[id]
+		if !ok {
+			h.order = append(h.order, id)
+			h.evict()
+		}
+		events = append(events, event)
+		if len(events) > h.size {
+			events = events[len(events)-h.size:]
+		}
+		h.events[id] = events
+	}
+
+	for subscriber := range h.subscribers {
+		if subscriber.connectionID != "" && subscriber.connectionID != event.GetConnectionId() {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			logrus.Warnf("Heal history subscriber is too slow, dropping heal event: %v", event)
+		}
+	}
+}
+
+// evict removes the connections with the oldest first heal event above the limit
+func (h *healHistory) evict() {
+	for h.connections > 0 && len(h.order) > h.connections {
+		delete(h.events, h.order[0])
+		h.order = h.order[1:]
+	}
+}
+
+func (h *healHistory) Get(connectionID string) []*nsmdapi.HealEvent {
+	h.Lock()
+	defer h.Unlock()
+
+	return append([]*nsmdapi.HealEvent(nil), h.events[connectionID]...)
+}
+
+func (h *healHistory) Subscribe(connectionID string) (<-chan *nsmdapi.HealEvent, func()) {
+	subscriber := &healSubscriber{
+		connectionID: connectionID,
+		events:       make(chan *nsmdapi.HealEvent, healSubscriberBufferSize),
+	}
+
+	h.Lock()
+	h.subscribers[subscriber] = true
+	h.Unlock()
+
+	return subscriber.events, func() {
+		h.Lock()
+		delete(h.subscribers, subscriber)
+		h.Unlock()
+	}
+}
+
+type healRecordKeyType struct{}
+
+var healRecordKey healRecordKeyType
+
+// healRecord is a heal in progress, it is passed with context to heal functions
+type healRecord struct {
+	healID    string
+	healState nsm.HealState
+	attempts  int32
+	lastError error
+	// props - snapshot of properties taken on heal start, reloaded properties are applied to next heals only
+	props *properties.Properties
+}
+
+func withHealRecord(parent context.Context, record *healRecord) context.Context {
+	return context.WithValue(parent, healRecordKey, record)
+}
+
+func getHealRecord(ctx context.Context) *healRecord {
+	if record, ok := ctx.Value(healRecordKey).(*healRecord); ok {
+		return record
+	}
+	return &healRecord{}
+}
+
+func (p *healProcessor) recordAttemptFailed(ctx context.Context, cc *model.ClientConnection, err error) {
+	record := getHealRecord(ctx)
+	record.attempts++
+	record.lastError = err
+	p.recordEvent(ctx, cc, nsmdapi.HealEventType_HEAL_ATTEMPT_FAILED, err)
+}
+
+func (p *healProcessor) recordResult(ctx context.Context, cc *model.ClientConnection, healed bool) {
+	if !healed {
+		p.recordEvent(ctx, cc, nsmdapi.HealEventType_HEAL_FAILED, getHealRecord(ctx).lastError)
+		return
+	}
+	// Connection could be healed with another endpoint.
+	if healedCC := p.model.GetClientConnection(cc.GetID()); healedCC != nil {
+		cc = healedCC
+	}
+	p.recordEvent(ctx, cc, nsmdapi.HealEventType_HEAL_SUCCEEDED, nil)
+}
+
+func (p *healProcessor) recordEvent(ctx context.Context, cc *model.ClientConnection, eventType nsmdapi.HealEventType, err error) {
+	if p.history == nil {
+		return
+	}
+	record := getHealRecord(ctx)
+	event := &nsmdapi.HealEvent{
+		ConnectionId:   cc.GetID(),
+		HealId:         record.healID,
+		Type:           eventType,
+		NetworkService: cc.GetNetworkService(),
+		Endpoint:       cc.Endpoint.GetNetworkServiceEndpoint().GetName(),
+		Attempt:        record.attempts,
+		Timestamp:      ptypes.TimestampNow(),
+	}
+	if record.healState != 0 {
+		event.HealState = record.healState.String()
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	p.history.Record(event)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+)
+
+func TestHealHistory_Bounded(t *testing.T) {
+	g := NewWithT(t)
+	history := newHealHistory(2, 2)
+
+	for _, id := range []string{"1", "1", "1", "2", "3"} {
+		history.Record(&nsmdapi.HealEvent{ConnectionId: id, HealId: "heal-" + id})
+	}
+
+	g.Expect(history.Get("1")).To(BeEmpty())
+	g.Expect(history.Get("2")).To(HaveLen(1))
+	g.Expect(history.Get("3")).To(HaveLen(1))
+
+	history.Record(&nsmdapi.HealEvent{ConnectionId: "3", Attempt: 1})
+	history.Record(&nsmdapi.HealEvent{ConnectionId: "3", Attempt: 2})
+	events := history.Get("3")
+	g.Expect(events).To(HaveLen(2))
+	g.Expect(events[0].GetAttempt()).To(Equal(int32(1)))
+	g.Expect(events[1].GetAttempt()).To(Equal(int32(2)))
+}
+
+func TestHealHistory_Subscribe(t *testing.T) {
+	g := NewWithT(t)
+	history := newHealHistory(2, 2)
+
+	all, cancelAll := history.Subscribe("")
+	defer cancelAll()
+	filtered, cancelFiltered := history.Subscribe("2")
+
+	history.Record(&nsmdapi.HealEvent{ConnectionId: "1"})
+	history.Record(&nsmdapi.HealEvent{ConnectionId: "2"})
+
+	g.Expect((<-all).GetConnectionId()).To(Equal("1"))
+	g.Expect((<-all).GetConnectionId()).To(Equal("2"))
+	g.Expect((<-filtered).GetConnectionId()).To(Equal("2"))
+	g.Expect(filtered).To(BeEmpty())
+
+	cancelFiltered()
+	history.Record(&nsmdapi.HealEvent{ConnectionId: "2"})
+	g.Expect(filtered).To(BeEmpty())
+	g.Expect((<-all).GetConnectionId()).To(Equal("2"))
+}
@@ -12,9 +12,11 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 )
 
@@ -55,7 +57,8 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 		span.LogError(err)
 		return nil, err
 	}
-	endpoints := nsem.filterEndpoints(endpointResponse.GetNetworkServiceEndpoints(), endpointResponse.NetworkServiceManagers, ignoreEndpoints)
+	nsem.pruneEndpointCircuits(ctx, requestConnection.GetNetworkService(), endpointResponse)
+	endpoints := nsem.filterEndpoints(ctx, endpointResponse.GetNetworkServiceEndpoints(), endpointResponse.NetworkServiceManagers, ignoreEndpoints)
 
 	if len(endpoints) == 0 {
 		err = errors.Errorf("failed to find NSE for NetworkService %s. Checked: %d of total NSEs: %d",
@@ -78,7 +81,19 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 		return nsem.model.GetSelector().SelectEndpoint(requestConnection, endpointResponse.GetNetworkService(), endpoints)
 	}
 
-	endpoint := endpointSelect()
+	var endpoint *registry.NetworkServiceEndpoint
+	for endpoint == nil && len(endpoints) > 0 {
+		if endpoint = endpointSelect(); endpoint == nil {
+			break
+		}
+		// Selected endpoint with quarantine over needs the only trial, which could be taken by another request.
+		endpointName := registry.NewEndpointNSMName(endpoint, endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()])
+		if !nsem.model.AcquireEndpointTrial(ctx, endpointName) {
+			span.Logger().Infof("Trial request to endpoint %v is already in progress", endpointName)
+			endpoints = removeEndpoint(endpoints, endpoint)
+			endpoint = nil
+		}
+	}
 	if endpoint == nil {
 		err = errors.Errorf("failed to select NSE for NetworkService %s. Checked: %d of total NSEs: %d",
 			requestConnection.GetNetworkService(), len(ignoreEndpoints), len(endpoints))
@@ -93,6 +108,83 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 	}, nil
 }
 
+func (nsem *nseManager) ExplainEndpoint(ctx context.Context, requestConnection *connection.Connection, ignoreEndpoints []string) (*nsmdapi.ExplainEndpointSelectionReply, error) {
+	span := spanhelper.FromContext(ctx, "ExplainEndpoint")
+	defer span.Finish()
+	span.LogObject("request", requestConnection)
+	span.LogObject("ignores", ignoreEndpoints)
+
+	discoveryClient, err := nsem.serviceRegistry.DiscoveryClient(span.Context())
+	if err != nil {
+		span.LogError(err)
+		return nil, err
+	}
+	endpointResponse, err := discoveryClient.FindNetworkService(span.Context(), &registry.FindNetworkServiceRequest{
+		NetworkServiceName: requestConnection.GetNetworkService(),
+	})
+	span.LogObject("nseResponse", endpointResponse)
+	if err != nil {
+		span.LogError(err)
+		return nil, err
+	}
+
+	ignored := map[string]bool{}
+	for _, name := range ignoreEndpoints {
+		ignored[name] = true
+	}
+	ignores := map[registry.EndpointNSMName]*registry.NSERegistration{}
+	for _, endpoint := range endpointResponse.GetNetworkServiceEndpoints() {
+		if ignored[endpoint.GetName()] {
+			manager := endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]
+			ignores[registry.NewEndpointNSMName(endpoint, manager)] = &registry.NSERegistration{
+				NetworkServiceEndpoint: endpoint,
+				NetworkServiceManager:  manager,
+			}
+		}
+	}
+	endpoints := nsem.filterEndpoints(ctx, endpointResponse.GetNetworkServiceEndpoints(), endpointResponse.GetNetworkServiceManagers(), ignores)
+
+	reply := &nsmdapi.ExplainEndpointSelectionReply{
+		NetworkService: endpointResponse.GetNetworkService(),
+		Endpoints:      endpoints,
+	}
+	passed := map[*registry.NetworkServiceEndpoint]bool{}
+	for _, endpoint := range endpoints {
+		passed[endpoint] = true
+	}
+	for _, endpoint := range endpointResponse.GetNetworkServiceEndpoints() {
+		if !passed[endpoint] {
+			reply.FilteredEndpoints = append(reply.FilteredEndpoints, endpoint)
+		}
+	}
+
+	for _, match := range selector.ExplainMatches(requestConnection, endpointResponse.GetNetworkService(), endpoints) {
+		matchExplanation := &nsmdapi.MatchExplanation{
+			Match:         match.Match,
+			SourceMatched: match.SourceMatched,
+		}
+		for _, route := range match.Routes {
+			matchExplanation.Routes = append(matchExplanation.Routes, &nsmdapi.RouteExplanation{
+				Destination: route.Destination,
+				Endpoints:   route.Endpoints,
+			})
+		}
+		reply.Matches = append(reply.Matches, matchExplanation)
+	}
+
+	if targetEndpoint := requestConnection.GetNetworkServiceEndpointName(); len(targetEndpoint) > 0 {
+		for _, endpoint := range endpoints {
+			if endpoint.GetName() == targetEndpoint {
+				reply.SelectedEndpoint = endpoint
+			}
+		}
+	} else if len(endpoints) > 0 {
+		reply.SelectedEndpoint = selector.DryRunSelectEndpoint(nsem.model.GetSelector(), requestConnection, endpointResponse.GetNetworkService(), endpoints)
+	}
+	span.LogObject("reply", reply)
+	return reply, nil
+}
+
 /**
 ctx - we assume it is big enought to perform connection.
 */
@@ -116,7 +208,7 @@ func (nsem *nseManager) CreateNSEClient(ctx context.Context, endpoint *registry.
 		return &endpointClient{connection: conn, client: client}, nil
 	} else {
 		logger.Infof("Create remote NSE connection to endpoint: %v", endpoint)
-		ctx, cancel := context.WithTimeout(span.Context(), nsem.props.HealRequestConnectTimeout)
+		ctx, cancel := context.WithTimeout(span.Context(), nsem.props.Snapshot().HealRequestConnectTimeout)
 		defer cancel()
 		client, conn, err := nsem.serviceRegistry.RemoteNetworkServiceClient(ctx, endpoint.GetNetworkServiceManager())
 		if err != nil {
@@ -131,7 +223,7 @@ func (nsem *nseManager) IsLocalEndpoint(endpoint *registry.NSERegistration) bool
 }
 
 func (nsem *nseManager) CheckUpdateNSE(ctx context.Context, reg *registry.NSERegistration) bool {
-	pingCtx, pingCancel := context.WithTimeout(ctx, nsem.props.HealRequestConnectCheckTimeout)
+	pingCtx, pingCancel := context.WithTimeout(ctx, nsem.props.Snapshot().HealRequestConnectCheckTimeout)
 	defer pingCancel()
 
 	client, err := nsem.CreateNSEClient(pingCtx, reg)
@@ -149,12 +241,31 @@ func (nsem *nseManager) cleanupNSE(ctx context.Context, endpoint *model.Endpoint
 	logrus.Infof("NSM: Remove Endpoint since it is not available... %v", endpoint)
 }
 
-func (nsem *nseManager) filterEndpoints(endpoints []*registry.NetworkServiceEndpoint, managers map[string]*registry.NetworkServiceManager, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) []*registry.NetworkServiceEndpoint {
+// pruneEndpointCircuits deletes circuits of network service endpoints, which are not registered anymore
+func (nsem *nseManager) pruneEndpointCircuits(ctx context.Context, networkService string, endpointResponse *registry.FindNetworkServiceResponse) {
+	var registered []registry.EndpointNSMName
+	for _, endpoint := range endpointResponse.GetNetworkServiceEndpoints() {
+		registered = append(registered, registry.NewEndpointNSMName(endpoint, endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]))
+	}
+	nsem.model.PruneEndpointCircuits(ctx, networkService, registered)
+}
+
+func removeEndpoint(endpoints []*registry.NetworkServiceEndpoint, endpoint *registry.NetworkServiceEndpoint) []*registry.NetworkServiceEndpoint {
+	result := make([]*registry.NetworkServiceEndpoint, 0, len(endpoints))
+	for _, candidate := range endpoints {
+		if candidate != endpoint {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+func (nsem *nseManager) filterEndpoints(ctx context.Context, endpoints []*registry.NetworkServiceEndpoint, managers map[string]*registry.NetworkServiceManager, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) []*registry.NetworkServiceEndpoint {
 	result := []*registry.NetworkServiceEndpoint{}
-	// Do filter of endpoints
+	// Do filter of endpoints, quarantined endpoints are ignored as well
 	for _, candidate := range endpoints {
 		endpointName := registry.NewEndpointNSMName(candidate, managers[candidate.NetworkServiceManagerName])
-		if ignoreEndpoints[endpointName] == nil {
+		if ignoreEndpoints[endpointName] == nil && nsem.model.IsEndpointAvailable(endpointName) {
 			result = append(result, candidate)
 		}
 	}
@@ -19,12 +19,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
@@ -33,6 +35,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connectionstore"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/local"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
@@ -58,6 +61,12 @@ type networkServiceManager struct {
 	stateRestored    chan bool
 	renamedEndpoints map[string]string
 	nseManager       nsm.NetworkServiceEndpointManager
+	healHistory      nsm.HealHistory
+
+	connectionStore   *connectionstore.Store
+	storedConnections map[string]*model.ClientConnection
+	storedMtx         sync.Mutex
+	persistOnce       sync.Once
 
 	remoteService networkservice.NetworkServiceServer
 	ctx           context.Context
@@ -73,7 +82,7 @@ func (srv *networkServiceManager) LocalManager(clientConnection nsm.ClientConnec
 		common.NewMonitorService(clientConnection.(*model.ClientConnection).Monitor),
 		local.NewConnectionService(srv.model),
 		local.NewForwarderService(srv.model, srv.serviceRegistry),
-		local.NewEndpointSelectorService(srv.nseManager),
+		local.NewEndpointSelectorService(srv.nseManager, srv.model),
 		local.NewEndpointService(srv.nseManager, srv.props, srv.model),
 		common.NewCrossConnectService(),
 	)
@@ -97,40 +106,87 @@ func (srv *networkServiceManager) Model() model.Model {
 	return srv.model
 }
 
+func (srv *networkServiceManager) HealHistory() nsm.HealHistory {
+	return srv.healHistory
+}
+
 func (srv *networkServiceManager) GetHealProperties() *properties.Properties {
 	return srv.props
 }
 
 // NewNetworkServiceManager creates an instance of NetworkServiceManager
 func NewNetworkServiceManager(ctx context.Context, model model.Model, serviceRegistry serviceregistry.ServiceRegistry) nsm.NetworkServiceManager {
-	properties := properties.NewNsmProperties()
+	props := properties.NewNsmProperties()
 	nseManager := &nseManager{
 		serviceRegistry: serviceRegistry,
 		model:           model,
-		props:           properties,
+		props:           props,
 	}
 
 	srv := &networkServiceManager{
 		serviceRegistry:  serviceRegistry,
 		model:            model,
-		props:            properties,
+		props:            props,
 		stateRestored:    make(chan bool, 1),
 		renamedEndpoints: make(map[string]string),
 		nseManager:       nseManager,
 		ctx:              ctx,
 	}
 
+	values := props.Snapshot()
+	model.SetCircuitBreakerConfig(circuitBreakerConfig(values))
+	model.SetForwarderPolicies(forwarderPolicies(values))
+	// Heal history size is applied on start only, the rest of properties are read on use
+	srv.healHistory = newHealHistory(values.HealHistorySize, values.HealHistoryConnections)
+	if err := props.WatchConfig(ctx, func(values *properties.Properties) {
+		model.SetCircuitBreakerConfig(circuitBreakerConfig(values))
+		model.SetForwarderPolicies(forwarderPolicies(values))
+	}); err != nil {
+		logrus.Errorf("Failed to watch config file, properties are not reloaded: %v", err)
+	}
 	srv.NetworkServiceHealProcessor = newNetworkServiceHealProcessor(
 		serviceRegistry,
 		model,
-		properties,
+		props,
 		srv,
 		nseManager,
+		srv.healHistory,
 	)
+	go srv.watchWireguardKeyRotation()
 
 	return srv
 }
 
+// circuitBreakerConfig - returns configuration of endpoint circuit breaker from properties
+func circuitBreakerConfig(props *properties.Properties) model.CircuitBreakerConfig {
+	return model.CircuitBreakerConfig{
+		FailureThreshold: props.EndpointFailureThreshold,
+		FailureWindow:    props.EndpointFailureWindow,
+		Cooldown:         props.EndpointQuarantine,
+	}
+}
+
+// forwarderPolicies - returns forwarder selection policies by network service from properties
+func forwarderPolicies(props *properties.Properties) map[string]*model.ForwarderPolicy {
+	policies := map[string]*model.ForwarderPolicy{}
+	for _, policy := range props.ForwarderPolicies {
+		priorities := map[string]int{}
+		for _, priority := range policy.Priorities {
+			priorities[priority.Forwarder] = priority.Priority
+		}
+		labels := map[string]string{}
+		for _, label := range policy.Labels {
+			labels[label.Key] = label.Value
+		}
+		policies[policy.NetworkService] = &model.ForwarderPolicy{
+			Mechanisms: policy.Mechanisms,
+			Priorities: priorities,
+			Labels:     labels,
+		}
+	}
+	return policies
+}
+
 func create_logid() (uuid string) {
 	b := make([]byte, 4)
 	_, err := rand.Read(b)
@@ -166,22 +222,31 @@ func (srv *networkServiceManager) RestoreConnections(xcons []*crossconnect.Cross
 	defer span.Finish()
 	logger := span.Logger()
 	for _, xcon := range xcons {
-		srv.restoreXconnection(span.Context(), xcon, logger, forwarder, manager)
+		srv.restoreXconnection(span.Context(), xcon, srv.takeStoredConnection(model.XconConnectionID(xcon.GetId())), logger, forwarder, manager)
+	}
+	// Connections stored but lost by forwarder need to be programmed again.
+	for _, stored := range srv.takeForwarderStoredConnections(forwarder) {
+		srv.restoreLostConnection(span.Context(), stored, forwarder, manager)
 	}
 	logger.Infof("All connections are recovered...")
+	srv.persistOnce.Do(func() {
+		go srv.persistConnections()
+	})
 	// Notify state is restored
 	srv.stateRestored <- true
 }
 
-func (srv *networkServiceManager) restoreXconnection(ctx context.Context, xcon *crossconnect.CrossConnect, logger logrus.FieldLogger, forwarder string, manager nsm.MonitorManager) {
+// restoreXconnection - restores connection programmed in forwarder, stored is the connection from snapshot if any,
+// forwarder state differing from the stored one is healed with the stored request
+func (srv *networkServiceManager) restoreXconnection(ctx context.Context, xcon *crossconnect.CrossConnect, stored *model.ClientConnection, logger logrus.FieldLogger, forwarder string, manager nsm.MonitorManager) {
 	// Model should increase its id counter to max of xcons restored from forwarder
-	srv.model.CorrectIDGenerator(xcon.GetId())
+	srv.model.CorrectIDGenerator(model.XconConnectionID(xcon.GetId()))
 	span := spanhelper.FromContext(ctx, "restoreXConnection")
 	defer span.Finish()
 	span.LogObject("forwarder", forwarder)
 	span.LogObject("xcon", xcon)
 
-	existing := srv.model.GetClientConnection(xcon.GetId())
+	existing := srv.model.GetClientConnection(model.XconConnectionID(xcon.GetId()))
 	if existing == nil {
 		span.Logger().Infof("Restoring state of active connection %v", xcon)
 
@@ -199,22 +264,38 @@ func (srv *networkServiceManager) restoreXconnection(ctx context.Context, xcon *
 		connectionState, networkServiceName, endpointName = srv.getConnectionParameters(xcon, logger)
 
 		endpoint, endpointRenamed := srv.findEndpoint(span.Context(), endpointName, networkServiceName, discovery, xcon, span)
+		if endpoint == nil && stored != nil {
+			endpoint = stored.Endpoint
+		}
 
 		var request *networkservice.NetworkServiceRequest
 		workspaceName := ""
 		if src := xcon.GetSource(); src != nil && !src.IsRemote() {
-			// Update request to match source connection
-			request = &networkservice.NetworkServiceRequest{
-				Connection: src,
-				MechanismPreferences: []*connection.Mechanism{
-					src.Mechanism,
-				},
+			if stored != nil && stored.Request != nil {
+				// Original request keeps labels and mechanism preferences of the client
+				request = stored.Request
+			} else {
+				// Update request to match source connection
+				request = &networkservice.NetworkServiceRequest{
+					Connection: src,
+					MechanismPreferences: []*connection.Mechanism{
+						src.Mechanism,
+					},
+				}
 			}
 			workspaceName = src.GetMechanism().GetParameters()[mechanismCommon.Workspace]
 		}
 
 		monitor := manager.LocalConnectionMonitor(workspaceName)
 		clientConnection := srv.createConnection(xcon, request, endpoint, dp, connectionState, monitor)
+		differs := false
+		if stored != nil {
+			clientConnection.RemoteNsm = stored.RemoteNsm
+			if differs = !proto.Equal(stored.Xcon, xcon); differs {
+				span.LogObject("stored-xcon", stored.Xcon)
+				span.Logger().Infof("Forwarder state of connection %v differs from the stored one", xcon.GetId())
+			}
+		}
 		srv.model.AddClientConnection(span.Context(), clientConnection)
 
 		if monitor == nil {
@@ -223,7 +304,12 @@ func (srv *networkServiceManager) restoreXconnection(ctx context.Context, xcon *
 			return
 		}
 
-		srv.performHeal(span.Context(), xcon, endpoint, endpointRenamed, clientConnection, logger)
+		if differs && request != nil && endpoint != nil && xcon.GetSource().GetState() != connection.State_DOWN {
+			// Stored request is performed again, so forwarder is re-programmed to match the stored connection
+			srv.Heal(span.Context(), clientConnection, nsm.HealStateDstUpdate)
+		} else {
+			srv.performHeal(span.Context(), xcon, endpoint, endpointRenamed, clientConnection, logger)
+		}
 		span.LogObject("restored", xcon)
 	}
 }
@@ -289,7 +375,7 @@ func (srv *networkServiceManager) performHeal(ctx context.Context, xcon *crossco
 
 func (srv *networkServiceManager) createConnection(xcon *crossconnect.CrossConnect, request *networkservice.NetworkServiceRequest, endpoint *registry.NSERegistration, dp *model.Forwarder, state model.ClientConnectionState, monitor connectionmonitor.MonitorServer) *model.ClientConnection {
 	return &model.ClientConnection{
-		ConnectionID:            xcon.GetId(),
+		ConnectionID:            model.XconConnectionID(xcon.GetId()),
 		Request:                 request,
 		Xcon:                    xcon,
 		Endpoint:                endpoint, // We do not have endpoint here.
@@ -362,6 +448,17 @@ func (srv *networkServiceManager) getConnectionParameters(xcon *crossconnect.Cro
 			} else {
 				srv.serviceRegistry.VniAllocator().Restore(srcIP, dstIP, vni)
 			}
+		case gre.MECHANISM:
+			m := gre.ToMechanism(mm)
+			srcIP, err := m.SrcIP()
+			dstIP, err2 := m.DstIP()
+			key, err3 := m.Key()
+			if err != nil || err2 != nil || err3 != nil {
+				logrus.Errorf("Error retrieving SRC/DST IP or GRE key from Remote connection %v %v %v", err, err2, err3)
+			} else {
+				// GRE keys share the allocator with VXLAN VNIs
+				srv.serviceRegistry.VniAllocator().Restore(srcIP, dstIP, key)
+			}
 		case srv6.MECHANISM:
 			m := srv6.ToMechanism(mm)
 			hardwareAddress, err := m.DstHardwareAddress()
@@ -393,7 +490,7 @@ func (srv *networkServiceManager) RemoteConnectionLost(ctx context.Context, clie
 	})
 
 	go func() {
-		<-time.After(srv.props.HealTimeout)
+		<-time.After(srv.props.Snapshot().HealTimeout)
 
 		if modelCC := srv.model.GetClientConnection(clientConnection.GetID()); modelCC != nil && modelCC.ConnectionState == model.ClientConnectionHealingBegin {
 			logrus.Errorf("NSM: Timeout happened for checking connection status from Healing.. %v. Closing connection...", clientConnection)
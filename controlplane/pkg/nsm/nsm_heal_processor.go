@@ -14,8 +14,12 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
 
+	"github.com/golang/protobuf/proto"
+
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
 
 	"github.com/sirupsen/logrus"
@@ -34,8 +38,13 @@ type healProcessor struct {
 	healCancellersMutex sync.Mutex
 	manager             nsm.NetworkServiceRequestManager
 	nseManager          nsm.NetworkServiceEndpointManager
+	history             nsm.HealHistory
 
 	eventCh chan healEvent
+	// renegotiations keeps ids of connections Wireguard keys are renegotiated for
+	renegotiations sync.Map
+
+	timeAfter func(time.Duration) <-chan time.Time
 }
 
 type healEvent struct {
@@ -50,15 +59,18 @@ func newNetworkServiceHealProcessor(
 	model model.Model,
 	properties *properties.Properties,
 	manager nsm.NetworkServiceRequestManager,
-	nseManager nsm.NetworkServiceEndpointManager) nsm.NetworkServiceHealProcessor {
+	nseManager nsm.NetworkServiceEndpointManager,
+	history nsm.HealHistory) nsm.NetworkServiceHealProcessor {
 	p := &healProcessor{
 		serviceRegistry: serviceRegistry,
 		model:           model,
 		props:           properties,
 		manager:         manager,
 		nseManager:      nseManager,
+		history:         history,
 		eventCh:         make(chan healEvent, 1),
 		healCancellers:  make(map[string]func()),
+		timeAfter:       time.After,
 	}
 	go p.serve()
 
@@ -88,9 +100,16 @@ func (p *healProcessor) Heal(ctx context.Context, clientConnection nsm.ClientCon
 
 	healID := create_logid()
 	logger.Infof("NSM_Heal(%v) %v", healID, cc)
+	props := p.props.Snapshot()
+	ctx = withHealRecord(ctx, &healRecord{
+		healID:    healID,
+		healState: healState,
+		props:     props,
+	})
 
-	if !p.props.HealEnabled {
+	if !props.HealEnabled {
 		logger.Infof("NSM_Heal(%v) Is Disabled/Closing connection %v", healID, cc)
+		p.recordEvent(ctx, cc, nsmdapi.HealEventType_HEAL_FAILED, errors.New("healing is disabled"))
 		_ = p.CloseConnection(ctx, cc)
 		return
 	}
@@ -162,18 +181,22 @@ func (p *healProcessor) serve() {
 			healed := false
 
 			ctx = common.WithModelConnection(ctx, e.cc)
+			p.recordEvent(ctx, e.cc, nsmdapi.HealEventType_HEAL_STARTED, nil)
 
 			switch e.healState {
 			case nsm.HealStateDstDown:
 				healed = p.healDstDown(ctx, e.cc)
 			case nsm.HealStateForwarderDown:
 				healed = p.healForwarderDown(ctx, e.cc)
+			case nsm.HealStateForwarderDrain:
+				healed = p.healForwarderDrain(ctx, e.cc)
 			case nsm.HealStateDstUpdate:
 				healed = p.healDstUpdate(ctx, e.cc)
 			case nsm.HealStateDstNmgrDown:
 				healed = p.healDstMgrDown(ctx, e.cc)
 			}
 
+			p.recordResult(ctx, e.cc, healed)
 			if healed {
 				span.LogValue("status", "healed")
 				logger.Infof("NSM_Heal(%v) Heal: Connection recovered: %v", e.healID, e.cc)
@@ -195,11 +218,12 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 	logger := span.Logger()
 	// Update context
 	ctx = span.Context()
+	props := p.healProperties(ctx)
 
 	logger.Infof("NSM_Heal(1.1.1) Checking if DST die is NSMD/DST die...")
 	// Check if this is a really HealStateDstDown or HealStateDstNmgrDown
 	if !p.nseManager.IsLocalEndpoint(cc.Endpoint) {
-		waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
+		waitCtx, waitCancel := context.WithTimeout(ctx, props.HealTimeout*3)
 		defer waitCancel()
 		remoteNsmClient, err := p.nseManager.CreateNSEClient(waitCtx, cc.Endpoint)
 		if remoteNsmClient != nil {
@@ -223,8 +247,11 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 	// We are client NSMd, we need to try recover our connection srv.
 	// Wait for NSE not equal to down one, since we know it will be re-registered with new endpoint name.
 	ctx = p.waitForNSEUpdateContext(ctx, cc.Endpoint, cc)
+	ctx, previous := p.makeBeforeBreak(ctx, cc)
 	// Fallback to heal with choose of new NSE.
-	for attempt := 0; attempt < p.props.HealRetryCount; attempt++ {
+	// New NSE is chosen by the network service selector, so the affinity one maps the client by its labels onto the
+	// same hash ring as on the initial request.
+	for attempt := 0; attempt < props.HealRetryCount; attempt++ {
 		// If client context is cancelled, we need to stop attempts.
 		if ctx.Err() != nil {
 			logger.Info("Client context is broken, stopping heal attempts")
@@ -232,7 +259,7 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 		}
 
 		attemptSpan := spanhelper.FromContext(ctx, fmt.Sprintf("healing-attempt-%v", attempt))
-		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), p.props.HealRequestTimeout)
+		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), props.HealRequestTimeout)
 		defer requestCancel()
 		defer attemptSpan.Finish()
 
@@ -240,12 +267,14 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 		_, err := p.manager.LocalManager(cc).Request(requestCtx, cc.Request)
 		span.LogError(err)
 		if err == nil {
+			p.breakPreviousPath(ctx, previous)
 			return true
 		}
-		logger.Errorf("NSM_Heal(2.3.1) Failed to heal connection: %v. Delaying: %v", err, p.props.HealRetryDelay)
-		if attempt+1 < p.props.HealRetryCount {
+		logger.Errorf("NSM_Heal(2.3.1) Failed to heal connection: %v", err)
+		p.recordAttemptFailed(ctx, cc, err)
+		if attempt+1 < props.HealRetryCount {
 			attemptSpan.Finish()
-			<-time.After(p.props.HealRetryDelay)
+			p.backoff(ctx, &props.HealRetryBackoff, attempt)
 			continue
 		}
 	}
@@ -253,8 +282,9 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 }
 
 func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientConnection) bool {
+	props := p.healProperties(ctx)
 	var cancel context.CancelFunc
-	ctx, cancel = context.WithTimeout(ctx, p.props.HealTimeout)
+	ctx, cancel = context.WithTimeout(ctx, props.HealTimeout)
 	defer cancel()
 
 	span := spanhelper.FromContext(ctx, "healForwarderDown")
@@ -264,9 +294,10 @@ func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientC
 	// Forwarder is down, we only need to re-programm forwarder.
 	// 1. Wait for forwarder to appear.
 	logger.Infof("NSM_Heal(3.1) Waiting for Forwarder to recovery...")
-	if err := p.serviceRegistry.WaitForForwarderAvailable(span.Context(), p.model, p.props.HealForwarderTimeout); err != nil {
-		err = errors.Errorf("NSM_Heal(3.1) Forwarder is not available on recovery for timeout %v: %v", p.props.HealForwarderTimeout, err)
+	if err := p.serviceRegistry.WaitForForwarderAvailable(span.Context(), p.model, props.HealForwarderTimeout); err != nil {
+		err = errors.Errorf("NSM_Heal(3.1) Forwarder is not available on recovery for timeout %v: %v", props.HealForwarderTimeout, err)
 		span.LogError(err)
+		p.recordAttemptFailed(ctx, cc, err)
 		return false
 	}
 	logger.Infof("NSM_Heal(3.2) Forwarder is now available...")
@@ -286,10 +317,56 @@ func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientC
 	request := cc.Request.Clone()
 	request.SetRequestConnection(cc.GetConnectionSource())
 
-	if _, err := p.manager.LocalManager(cc).Request(span.Context(), cc.Request); err != nil {
+	for attempt := 0; attempt < props.HealRetryCount; attempt++ {
+		// If client context is cancelled, we need to stop attempts.
+		if span.Context().Err() != nil {
+			logger.Info("Client context is broken, stopping heal attempts")
+			break
+		}
+
+		_, err := p.manager.LocalManager(cc).Request(span.Context(), cc.Request)
+		if err == nil {
+			return true
+		}
 		logger.Errorf("NSM_Heal(3.5) Failed to heal connection: %v", err)
+		p.recordAttemptFailed(ctx, cc, err)
+		if attempt+1 < props.HealRetryCount {
+			p.backoff(span.Context(), &props.HealRetryBackoff, attempt)
+		}
+	}
+
+	return false
+}
+
+// healForwarderDrain - programs connection on another forwarder next to its path on the draining one, the previous path
+// is closed once connection is switched to the new one
+func (p *healProcessor) healForwarderDrain(ctx context.Context, cc *model.ClientConnection) bool {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, p.healProperties(ctx).HealTimeout)
+	defer cancel()
+
+	span := spanhelper.FromContext(ctx, "healForwarderDrain")
+	defer span.Finish()
+	ctx = span.Context()
+
+	logger := span.Logger()
+	logger.Infof("NSM_Heal(8.1) Migrating connection from draining forwarder %v...", cc.ForwarderRegisteredName)
+	// Model returns a copy, so it is not changed by the request.
+	previous := p.model.GetClientConnection(cc.GetID())
+	if previous == nil || cc.Request == nil {
+		return false
+	}
+
+	request := cc.Request.Clone()
+	request.SetRequestConnection(cc.GetConnectionSource())
+
+	if _, err := p.manager.LocalManager(cc).Request(common.WithMakeBeforeBreak(ctx), request); err != nil {
+		span.LogError(err)
+		logger.Errorf("NSM_Heal(8.2) Failed to migrate connection: %v", err)
+		p.recordAttemptFailed(ctx, cc, err)
 		return false
 	}
+	p.breakPreviousPath(ctx, previous)
 
 	return true
 }
@@ -300,7 +377,7 @@ func (p *healProcessor) healDstUpdate(ctx context.Context, cc *model.ClientConne
 	ctx = span.Context()
 
 	var cancel context.CancelFunc
-	ctx, cancel = context.WithTimeout(ctx, p.props.HealTimeout)
+	ctx, cancel = context.WithTimeout(ctx, p.healProperties(ctx).HealTimeout)
 	defer cancel()
 
 	logger := span.Logger()
@@ -314,12 +391,15 @@ func (p *healProcessor) healDstUpdate(ctx context.Context, cc *model.ClientConne
 	request := cc.Request.Clone()
 	request.SetRequestConnection(cc.GetConnectionSource())
 
+	ctx, previous := p.makeBeforeBreak(ctx, cc)
 	err := p.performRequest(ctx, request, cc)
 	if err != nil {
 		span.LogError(err)
 		logger.Errorf("NSM_Heal(5.2) Failed to heal connection: %v", err)
+		p.recordAttemptFailed(ctx, cc, err)
 		return false
 	}
+	p.breakPreviousPath(ctx, previous)
 
 	return true
 }
@@ -343,12 +423,13 @@ func (p *healProcessor) healDstMgrDown(ctx context.Context, cc *model.ClientConn
 	ctx = span.Context()
 	logger := span.Logger()
 	logger.Infof("NSM_Heal(6.1) Starting DST + NSMGR Heal...")
+	props := p.healProperties(ctx)
 
 	var endpointName string
 	// Wait for exact same NSE to be available with NSMD connection alive.
 	if cc.Endpoint != nil {
 		endpointName = cc.Endpoint.GetNetworkServiceEndpoint().GetName()
-		waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
+		waitCtx, waitCancel := context.WithTimeout(ctx, props.HealTimeout*3)
 		defer waitCancel()
 		if !p.waitNSE(waitCtx, endpointName, cc.GetNetworkService(), p.nseIsSameAndAvailable) {
 			span.LogValue("waitNSE", "failed to find endpoint by name with timeout")
@@ -357,9 +438,9 @@ func (p *healProcessor) healDstMgrDown(ctx context.Context, cc *model.ClientConn
 			})
 		}
 	}
-	for attempt := 0; attempt < p.props.HealRetryCount; attempt++ {
+	for attempt := 0; attempt < props.HealRetryCount; attempt++ {
 		attemptSpan := spanhelper.FromContext(ctx, fmt.Sprintf("healing-attempt-%v", attempt))
-		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), p.props.HealRequestTimeout)
+		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), props.HealRequestTimeout)
 		defer requestCancel()
 		defer attemptSpan.Finish()
 		err := p.performRequest(requestCtx, cc.Request, cc)
@@ -367,12 +448,13 @@ func (p *healProcessor) healDstMgrDown(ctx context.Context, cc *model.ClientConn
 			attemptSpan.LogObject("state", "healed")
 			return true
 		}
-		err = errors.Errorf("heal(6.2.3) Failed to heal connection: %v. Delaying: %v", err, p.props.HealRetryDelay)
+		err = errors.Errorf("heal(6.2.3) Failed to heal connection: %v", err)
 		span.LogError(err)
+		p.recordAttemptFailed(ctx, cc, err)
 		attemptSpan.Finish()
-		if attempt+1 < p.props.HealRetryCount {
+		if attempt+1 < props.HealRetryCount {
 			attemptSpan.Finish()
-			<-time.After(p.props.HealRetryDelay)
+			p.backoff(ctx, &props.HealRetryBackoff, attempt)
 			continue
 		}
 	}
@@ -380,6 +462,62 @@ func (p *healProcessor) healDstMgrDown(ctx context.Context, cc *model.ClientConn
 	return true
 }
 
+// makeBeforeBreak - requests programming of the new path next to the previous one if make-before-break heal is enabled,
+// returns the previous path to be closed after connection is switched
+func (p *healProcessor) makeBeforeBreak(ctx context.Context, cc *model.ClientConnection) (context.Context, *model.ClientConnection) {
+	if !p.healProperties(ctx).HealMakeBeforeBreak || cc.GetConnectionSource().IsRemote() || cc.ForwarderState != model.ForwarderStateReady {
+		return ctx, nil
+	}
+	// Model returns a copy, so it is not changed by the request.
+	previous := p.model.GetClientConnection(cc.GetID())
+	if previous == nil {
+		return ctx, nil
+	}
+	return common.WithMakeBeforeBreak(ctx), previous
+}
+
+// breakPreviousPath - closes cross connection and endpoint connection of previous path, if connection was switched to
+// the new path programmed next to it
+func (p *healProcessor) breakPreviousPath(ctx context.Context, previous *model.ClientConnection) {
+	if previous == nil {
+		return
+	}
+	cc := p.model.GetClientConnection(previous.GetID())
+	if cc == nil || cc.Xcon.GetId() == previous.Xcon.GetId() && cc.ForwarderRegisteredName == previous.ForwarderRegisteredName {
+		// Path was re-programmed in place, nothing to close.
+		return
+	}
+
+	span := spanhelper.FromContext(ctx, "breakPreviousPath")
+	defer span.Finish()
+	span.LogObject("previous", previous.Xcon)
+
+	closeCtx, closeCancel := context.WithTimeout(span.Context(), p.healProperties(ctx).CloseTimeout)
+	defer closeCancel()
+
+	if forwarder := p.model.GetForwarder(previous.ForwarderRegisteredName); forwarder != nil {
+		forwarderClient, forwarderConn, err := p.serviceRegistry.ForwarderConnection(closeCtx, forwarder)
+		if err == nil {
+			// New path is programmed under another cross connect id, so previous one is closed with its own id.
+			xcon := proto.Clone(previous.Xcon).(*crossconnect.CrossConnect)
+			_, err = forwarderClient.Close(closeCtx, xcon)
+			if forwarderConn != nil {
+				_ = forwarderConn.Close()
+			}
+		}
+		span.LogError(err)
+	}
+
+	if previous.Endpoint.GetEndpointNSMName() != cc.Endpoint.GetEndpointNSMName() {
+		nseClient, err := p.nseManager.CreateNSEClient(closeCtx, previous.Endpoint)
+		if err == nil {
+			err = nseClient.Close(closeCtx, previous.Xcon.GetDestination())
+			span.LogError(nseClient.Cleanup())
+		}
+		span.LogError(err)
+	}
+}
+
 type nseValidator func(ctx context.Context, endpoint string, reg *registry.NSERegistration) bool
 
 func (p *healProcessor) nseIsNewAndAvailable(ctx context.Context, endpointName string, reg *registry.NSERegistration) bool {
@@ -424,6 +562,7 @@ func (p *healProcessor) waitNSE(ctx context.Context, endpointName, networkServic
 	span.LogObject("networkService", networkService)
 
 	logger := span.Logger()
+	props := p.healProperties(ctx)
 	discoveryClient, err := p.serviceRegistry.DiscoveryClient(span.Context())
 	if err != nil {
 		span.LogError(err)
@@ -440,7 +579,7 @@ func (p *healProcessor) waitNSE(ctx context.Context, endpointName, networkServic
 		logger.Infof("Complete Waiting for Remote NSE/NSMD with network service %s. Since elapsed: %v", networkService, time.Since(st))
 	}()
 
-	for {
+	for attempt := 0; ; attempt++ {
 		logger.Infof("NSM: RemoteNSE: Waiting for NSE with network service %s. Since elapsed: %v", networkService, time.Since(st))
 
 		// If client context was cancelled, we need to stop waiting.
@@ -464,17 +603,35 @@ func (p *healProcessor) waitNSE(ctx context.Context, endpointName, networkServic
 			}
 		}
 
-		if time.Since(st) > p.props.HealDSTNSEWaitTimeout {
+		if time.Since(st) > props.HealDSTNSEWaitTimeout {
 			span.LogError(errors.Errorf("timeout waiting for NetworkService: %v timeout: %v", networkService, time.Since(st)))
 			return false
 		}
 		// Wait a bit
-		<-time.After(p.props.HealDSTNSEWaitTick)
+		p.backoff(ctx, &props.HealDSTNSEWaitBackoff, attempt)
+	}
+}
+
+// healProperties - returns snapshot of properties the heal in progress was started with
+func (p *healProcessor) healProperties(ctx context.Context) *properties.Properties {
+	if props := getHealRecord(ctx).props; props != nil {
+		return props
+	}
+	return p.props.Snapshot()
+}
+
+// backoff - waits for the delay of backoff policy after failed attempt, returns earlier if ctx is done
+func (p *healProcessor) backoff(ctx context.Context, backoff *properties.Backoff, attempt int) {
+	delay := backoff.Delay(attempt)
+	logrus.Infof("NSM_Heal Delaying attempt %v: %v", attempt+1, delay)
+	select {
+	case <-ctx.Done():
+	case <-p.timeAfter(delay):
 	}
 }
 
 func (p *healProcessor) waitForNSEUpdateContext(ctx context.Context, endpoint *registry.NSERegistration, cc *model.ClientConnection) context.Context {
-	waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
+	waitCtx, waitCancel := context.WithTimeout(ctx, p.healProperties(ctx).HealTimeout*3)
 	defer waitCancel()
 	if !p.waitNSE(waitCtx, endpoint.NetworkServiceEndpoint.Name, cc.GetNetworkService(), p.nseIsNewAndAvailable) {
 		// Mark endpoint as ignored.
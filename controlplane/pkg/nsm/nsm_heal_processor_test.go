@@ -2,6 +2,7 @@ package nsm
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,14 +20,20 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/local"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	test_utils "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/tests/utils"
+	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 )
 
 const (
@@ -94,6 +101,7 @@ func newHealTestData() *healTestData {
 		discoveryClient: &discoveryClientStub{
 			response: data.createFindNetworkServiceResponse(),
 		},
+		forwarderClient: &forwarderClientStub{},
 	}
 	data.connectionManager = &connectionManagerStub{
 		model: data.model,
@@ -115,6 +123,8 @@ func newHealTestData() *healTestData {
 		},
 		nseManager: data.nseManager,
 		manager:    data.connectionManager,
+		history:    newHealHistory(10, 10),
+		timeAfter:  time.After,
 	}
 
 	data.model.SetNsm(&registry.NetworkServiceManager{
@@ -285,6 +295,224 @@ func TestHealDstDown_LocalClientRemoteEndpoint_NoNSEFound(t *testing.T) {
 		Verify(t)
 }
 
+func TestHealDstDown_LocalClientRemoteEndpoint_Affinity(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+	data.healProcessor.props.HealRequestTimeout = time.Second
+	data.model.SetNetworkServiceSelector(networkServiceName, selector.NewAffinitySelector([]string{connection.PodNameKey}))
+
+	// Heal request goes through the endpoint selector choosing endpoints by the network service selector
+	manager := &selectingManagerStub{
+		connectionManagerStub: data.connectionManager,
+		nseManager: &nseManager{
+			serviceRegistry: data.serviceRegistry,
+			model:           data.model,
+			props:           data.healProcessor.props,
+		},
+		downEndpoints: map[string]bool{nse1Name: true},
+	}
+	data.healProcessor.manager = manager
+
+	nse1 := data.createEndpoint(nse1Name, remoteNSMName)
+	nses := []*registry.NSERegistration{nse1}
+	for _, name := range []string{nse2Name, "nse-3", "nse-4", "nse-5"} {
+		nse := data.createEndpoint(name, remoteNSMName)
+		data.nseManager.nses = append(data.nseManager.nses, nse)
+		nses = append(nses, nse)
+	}
+	data.serviceRegistry.discoveryClient.response = data.createFindNetworkServiceResponse(nses...)
+
+	xcon := data.createCrossConnection(false, true, "src", "dst")
+	request := data.createRequest(false)
+	request.Connection.Id = "id"
+	request.Connection.Labels = map[string]string{connection.PodNameKey: "client-pod"}
+	request.MechanismPreferences = []*connection.Mechanism{{Type: kernel.MECHANISM}}
+	cc := data.createClientConnection("id", xcon, nse1, remoteNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), cc)
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.model.GetClientConnection("id"))
+	g.Expect(healed).To(BeTrue())
+
+	// Healed connection lands on the endpoint the hash ring maps the client to among the available ones
+	var available []*registry.NetworkServiceEndpoint
+	for _, nse := range nses[1:] {
+		available = append(available, nse.GetNetworkServiceEndpoint())
+	}
+	expected := selector.NewAffinitySelector([]string{connection.PodNameKey}).SelectEndpoint(request.GetConnection(), nil, available)
+	g.Expect(expected).NotTo(BeNil())
+	g.Expect(manager.requested).To(Equal([]string{nse1Name, expected.GetName()}))
+
+	test_utils.NewModelVerifier(data.model).
+		ClientConnectionExists("id", "src", "-", remoteNSMName, expected.GetName(), forwarder1Name).
+		ForwarderExists(forwarder1Name).
+		Verify(t)
+}
+
+func TestHealDstDown_LocalClientLocalEndpoint_RequestFailedBackoff(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealRetryCount = 4
+	data.healProcessor.props.HealRetryBackoff = properties.Backoff{
+		Initial:    time.Second,
+		Multiplier: 2,
+		Max:        3 * time.Second,
+	}
+
+	var delays []time.Duration
+	data.healProcessor.timeAfter = func(delay time.Duration) <-chan time.Time {
+		delays = append(delays, delay)
+		return time.After(0)
+	}
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.requestError = errors.New("request error")
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeFalse())
+	g.Expect(delays).To(Equal([]time.Duration{time.Second, 2 * time.Second, 3 * time.Second}))
+}
+
+func TestHeal_History(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealRetryCount = 2
+	data.healProcessor.timeAfter = func(time.Duration) <-chan time.Time {
+		return time.After(0)
+	}
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.requestError = errors.New("request error")
+
+	ctx := withHealRecord(context.Background(), &healRecord{
+		healID:    "heal-1",
+		healState: nsm.HealStateDstDown,
+	})
+	cc := data.cloneClientConnection(connection)
+	healed := data.healProcessor.healDstDown(ctx, cc)
+	data.healProcessor.recordResult(ctx, cc, healed)
+	g.Expect(healed).To(BeFalse())
+
+	events := data.healProcessor.history.Get("id")
+	g.Expect(events).To(HaveLen(3))
+	for i, eventType := range []nsmdapi.HealEventType{
+		nsmdapi.HealEventType_HEAL_ATTEMPT_FAILED,
+		nsmdapi.HealEventType_HEAL_ATTEMPT_FAILED,
+		nsmdapi.HealEventType_HEAL_FAILED,
+	} {
+		g.Expect(events[i].GetType()).To(Equal(eventType))
+		g.Expect(events[i].GetHealId()).To(Equal("heal-1"))
+		g.Expect(events[i].GetHealState()).To(Equal("DstDown"))
+		g.Expect(events[i].GetEndpoint()).To(Equal(nse1Name))
+		g.Expect(events[i].GetError()).To(Equal("request error"))
+	}
+	g.Expect(events[0].GetAttempt()).To(Equal(int32(1)))
+	g.Expect(events[2].GetAttempt()).To(Equal(int32(2)))
+}
+
+func TestHealForwarderDown_RequestFailedBackoff(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Minute
+	data.healProcessor.props.HealForwarderTimeout = time.Second
+	data.healProcessor.props.HealRetryCount = 3
+	data.healProcessor.props.HealRetryBackoff = properties.Backoff{
+		Initial:    500 * time.Millisecond,
+		Multiplier: 3,
+		Max:        time.Minute,
+	}
+
+	var delays []time.Duration
+	data.healProcessor.timeAfter = func(delay time.Duration) <-chan time.Time {
+		delays = append(delays, delay)
+		if len(delays) == 2 {
+			data.connectionManager.requestError = nil
+		}
+		return time.After(0)
+	}
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.requestError = errors.New("request error")
+
+	healed := data.healProcessor.healForwarderDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+	g.Expect(delays).To(Equal([]time.Duration{500 * time.Millisecond, 1500 * time.Millisecond}))
+}
+
+func TestHealDstDown_LocalClientRemoteEndpoint_MakeBeforeBreak(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealMakeBeforeBreak = true
+	data.healProcessor.props.CloseTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, remoteNSMName)
+
+	nse2 := data.createEndpoint(nse2Name, remoteNSMName)
+	data.nseManager.nses = append(data.nseManager.nses, nse2)
+
+	xcon := data.createCrossConnection(false, true, "src", "dst")
+	xcon.Source.Mechanism = &connection.Mechanism{
+		Type:       kernel.MECHANISM,
+		Parameters: map[string]string{mechanismCommon.InterfaceNameKey: "nsm0"},
+	}
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, remoteNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.serviceRegistry.discoveryClient.response = data.createFindNetworkServiceResponse(nse2)
+	data.connectionManager.nse = nse2
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+
+	cc := data.model.GetClientConnection("id")
+	g.Expect(cc.Xcon.GetId()).To(Equal(xcon.GetId() + "-mbb"))
+	g.Expect(cc.Xcon.GetSource().GetMechanism().GetParameters()[mechanismCommon.InterfaceNameKey]).To(Equal("nsm0-mbb"))
+	g.Expect(cc.Endpoint.GetNetworkServiceEndpoint().GetName()).To(Equal(nse2Name))
+
+	// Previous path is closed under its own id only after connection is switched to the new one.
+	g.Expect(data.serviceRegistry.forwarderClient.closed).To(HaveLen(1))
+	closed := data.serviceRegistry.forwarderClient.closed[0]
+	g.Expect(closed.GetId()).To(Equal(xcon.GetId()))
+	g.Expect(closed.GetSource().GetMechanism().GetParameters()[mechanismCommon.InterfaceNameKey]).To(Equal("nsm0"))
+	g.Expect(data.nseManager.nseClients[nse1Name].closed.GetId()).To(Equal("dst"))
+}
+
+func TestMakeBeforeBreakInterfaceName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(local.MakeBeforeBreakInterfaceName("nsm0")).To(Equal("nsm0-mbb"))
+	g.Expect(local.MakeBeforeBreakInterfaceName("nsm0-mbb")).To(Equal("nsm0"))
+	g.Expect(local.MakeBeforeBreakInterfaceName("interface-12345")).To(Equal("interface-1-mbb"))
+}
+
 type discoveryClientStub struct {
 	response *registry.FindNetworkServiceResponse
 	error    error
@@ -299,6 +527,7 @@ func (stub *discoveryClientStub) FindNetworkService(ctx net_context.Context, in
 
 type serviceRegistryStub struct {
 	discoveryClient *discoveryClientStub
+	forwarderClient *forwarderClientStub
 	error           error
 
 	serviceregistry.ServiceRegistry
@@ -308,6 +537,21 @@ func (stub *serviceRegistryStub) DiscoveryClient(ctx context.Context) (registry.
 	return stub.discoveryClient, stub.error
 }
 
+func (stub *serviceRegistryStub) ForwarderConnection(ctx context.Context, forwarder *model.Forwarder) (forwarderapi.ForwarderClient, *grpc.ClientConn, error) {
+	return stub.forwarderClient, nil, stub.error
+}
+
+type forwarderClientStub struct {
+	closed []*crossconnect.CrossConnect
+
+	forwarderapi.ForwarderClient
+}
+
+func (stub *forwarderClientStub) Close(ctx net_context.Context, in *crossconnect.CrossConnect, opts ...grpc.CallOption) (*empty.Empty, error) {
+	stub.closed = append(stub.closed, in)
+	return &empty.Empty{}, nil
+}
+
 func (stub *serviceRegistryStub) WaitForForwarderAvailable(ctx context.Context, model model.Model, timeout time.Duration) error {
 	return nsmd.NewServiceRegistry().WaitForForwarderAvailable(ctx, model, timeout)
 }
@@ -315,10 +559,24 @@ func (stub *serviceRegistryStub) WaitForForwarderAvailable(ctx context.Context,
 type connectionManagerStub struct {
 	model model.Model
 
-	requestError error
-	nse          *registry.NSERegistration
+	requestError     error
+	nse              *registry.NSERegistration
+	ignoredEndpoints map[registry.EndpointNSMName]*registry.NSERegistration
 
 	closeError error
+
+	// forwarder is a forwarder requested connections are moved to, if set
+	forwarder string
+
+	requestsMutex sync.Mutex
+	requestedIDs  []string
+}
+
+// requestedConnections returns ids of requested connections
+func (stub *connectionManagerStub) requestedConnections() []string {
+	stub.requestsMutex.Lock()
+	defer stub.requestsMutex.Unlock()
+	return append([]string{}, stub.requestedIDs...)
 }
 
 func (stub *connectionManagerStub) LocalManager(cc nsm.ClientConnection) networkservice.NetworkServiceServer {
@@ -334,6 +592,10 @@ func (stub *connectionManagerStub) RemoteManager() networkservice.NetworkService
 }
 
 func (stub *connectionManagerStub) request(ctx context.Context, request *networkservice.NetworkServiceRequest, existingConnection *model.ClientConnection) (*connection.Connection, error) {
+	stub.requestsMutex.Lock()
+	stub.requestedIDs = append(stub.requestedIDs, request.GetConnection().GetId())
+	stub.requestsMutex.Unlock()
+
 	if stub.requestError != nil {
 		return nil, stub.requestError
 	}
@@ -344,8 +606,18 @@ func (stub *connectionManagerStub) request(ctx context.Context, request *network
 		endpointId = existingConnection.Endpoint.GetEndpointNSMName()
 	}
 
+	// Forwarder service programs the new path under another interface name.
+	endpointChanged := stub.nse != nil && existingConnection.Endpoint.GetEndpointNSMName() != stub.nse.GetEndpointNSMName()
+	forwarderChanged := stub.forwarder != "" && existingConnection.ForwarderRegisteredName != stub.forwarder
+	if common.MakeBeforeBreak(ctx) && (endpointChanged || forwarderChanged) {
+		parameters := existingConnection.Xcon.GetSource().GetMechanism().GetParameters()
+		parameters[mechanismCommon.InterfaceNameKey] = local.MakeBeforeBreakInterfaceName(parameters[mechanismCommon.InterfaceNameKey])
+		existingConnection.Xcon.Id = model.MakeBeforeBreakXconID(existingConnection.Xcon.GetId())
+	}
+
 	// Update Endpoint, if less what expected
 	ignoreEndpoints := common.IgnoredEndpoints(ctx)
+	stub.ignoredEndpoints = ignoreEndpoints
 
 	if ignoreEndpoints[endpointId] != nil {
 		if stub.nse != nil {
@@ -360,6 +632,9 @@ func (stub *connectionManagerStub) request(ctx context.Context, request *network
 		}
 	}
 
+	if stub.forwarder != "" {
+		existingConnection.ForwarderRegisteredName = stub.forwarder
+	}
 	existingConnection.ConnectionState = model.ClientConnectionReady
 	existingConnection.ForwarderState = model.ForwarderStateReady
 	stub.model.UpdateClientConnection(context.Background(), existingConnection)
@@ -385,12 +660,65 @@ func (stub *connectionManagerStub) Close(ctx context.Context, clientConnection n
 	return nil
 }
 
+// selectingManagerStub performs local requests through the connection and endpoint selector services, requests to
+// down endpoints fail
+type selectingManagerStub struct {
+	*connectionManagerStub
+
+	nseManager    nsm.NetworkServiceEndpointManager
+	downEndpoints map[string]bool
+	requested     []string
+}
+
+func (stub *selectingManagerStub) LocalManager(cc nsm.ClientConnection) networkservice.NetworkServiceServer {
+	return common.NewCompositeService("LocalHeal",
+		common.NewRequestValidator(),
+		local.NewConnectionService(stub.model),
+		&forwarderContextStub{model: stub.model},
+		local.NewEndpointSelectorService(stub.nseManager, stub.model),
+		stub,
+	)
+}
+
+func (stub *selectingManagerStub) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	name := common.Endpoint(ctx).GetNetworkServiceEndpoint().GetName()
+	stub.requested = append(stub.requested, name)
+	if stub.downEndpoints[name] {
+		return nil, errors.Errorf("endpoint %s is down", name)
+	}
+	return request.GetConnection(), nil
+}
+
+func (stub *selectingManagerStub) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+// forwarderContextStub passes the forwarder of model connection to the next services
+type forwarderContextStub struct {
+	model model.Model
+}
+
+func (stub *forwarderContextStub) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	ctx = common.WithForwarder(ctx, stub.model.GetForwarder(common.ModelConnection(ctx).ForwarderRegisteredName))
+	return common.ProcessNext(ctx, request)
+}
+
+func (stub *forwarderContextStub) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
+	return common.ProcessClose(ctx, connection)
+}
+
 type nseClientStub struct {
 	cleanedUp bool
+	closed    *connection.Connection
 
 	nsm.NetworkServiceClient
 }
 
+func (stub *nseClientStub) Close(ctx context.Context, connection *connection.Connection) error {
+	stub.closed = connection
+	return nil
+}
+
 func (stub *nseClientStub) Cleanup() error {
 	stub.cleanedUp = true
 	return nil
@@ -409,6 +737,10 @@ func (stub *nseManagerStub) GetEndpoint(ctx net_context.Context, requestConnecti
 	panic("implement me")
 }
 
+func (stub *nseManagerStub) ExplainEndpoint(ctx context.Context, requestConnection *connection.Connection, ignoreEndpoints []string) (*nsmdapi.ExplainEndpointSelectionReply, error) {
+	panic("implement me")
+}
+
 func (stub *nseManagerStub) CreateNSEClient(ctx context.Context, endpoint *registry.NSERegistration) (nsm.NetworkServiceClient, error) {
 	if stub.clientError != nil {
 		return nil, stub.clientError
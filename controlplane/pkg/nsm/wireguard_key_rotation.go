@@ -0,0 +1,186 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
+)
+
+// watchWireguardKeyRotation - forwarders rotate Wireguard keys every WireguardKeyRotationInterval and advertise the new
+// public key by mechanisms update, remote Wireguard connections of the forwarder are renegotiated with the new key
+func (srv *networkServiceManager) watchWireguardKeyRotation() {
+	listener := &forwarderUpdateListener{
+		updated: make(chan struct{}, 1),
+	}
+	srv.model.AddListener(listener)
+	defer srv.model.RemoveListener(listener)
+
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-listener.updated:
+		}
+		srv.renegotiateWireguardKeys(srv.ctx)
+	}
+}
+
+// renegotiateWireguardKeys - renegotiates keys of connections established with Wireguard key no longer advertised by
+// their forwarder, the previous key is kept by the forwarder until the connection is updated
+func (srv *networkServiceManager) renegotiateWireguardKeys(ctx context.Context) {
+	for _, cc := range srv.model.GetAllClientConnections() {
+		dp := srv.model.GetForwarder(cc.ForwarderRegisteredName)
+		if dp == nil || !staleWireguardKey(cc, dp) {
+			continue
+		}
+		srv.RenegotiateWireguardKeys(ctx, cc)
+	}
+}
+
+// RenegotiateWireguardKeys - renegotiates Wireguard keys of the connection in background. It is not a heal, so it
+// does not depend on healing being enabled and the connection is kept with the previous keys if renegotiation fails,
+// failed renegotiation is retried HealRetryCount times with HealRetryBackoff delays.
+func (p *healProcessor) RenegotiateWireguardKeys(ctx context.Context, clientConnection nsm.ClientConnection) {
+	id := clientConnection.GetID()
+	if _, renegotiating := p.renegotiations.LoadOrStore(id, true); renegotiating {
+		return
+	}
+	// We need to create new context, since renegotiation outlives the caller.
+	span := spanhelper.CopySpan(context.Background(), spanhelper.GetSpanHelper(ctx), "RenegotiateWireguardKeys")
+	span.LogValue("connection-id", id)
+	go func() {
+		defer span.Finish()
+		defer p.renegotiations.Delete(id)
+
+		props := p.props.Snapshot()
+		for attempt := 0; ; attempt++ {
+			err := p.renegotiateWireguardKeys(span.Context(), id, props)
+			if err == nil {
+				return
+			}
+			span.LogError(err)
+			if attempt+1 >= props.HealRetryCount {
+				span.Logger().Errorf("Failed to renegotiate Wireguard keys, connection %v is kept with the previous keys", id)
+				return
+			}
+			p.backoff(span.Context(), &props.HealRetryBackoff, attempt)
+		}
+	}()
+}
+
+// renegotiateWireguardKeys - updates ready connection with its own request, so the rotated Wireguard key is negotiated
+// with Remote NSM and the path is re-programmed in place. Connection of remote client is renegotiated by Remote NSM,
+// so it is notified about the rotated key.
+func (p *healProcessor) renegotiateWireguardKeys(ctx context.Context, id string, props *properties.Properties) error {
+	cc := p.model.GetClientConnection(id)
+	if cc == nil {
+		// Connection is closed, so there is nothing to renegotiate
+		return nil
+	}
+	if cc.ConnectionState != model.ClientConnectionReady {
+		return errors.Errorf("connection %v is not ready: %v", id, cc.ConnectionState)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, props.HealTimeout)
+	defer cancel()
+
+	if cc.GetConnectionSource().IsRemote() {
+		return p.notifyWireguardKeyRotation(ctx, cc)
+	}
+	if cc.Request == nil {
+		return errors.Errorf("connection %v has no request", id)
+	}
+
+	request := cc.Request.Clone()
+	request.SetRequestConnection(cc.GetConnectionSource())
+
+	if err := p.performRequest(common.WithModelConnection(ctx, cc), request, cc); err != nil {
+		// Forwarder keeps the previous keys, so the connection is ready to be renegotiated again
+		p.model.ApplyClientConnectionChanges(ctx, id, func(modelCC *model.ClientConnection) {
+			if modelCC.ConnectionState == model.ClientConnectionHealing {
+				modelCC.ConnectionState = model.ClientConnectionReady
+			}
+		})
+		return errors.Wrapf(err, "failed to renegotiate Wireguard keys of connection %v", id)
+	}
+	return nil
+}
+
+// notifyWireguardKeyRotation - sends the remote connection with the rotated Wireguard key of the local Forwarder to
+// Remote NSM, so it renegotiates the connection and the path is re-programmed in place
+func (p *healProcessor) notifyWireguardKeyRotation(ctx context.Context, cc *model.ClientConnection) error {
+	forwarder := p.model.GetForwarder(cc.ForwarderRegisteredName)
+	if cc.Monitor == nil || forwarder == nil {
+		return errors.Errorf("failed to notify Remote NSM about Wireguard key rotation of connection %v", cc.GetID())
+	}
+
+	logrus.Infof("Notifying Remote NSM about Wireguard key rotation... %v", cc.GetID())
+	// Model is updated once Remote NSM renegotiates the connection, so it is notified again until then
+	src := cc.GetConnectionSource().Clone()
+	src.GetMechanism().GetParameters()[wireguard.DstPublicKey] = advertisedWireguardKey(forwarder)
+	cc.Monitor.Update(ctx, src)
+	return nil
+}
+
+// staleWireguardKey - checks if cc is a remote Wireguard connection established with the key of its local side other
+// than the one advertised by dp. Connections initiated by this NSM are renegotiated with Remote NSM, connections of
+// remote clients are renegotiated by Remote NSM once it is notified about the rotated key.
+func staleWireguardKey(cc *model.ClientConnection, dp *model.Forwarder) bool {
+	publicKey := advertisedWireguardKey(dp)
+	if publicKey == "" {
+		return false
+	}
+	if src := cc.GetConnectionSource(); src.IsRemote() {
+		return src.GetMechanism().GetType() == wireguard.MECHANISM &&
+			publicKey != src.GetMechanism().GetParameters()[wireguard.DstPublicKey]
+	}
+	dst := cc.GetConnectionDestination()
+	return dst.IsRemote() && dst.GetMechanism().GetType() == wireguard.MECHANISM &&
+		publicKey != dst.GetMechanism().GetParameters()[wireguard.SrcPublicKey]
+}
+
+// advertisedWireguardKey - returns Wireguard public key advertised by dp, empty if dp does not support Wireguard
+func advertisedWireguardKey(dp *model.Forwarder) string {
+	for _, m := range dp.RemoteMechanisms {
+		if m.GetType() == wireguard.MECHANISM {
+			return m.GetParameters()[wireguard.SrcPublicKey]
+		}
+	}
+	return ""
+}
+
+// forwarderUpdateListener - notifies forwarders are updated, notifications are coalesced until received
+type forwarderUpdateListener struct {
+	model.ListenerImpl
+	updated chan struct{}
+}
+
+func (l *forwarderUpdateListener) ForwarderUpdated(_ context.Context, _ *model.Forwarder) {
+	select {
+	case l.updated <- struct{}{}:
+	default:
+	}
+}
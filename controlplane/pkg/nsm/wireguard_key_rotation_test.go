@@ -0,0 +1,162 @@
+package nsm
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/sdk/monitor"
+	"github.com/networkservicemesh/networkservicemesh/sdk/monitor/connectionmonitor"
+)
+
+// updateRecorder records connections sent to Remote NSM
+type updateRecorder struct {
+	connectionmonitor.MonitorServer
+	sync.Mutex
+	updated []*connection.Connection
+}
+
+func (r *updateRecorder) Update(_ context.Context, entity monitor.Entity) {
+	r.Lock()
+	defer r.Unlock()
+	r.updated = append(r.updated, entity.(*connection.Connection))
+}
+
+func (r *updateRecorder) connections() []*connection.Connection {
+	r.Lock()
+	defer r.Unlock()
+	return append([]*connection.Connection{}, r.updated...)
+}
+
+func (data *healTestData) addWireguardConnection(id string, remoteDst bool, publicKey string) {
+	nse := data.createEndpoint(nse1Name, remoteNSMName)
+	// Source of local connection has the id of the client connection
+	xcon := data.createCrossConnection(false, remoteDst, id, "dst-"+id)
+	xcon.Destination.Mechanism = &connection.Mechanism{
+		Type: wireguard.MECHANISM,
+		Parameters: map[string]string{
+			wireguard.SrcPublicKey: publicKey,
+		},
+	}
+	request := data.createRequest(false)
+	request.Connection.Id = id
+	cc := data.createClientConnection(id, xcon, nse, remoteNSMName, forwarder1Name, request)
+	cc.ConnectionState = model.ClientConnectionReady
+	data.model.AddClientConnection(context.Background(), cc)
+}
+
+// addRemoteWireguardConnection adds connection of remote client, returns the monitor of Remote NSM
+func (data *healTestData) addRemoteWireguardConnection(id, publicKey string) *updateRecorder {
+	nse := data.createEndpoint(nse1Name, "")
+	xcon := data.createCrossConnection(true, false, "src-"+id, "dst-"+id)
+	xcon.Source.Mechanism = &connection.Mechanism{
+		Type: wireguard.MECHANISM,
+		Parameters: map[string]string{
+			wireguard.SrcPublicKey: "remote-key",
+			wireguard.DstPublicKey: publicKey,
+		},
+	}
+	request := data.createRequest(true)
+	request.Connection.Id = id
+	cc := data.createClientConnection(id, xcon, nse, remoteNSMName, forwarder1Name, request)
+	cc.ConnectionState = model.ClientConnectionReady
+	recorder := &updateRecorder{}
+	cc.Monitor = recorder
+	data.model.AddClientConnection(context.Background(), cc)
+	return recorder
+}
+
+// rotateForwarderKey advertises publicKey as Wireguard key of forwarder-1
+func (data *healTestData) rotateForwarderKey(publicKey string) {
+	dp := data.model.GetForwarder(forwarder1Name)
+	dp.SetRemoteMechanisms([]*connection.Mechanism{
+		{
+			Type: wireguard.MECHANISM,
+			Parameters: map[string]string{
+				wireguard.SrcPublicKey: publicKey,
+			},
+		},
+	})
+	data.model.UpdateForwarder(context.Background(), dp)
+}
+
+func TestRenegotiateWireguardKeys(t *testing.T) {
+	g := NewWithT(t)
+	data, srv, stop := newDrainTestData()
+	defer stop()
+	// Renegotiation is not a heal, so it does not depend on healing being enabled
+	data.healProcessor.props.HealEnabled = false
+
+	data.rotateForwarderKey("rotated-key")
+	data.addWireguardConnection("stale", true, "previous-key")
+	data.addWireguardConnection("rotated", true, "rotated-key")
+	data.addWireguardConnection("local", false, "previous-key")
+	staleRemote := data.addRemoteWireguardConnection("stale-remote", "previous-key")
+	rotatedRemote := data.addRemoteWireguardConnection("rotated-remote", "rotated-key")
+
+	srv.renegotiateWireguardKeys(context.Background())
+
+	g.Eventually(data.connectionManager.requestedConnections, 5*time.Second).Should(Equal([]string{"stale"}))
+	g.Eventually(func() model.ClientConnectionState {
+		return data.model.GetClientConnection("stale").ConnectionState
+	}, 5*time.Second).Should(Equal(model.ClientConnectionReady))
+	g.Expect(data.healProcessor.history.Get("stale")).To(BeEmpty())
+
+	// Remote NSM is notified about the rotated key of remote client connection, the model is kept until it renegotiates
+	g.Eventually(func() int {
+		return len(staleRemote.connections())
+	}, 5*time.Second).ShouldNot(BeZero())
+	notified := staleRemote.connections()[0]
+	g.Expect(notified.GetId()).To(Equal("src-stale-remote"))
+	g.Expect(notified.GetMechanism().GetParameters()[wireguard.DstPublicKey]).To(Equal("rotated-key"))
+	g.Expect(notified.GetMechanism().GetParameters()[wireguard.SrcPublicKey]).To(Equal("remote-key"))
+	staleRemoteCC := data.model.GetClientConnection("stale-remote")
+	g.Expect(staleRemoteCC.GetConnectionSource().GetMechanism().GetParameters()[wireguard.DstPublicKey]).To(Equal("previous-key"))
+	g.Expect(staleRemoteCC.ConnectionState).To(Equal(model.ClientConnectionReady))
+	g.Expect(rotatedRemote.connections()).To(BeEmpty())
+
+	// Remote NSM is notified again until it renegotiates the connection
+	g.Eventually(func() bool {
+		_, renegotiating := data.healProcessor.renegotiations.Load("stale-remote")
+		return renegotiating
+	}, 5*time.Second).Should(BeFalse())
+	srv.renegotiateWireguardKeys(context.Background())
+	g.Eventually(func() int {
+		return len(staleRemote.connections())
+	}, 5*time.Second).Should(Equal(2))
+}
+
+func TestRenegotiateWireguardKeysFailed(t *testing.T) {
+	g := NewWithT(t)
+	data, srv, stop := newDrainTestData()
+	defer stop()
+	data.healProcessor.props.HealRetryCount = 2
+	data.connectionManager.requestError = errors.New("Remote NSM is not available")
+
+	data.rotateForwarderKey("rotated-key")
+	data.addWireguardConnection("stale", true, "previous-key")
+
+	srv.renegotiateWireguardKeys(context.Background())
+
+	// Connection is kept with the previous key after all attempts failed
+	g.Eventually(data.connectionManager.requestedConnections, 5*time.Second).Should(Equal([]string{"stale", "stale"}))
+	g.Eventually(func() bool {
+		_, renegotiating := data.healProcessor.renegotiations.Load("stale")
+		return renegotiating
+	}, 5*time.Second).Should(BeFalse())
+	cc := data.model.GetClientConnection("stale")
+	g.Expect(cc).NotTo(BeNil())
+	g.Expect(cc.ConnectionState).To(Equal(model.ClientConnectionReady))
+
+	// It is renegotiated again on the next forwarder update
+	data.connectionManager.requestError = nil
+	srv.renegotiateWireguardKeys(context.Background())
+	g.Eventually(data.connectionManager.requestedConnections, 5*time.Second).Should(HaveLen(3))
+}
@@ -16,19 +16,25 @@ package nsmd
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"net"
 	"path"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 	forwarderregistrarapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
@@ -41,12 +47,13 @@ const (
 	// ForwarderRegistrarSocket defines the name of NSM forwarder registrar socket
 	ForwarderRegistrarSocket = "nsm.forwarder-registrar.io.sock"
 	socketMask               = 0077
-	livenessInterval         = 5
+	livenessTokenLength      = 16
 )
 
 // ForwarderRegistrarServer - NSMgr registration service
 type ForwarderRegistrarServer struct {
 	model                        model.Model
+	manager                      nsm.NetworkServiceManager
 	grpcServer                   *grpc.Server
 	forwarderRegistrarSocketPath string
 	sock                         net.Listener
@@ -88,6 +95,11 @@ func forwarderMonitor(model model.Model, forwarderName string) {
 			return
 		}
 		logrus.Infof("Forwarder %s informed of its parameters changes, applying new parameters %+v", forwarderName, updates.RemoteMechanisms)
+		// Forwarder could be changed since the last update, e.g. marked as draining or unregistered
+		if forwarder = model.GetForwarder(forwarderName); forwarder == nil {
+			logrus.Infof("Forwarder %s is unregistered, stopping its monitoring", forwarderName)
+			return
+		}
 		// TODO: this is not good -- direct model changes
 		forwarder.SetRemoteMechanisms(updates.RemoteMechanisms)
 		forwarder.SetLocalMechanisms(updates.LocalMechanisms)
@@ -96,33 +108,142 @@ func forwarderMonitor(model model.Model, forwarderName string) {
 	}
 }
 
-// RequestLiveness is a stream initiated by NSM to inform the forwarder that NSM is still alive and
-// no re-registration is required. Detection a failure on this "channel" will mean
-// that NSM is gone and the forwarder needs to start re-registration logic.
+// RequestLiveness is a bidirectional heartbeat stream between NSM and the forwarder. NSM sends a heartbeat every
+// configured interval, so the forwarder knows NSM is still alive and no re-registration is required. If the forwarder
+// sends heartbeats and misses configured amount of them in a row, it is considered hung: the stream is closed and
+// the forwarder is removed from the object store, so its connections are healed. The stream is bound to the forwarder
+// by the liveness token issued on registration, forwarders sending heartbeats without it are rejected. Forwarders of
+// protocol version 1 do not send heartbeats, the failure of them is detected by errors on this "channel" only.
 func (r *ForwarderRegistrarServer) RequestLiveness(liveness forwarderregistrarapi.ForwarderRegistration_RequestLivenessServer) error {
-	logrus.Infof("Liveness Request received")
+	interval, missThreshold := r.heartbeatProperties()
+	logrus.Infof("Liveness Request received, heartbeat interval %v, miss threshold %v", interval, missThreshold)
+
+	token, forwarderName, err := r.livenessForwarder(liveness.Context())
+	if err != nil {
+		logrus.Errorf("Liveness Request is rejected: %v", err)
+		return err
+	}
+
+	heartbeats := make(chan *forwarderregistrarapi.Heartbeat)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			heartbeat, err := liveness.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case heartbeats <- heartbeat:
+			case <-liveness.Context().Done():
+				return
+			}
+		}
+	}()
+
+	tracker := forwarderregistrarapi.NewHeartbeatTracker(missThreshold)
+	received := false
+	sequence := uint64(1)
+	if err := liveness.Send(tracker.NewHeartbeat(forwarderName, sequence, interval)); err != nil {
+		logrus.Errorf("deteced error %s, grpc code: %+v on grpc channel", err.Error(), status.Convert(err).Code())
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
-		if err := liveness.SendMsg(&empty.Empty{}); err != nil {
-			logrus.Errorf("deteced error %s, grpc code: %+v on grpc channel", err.Error(), status.Convert(err).Code())
+		select {
+		case heartbeat := <-heartbeats:
+			if token == "" {
+				logrus.Errorf("Heartbeat of forwarder %q is received on the liveness stream without liveness token", heartbeat.GetForwarderName())
+				return status.Error(codes.Unauthenticated, "liveness token is required to send heartbeats")
+			}
+			if !received {
+				received = true
+				peerInterval, _ := ptypes.Duration(heartbeat.GetInterval())
+				logrus.Infof("Forwarder %s sends heartbeats every %v", forwarderName, peerInterval)
+			}
+			if lost := tracker.Receive(heartbeat, time.Now()); lost > 0 {
+				logrus.Warnf("Forwarder %s heartbeats lost: %v, last received sequence %v", forwarderName, lost, heartbeat.GetSequence())
+			}
+		case err := <-recvErr:
+			logrus.Errorf("deteced error %s, grpc code: %+v on grpc channel of forwarder %s", err.Error(), status.Convert(err).Code(), forwarderName)
 			return err
+		case <-ticker.C:
+			if tracker.Expired(time.Now()) {
+				logrus.Errorf("Forwarder %s missed %v heartbeats in a row, last received sequence %v, removing forwarder from Objectstore.",
+					forwarderName, missThreshold, tracker.LastSequence())
+				// The forwarder could re-register with the same name, its new registration is not removed
+				if forwarder := r.model.GetForwarder(forwarderName); forwarder != nil && forwarder.LivenessToken == token {
+					r.model.DeleteForwarder(context.Background(), forwarderName)
+				}
+				return status.Errorf(codes.DeadlineExceeded, "no heartbeats received from forwarder %s for %v", forwarderName, tracker.Deadline())
+			}
+			sequence++
+			if err := liveness.Send(tracker.NewHeartbeat(forwarderName, sequence, interval)); err != nil {
+				logrus.Errorf("deteced error %s, grpc code: %+v on grpc channel of forwarder %s", err.Error(), status.Convert(err).Code(), forwarderName)
+				return err
+			}
 		}
-		time.Sleep(time.Second * livenessInterval)
 	}
 }
 
+// livenessForwarder returns liveness token sent in the stream metadata and name of the forwarder it is issued to,
+// both are empty if the token is not sent. Returns error if the token is not issued to any registered forwarder.
+func (r *ForwarderRegistrarServer) livenessForwarder(ctx context.Context) (string, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(forwarderregistrarapi.LivenessTokenKey)) == 0 {
+		return "", "", nil
+	}
+	token := md.Get(forwarderregistrarapi.LivenessTokenKey)[0]
+	for _, forwarder := range r.model.GetAllForwarders() {
+		if token != "" && forwarder.LivenessToken == token {
+			return token, forwarder.RegisteredName, nil
+		}
+	}
+	return "", "", status.Error(codes.Unauthenticated, "liveness token is not issued to any registered forwarder")
+}
+
+// heartbeatProperties returns interval of heartbeats sent to forwarders and amount of forwarder heartbeats missed
+// in a row the forwarder is considered hung after
+func (r *ForwarderRegistrarServer) heartbeatProperties() (time.Duration, int) {
+	if r.manager == nil {
+		return forwarderregistrarapi.DefaultHeartbeatInterval, forwarderregistrarapi.DefaultHeartbeatMissThreshold
+	}
+	props := r.manager.GetHealProperties().Snapshot()
+	return props.ForwarderHeartbeatInterval, props.ForwarderHeartbeatMissThreshold
+}
+
 // RequestForwarderRegistration - request forwarder to be registered.
 func (r *ForwarderRegistrarServer) RequestForwarderRegistration(ctx context.Context, req *forwarderregistrarapi.ForwarderRegistrationRequest) (*forwarderregistrarapi.ForwarderRegistrationReply, error) {
-	logrus.Infof("Received new forwarder registration requests from %s", req.ForwarderName)
+	logrus.Infof("Received new forwarder registration requests from %s: implementation %q, version %q, protocol version %v",
+		req.ForwarderName, req.Implementation, req.Version, req.ProtocolVersion)
+	protocolVersion, err := checkProtocolVersion(req.ProtocolVersion)
+	if err != nil {
+		logrus.Errorf("forwarder %s registration is rejected: %v", req.ForwarderName, err)
+		return &forwarderregistrarapi.ForwarderRegistrationReply{Registered: false, ProtocolVersion: forwarderregistrarapi.ProtocolVersion}, err
+	}
 	// Need to check if name of forwarder already exists in the object store
 	if r.model.GetForwarder(req.ForwarderName) != nil {
 		logrus.Errorf("forwarder with name %s already exist", req.ForwarderName)
 		// TODO (sbezverk) Need to decide the right action, fail or not, failing for now
-		return &forwarderregistrarapi.ForwarderRegistrationReply{Registered: false}, errors.Errorf("forwarder with name %s already registered", req.ForwarderName)
+		return &forwarderregistrarapi.ForwarderRegistrationReply{Registered: false, ProtocolVersion: forwarderregistrarapi.ProtocolVersion}, errors.Errorf("forwarder with name %s already registered", req.ForwarderName)
+	}
+	livenessToken, err := newLivenessToken()
+	if err != nil {
+		logrus.Errorf("failed to issue liveness token to forwarder %s: %v", req.ForwarderName, err)
+		return &forwarderregistrarapi.ForwarderRegistrationReply{Registered: false, ProtocolVersion: forwarderregistrarapi.ProtocolVersion}, err
 	}
 	// Instantiating forwarder object with parameters from the request and creating a new object in the Object store
 	forwarder := &model.Forwarder{
-		RegisteredName: req.ForwarderName,
-		SocketLocation: req.ForwarderSocket,
+		RegisteredName:   req.ForwarderName,
+		SocketLocation:   req.ForwarderSocket,
+		Implementation:   req.Implementation,
+		Version:          req.Version,
+		ProtocolVersion:  protocolVersion,
+		Capabilities:     mechanismCapabilities(req.Mechanisms),
+		MaxCrossConnects: req.MaxCrossConnects,
+		Labels:           req.Labels,
+		LivenessToken:    livenessToken,
 	}
 
 	r.model.AddForwarder(ctx, forwarder)
@@ -132,17 +253,104 @@ func (r *ForwarderRegistrarServer) RequestForwarderRegistration(ctx context.Cont
 	// object.
 	go forwarderMonitor(r.model, req.ForwarderName)
 
-	return &forwarderregistrarapi.ForwarderRegistrationReply{Registered: true}, nil
+	reply := &forwarderregistrarapi.ForwarderRegistrationReply{
+		Registered:      true,
+		ProtocolVersion: forwarderregistrarapi.ProtocolVersion,
+		LivenessToken:   livenessToken,
+	}
+	if interval := r.wireguardKeyRotationInterval(); interval > 0 {
+		reply.WireguardKeyRotationInterval = ptypes.DurationProto(interval)
+	}
+	return reply, nil
+}
+
+// wireguardKeyRotationInterval returns interval forwarders rotate Wireguard keys at, 0 if the rotation is disabled.
+// The interval is applied on registration, so forwarders registered before the change keep the previous one.
+func (r *ForwarderRegistrarServer) wireguardKeyRotationInterval() time.Duration {
+	if r.manager == nil {
+		return 0
+	}
+	return r.manager.GetHealProperties().Snapshot().WireguardKeyRotationInterval
+}
+
+// newLivenessToken returns random token binding the liveness stream to the forwarder registration
+func newLivenessToken() (string, error) {
+	b := make([]byte, livenessTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate liveness token")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// checkProtocolVersion returns protocol version of forwarder registration, error if NSM does not support it
+func checkProtocolVersion(protocolVersion uint32) (uint32, error) {
+	if protocolVersion == 0 {
+		// Forwarders not sending protocol version are of the first one
+		protocolVersion = 1
+	}
+	if protocolVersion < forwarderregistrarapi.MinProtocolVersion || protocolVersion > forwarderregistrarapi.ProtocolVersion {
+		return protocolVersion, status.Errorf(codes.FailedPrecondition, "forwarder protocol version %v is not supported, supported versions are %v-%v",
+			protocolVersion, forwarderregistrarapi.MinProtocolVersion, forwarderregistrarapi.ProtocolVersion)
+	}
+	return protocolVersion, nil
+}
+
+// mechanismCapabilities converts mechanism capabilities advertised by forwarder to the model ones
+func mechanismCapabilities(mechanisms []*forwarderregistrarapi.MechanismCapability) []*model.MechanismCapability {
+	var rv []*model.MechanismCapability
+	for _, m := range mechanisms {
+		capability := &model.MechanismCapability{
+			Type:   m.GetType(),
+			Remote: m.GetRemote(),
+		}
+		for _, p := range m.GetParameters() {
+			capability.Parameters = append(capability.Parameters, &model.MechanismParameter{
+				Name:     p.GetName(),
+				Type:     p.GetType(),
+				Required: p.GetRequired(),
+			})
+		}
+		rv = append(rv, capability)
+	}
+	return rv
 }
 
 // RequestForwarderUnRegistration - request forwarder to be unregistered
 func (r *ForwarderRegistrarServer) RequestForwarderUnRegistration(ctx context.Context, req *forwarderregistrarapi.ForwarderUnRegistrationRequest) (*forwarderregistrarapi.ForwarderUnRegistrationReply, error) {
-	logrus.Infof("Received forwarder un-registration requests from %s", req.ForwarderName)
+	logrus.Infof("Received forwarder un-registration requests from %s, drain: %v", req.ForwarderName, req.Drain)
+
+	// Migrating connections to other forwarders, the forwarder waits for the reply to exit cleanly.
+	// Drain is not supported without network service manager.
+	drained := false
+	if req.Drain && r.manager != nil && r.model.GetForwarder(req.ForwarderName) != nil {
+		drainCtx, drainCancel := r.drainContext(ctx)
+		defer drainCancel()
+		if err := r.manager.DrainForwarder(drainCtx, req.ForwarderName); err != nil {
+			logrus.Errorf("Failed to drain forwarder %s, remaining connections will be healed: %v", req.ForwarderName, err)
+		} else {
+			drained = true
+		}
+	}
 
 	// Removing forwarder from the store, if it does not exists, it does not matter as long as it is no longer there.
 	r.model.DeleteForwarder(ctx, req.ForwarderName)
 
-	return &forwarderregistrarapi.ForwarderUnRegistrationReply{UnRegistered: true}, nil
+	return &forwarderregistrarapi.ForwarderUnRegistrationReply{UnRegistered: true, Drained: drained}, nil
+}
+
+// drainContext - returns context of forwarder drain, it is not cancelled with the un-registration request, so connections
+// are not left half migrated if the forwarder stops waiting, but it is limited by the request deadline
+func (r *ForwarderRegistrarServer) drainContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := r.manager.GetHealProperties().Snapshot().HealTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	span := spanhelper.CopySpan(context.Background(), spanhelper.GetSpanHelper(ctx), "DrainForwarder")
+	drainCtx, cancel := context.WithTimeout(span.Context(), timeout)
+	return drainCtx, func() {
+		cancel()
+		span.Finish()
+	}
 }
 
 // startForwarderServer starts for a server listening for local NSEs advertise/remove
@@ -197,8 +405,8 @@ func (r *ForwarderRegistrarServer) Stop() {
 }
 
 // StartForwarderRegistrarServer -  registers and starts gRPC server which is listening for
-// Network Service Forwarder Registrar requests.
-func StartForwarderRegistrarServer(ctx context.Context, model model.Model) (*ForwarderRegistrarServer, error) {
+// Network Service Forwarder Registrar requests, manager drains forwarders on their un-registration.
+func StartForwarderRegistrarServer(ctx context.Context, model model.Model, manager nsm.NetworkServiceManager) (*ForwarderRegistrarServer, error) {
 	span := spanhelper.FromContext(ctx, "ForwarderRegistrarServer")
 	defer span.Finish()
 	server := tools.NewServer(span.Context())
@@ -207,6 +415,7 @@ func StartForwarderRegistrarServer(ctx context.Context, model model.Model) (*For
 		grpcServer:                   server,
 		forwarderRegistrarSocketPath: path.Join(ForwarderRegistrarSocketBaseDir, ForwarderRegistrarSocket),
 		model:                        model,
+		manager:                      manager,
 	}
 
 	var err error
@@ -0,0 +1,347 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+	forwarderregistrarapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
+)
+
+func TestCheckProtocolVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	version, err := checkProtocolVersion(0)
+	g.Expect(err).To(BeNil())
+	g.Expect(version).To(Equal(uint32(1)))
+
+	version, err = checkProtocolVersion(forwarderregistrarapi.ProtocolVersion)
+	g.Expect(err).To(BeNil())
+	g.Expect(version).To(Equal(forwarderregistrarapi.ProtocolVersion))
+
+	_, err = checkProtocolVersion(forwarderregistrarapi.ProtocolVersion + 1)
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+}
+
+func TestForwarderRegistrationIncompatibleVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	server := &ForwarderRegistrarServer{model: mdl}
+	reply, err := server.RequestForwarderRegistration(context.Background(), &forwarderregistrarapi.ForwarderRegistrationRequest{
+		ForwarderName:   "forwarder",
+		ForwarderSocket: "forwarder.sock",
+		ProtocolVersion: forwarderregistrarapi.ProtocolVersion + 1,
+	})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	g.Expect(reply.Registered).To(BeFalse())
+	g.Expect(reply.ProtocolVersion).To(Equal(forwarderregistrarapi.ProtocolVersion))
+	g.Expect(mdl.GetForwarder("forwarder")).To(BeNil())
+}
+
+func TestForwarderUnRegistrationDrainWithoutManager(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder"})
+	server := &ForwarderRegistrarServer{model: mdl}
+	reply, err := server.RequestForwarderUnRegistration(context.Background(), &forwarderregistrarapi.ForwarderUnRegistrationRequest{
+		ForwarderName: "forwarder",
+		Drain:         true,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(reply.UnRegistered).To(BeTrue())
+	g.Expect(reply.Drained).To(BeFalse())
+	g.Expect(mdl.GetForwarder("forwarder")).To(BeNil())
+}
+
+func TestMechanismCapabilities(t *testing.T) {
+	g := NewWithT(t)
+
+	capabilities := mechanismCapabilities([]*forwarderregistrarapi.MechanismCapability{
+		{
+			Type:   "VXLAN",
+			Remote: true,
+			Parameters: []*forwarderregistrarapi.MechanismParameter{
+				{Name: "src_ip", Type: "ip", Required: true},
+			},
+		},
+	})
+	g.Expect(capabilities).To(Equal([]*model.MechanismCapability{
+		{
+			Type:   "VXLAN",
+			Remote: true,
+			Parameters: []*model.MechanismParameter{
+				{Name: "src_ip", Type: "ip", Required: true},
+			},
+		},
+	}))
+}
+
+// heartbeatManagerStub provides properties of heartbeats, other methods of the manager are not expected to be called
+type heartbeatManagerStub struct {
+	nsm.NetworkServiceManager
+	props *properties.Properties
+}
+
+func (m *heartbeatManagerStub) GetHealProperties() *properties.Properties {
+	return m.props
+}
+
+// livenessStreamStub is a server side of liveness stream, forwarder heartbeats are received from recv
+type livenessStreamStub struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv chan *forwarderregistrarapi.Heartbeat
+	sent chan *forwarderregistrarapi.Heartbeat
+}
+
+func newLivenessStreamStub(ctx context.Context) *livenessStreamStub {
+	return &livenessStreamStub{
+		ctx:  ctx,
+		recv: make(chan *forwarderregistrarapi.Heartbeat),
+		sent: make(chan *forwarderregistrarapi.Heartbeat, 100),
+	}
+}
+
+func (s *livenessStreamStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *livenessStreamStub) Send(heartbeat *forwarderregistrarapi.Heartbeat) error {
+	s.sent <- heartbeat
+	return nil
+}
+
+func (s *livenessStreamStub) Recv() (*forwarderregistrarapi.Heartbeat, error) {
+	select {
+	case heartbeat := <-s.recv:
+		return heartbeat, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+// livenessContext returns context of the liveness stream the forwarder sends token in
+func livenessContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwarderregistrarapi.LivenessTokenKey, token))
+}
+
+func newHeartbeatTestServer(mdl model.Model) *ForwarderRegistrarServer {
+	props := properties.NewNsmProperties()
+	props.ForwarderHeartbeatInterval = 50 * time.Millisecond
+	props.ForwarderHeartbeatMissThreshold = 2
+	return &ForwarderRegistrarServer{
+		model:   mdl,
+		manager: &heartbeatManagerStub{props: props},
+	}
+}
+
+func TestRequestLivenessHungForwarder(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder", LivenessToken: "token"})
+	server := newHeartbeatTestServer(mdl)
+
+	ctx, cancel := context.WithCancel(livenessContext("token"))
+	defer cancel()
+	stream := newLivenessStreamStub(ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.RequestLiveness(stream)
+	}()
+
+	// The forwarder sends a single heartbeat and hangs
+	stream.recv <- &forwarderregistrarapi.Heartbeat{
+		ForwarderName: "forwarder",
+		Sequence:      1,
+		Interval:      ptypes.DurationProto(50 * time.Millisecond),
+	}
+
+	var err error
+	g.Eventually(result, time.Second).Should(Receive(&err))
+	g.Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+	g.Expect(mdl.GetForwarder("forwarder")).To(BeNil())
+}
+
+func TestRequestLivenessHeartbeats(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder", LivenessToken: "token"})
+	server := newHeartbeatTestServer(mdl)
+
+	ctx, cancel := context.WithCancel(livenessContext("token"))
+	stream := newLivenessStreamStub(ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.RequestLiveness(stream)
+	}()
+
+	sending := make(chan struct{})
+	go func() {
+		defer close(sending)
+		for sequence := uint64(1); ctx.Err() == nil; sequence++ {
+			select {
+			case stream.recv <- &forwarderregistrarapi.Heartbeat{
+				ForwarderName: "forwarder",
+				Sequence:      sequence,
+				Interval:      ptypes.DurationProto(50 * time.Millisecond),
+			}:
+			case <-ctx.Done():
+			}
+			<-time.After(40 * time.Millisecond)
+		}
+	}()
+	g.Consistently(result, 300*time.Millisecond).ShouldNot(Receive())
+
+	var heartbeat *forwarderregistrarapi.Heartbeat
+	g.Expect(stream.sent).To(Receive(&heartbeat))
+	g.Expect(heartbeat.Sequence).To(Equal(uint64(1)))
+	for len(stream.sent) > 0 {
+		heartbeat = <-stream.sent
+	}
+	g.Expect(heartbeat.Sequence).To(BeNumerically(">", 1))
+	g.Expect(heartbeat.Ack).To(BeNumerically(">", 1))
+	g.Expect(heartbeat.ForwarderName).To(Equal("forwarder"))
+
+	// Stream failure is detected immediately, the forwarder is removed by its monitor
+	cancel()
+	<-sending
+	g.Eventually(result).Should(Receive())
+	g.Expect(mdl.GetForwarder("forwarder")).NotTo(BeNil())
+}
+
+func TestForwarderRegistrationWireguardKeyRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	server := newHeartbeatTestServer(mdl)
+	server.manager.GetHealProperties().WireguardKeyRotationInterval = time.Hour
+	reply, err := server.RequestForwarderRegistration(context.Background(), &forwarderregistrarapi.ForwarderRegistrationRequest{
+		ForwarderName:   "forwarder",
+		ForwarderSocket: "forwarder.sock",
+		ProtocolVersion: forwarderregistrarapi.ProtocolVersion,
+	})
+	g.Expect(err).To(BeNil())
+	interval, err := ptypes.Duration(reply.GetWireguardKeyRotationInterval())
+	g.Expect(err).To(BeNil())
+	g.Expect(interval).To(Equal(time.Hour))
+
+	// Rotation is disabled
+	server.manager.GetHealProperties().WireguardKeyRotationInterval = 0
+	reply, err = server.RequestForwarderRegistration(context.Background(), &forwarderregistrarapi.ForwarderRegistrationRequest{
+		ForwarderName:   "forwarder-2",
+		ForwarderSocket: "forwarder-2.sock",
+		ProtocolVersion: forwarderregistrarapi.ProtocolVersion,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(reply.GetWireguardKeyRotationInterval()).To(BeNil())
+}
+
+func TestRequestLivenessUnboundStream(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder", LivenessToken: "token"})
+	server := newHeartbeatTestServer(mdl)
+
+	// Token not issued to any forwarder is rejected
+	err := server.RequestLiveness(newLivenessStreamStub(livenessContext("unknown")))
+	g.Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+
+	// Heartbeats are not accepted on the stream without token, whatever forwarder name they carry
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newLivenessStreamStub(ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.RequestLiveness(stream)
+	}()
+	stream.recv <- &forwarderregistrarapi.Heartbeat{
+		ForwarderName: "forwarder",
+		Sequence:      1,
+		Interval:      ptypes.DurationProto(50 * time.Millisecond),
+	}
+	g.Eventually(result, time.Second).Should(Receive(&err))
+	g.Expect(status.Code(err)).To(Equal(codes.Unauthenticated))
+	g.Consistently(func() *model.Forwarder { return mdl.GetForwarder("forwarder") }, 200*time.Millisecond).ShouldNot(BeNil())
+}
+
+func TestRequestLivenessReRegisteredForwarder(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder", LivenessToken: "token"})
+	server := newHeartbeatTestServer(mdl)
+
+	ctx, cancel := context.WithCancel(livenessContext("token"))
+	defer cancel()
+	stream := newLivenessStreamStub(ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.RequestLiveness(stream)
+	}()
+	stream.recv <- &forwarderregistrarapi.Heartbeat{
+		ForwarderName: "forwarder",
+		Sequence:      1,
+		Interval:      ptypes.DurationProto(50 * time.Millisecond),
+	}
+
+	// The forwarder re-registers before its previous stream expires
+	mdl.DeleteForwarder(context.Background(), "forwarder")
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder", LivenessToken: "new-token"})
+
+	var err error
+	g.Eventually(result, time.Second).Should(Receive(&err))
+	g.Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+	g.Expect(mdl.GetForwarder("forwarder")).NotTo(BeNil())
+}
+
+func TestForwarderRegistrationLivenessToken(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	server := &ForwarderRegistrarServer{model: mdl}
+	reply, err := server.RequestForwarderRegistration(context.Background(), &forwarderregistrarapi.ForwarderRegistrationRequest{
+		ForwarderName:   "forwarder",
+		ForwarderSocket: "forwarder.sock",
+		ProtocolVersion: forwarderregistrarapi.ProtocolVersion,
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(reply.LivenessToken).NotTo(BeEmpty())
+	g.Expect(mdl.GetForwarder("forwarder").LivenessToken).To(Equal(reply.LivenessToken))
+
+	token, name, err := server.livenessForwarder(livenessContext(reply.LivenessToken))
+	g.Expect(err).To(BeNil())
+	g.Expect(token).To(Equal(reply.LivenessToken))
+	g.Expect(name).To(Equal("forwarder"))
+}
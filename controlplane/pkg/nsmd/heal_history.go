@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+)
+
+type healHistoryServer struct {
+	history nsm.HealHistory
+}
+
+// NewHealHistoryServer - creates a server exposing heal events of client connections
+func NewHealHistoryServer(history nsm.HealHistory) nsmdapi.HealHistoryServer {
+	return &healHistoryServer{history: history}
+}
+
+func (s *healHistoryServer) GetHealHistory(ctx context.Context, request *nsmdapi.HealHistoryRequest) (*nsmdapi.HealHistoryReply, error) {
+	if request.GetConnectionId() == "" {
+		return nil, errors.New("connection id is required")
+	}
+	return &nsmdapi.HealHistoryReply{
+		Events: s.history.Get(request.GetConnectionId()),
+	}, nil
+}
+
+func (s *healHistoryServer) MonitorHealEvents(request *nsmdapi.MonitorHealEventsRequest, stream nsmdapi.HealHistory_MonitorHealEventsServer) error {
+	events, cancel := s.history.Subscribe(request.GetConnectionId())
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
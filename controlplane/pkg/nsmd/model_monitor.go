@@ -0,0 +1,254 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// modelMonitorBufferSize - amount of model events queued for a slow monitor before its stream is closed
+const modelMonitorBufferSize = 1000
+
+type modelMonitorServer struct {
+	model model.Model
+}
+
+// NewModelMonitorServer - creates a server streaming changes of endpoints, forwarders and client connections of the model
+func NewModelMonitorServer(model model.Model) nsmdapi.ModelMonitorServer {
+	return &modelMonitorServer{model: model}
+}
+
+// MonitorModel - sends the current model state and then its changes in the order they happen. Objects existing when
+// the monitor is added are sent again as ADD events after the initial state, so ADD events should be treated as upserts.
+func (s *modelMonitorServer) MonitorModel(request *nsmdapi.MonitorModelRequest, stream nsmdapi.ModelMonitor_MonitorModelServer) error {
+	listener := newModelEventListener()
+	// Listener is added before the state is taken, so no change made in between is lost
+	s.model.AddListener(listener)
+	defer s.model.RemoveListener(listener)
+
+	if err := stream.Send(s.initialState()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-listener.overflow:
+			logrus.Warnf("Model monitor is too slow, closing its stream")
+			return status.Error(codes.ResourceExhausted, "model monitor is too slow, events are lost")
+		case event := <-listener.events:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *modelMonitorServer) initialState() *nsmdapi.ModelEvent {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_INITIAL_STATE_TRANSFER)
+	for _, endpoint := range s.model.GetAllEndpoints() {
+		event.Endpoints = append(event.Endpoints, endpointProto(endpoint))
+	}
+	for _, forwarder := range s.model.GetAllForwarders() {
+		event.Forwarders = append(event.Forwarders, forwarderProto(forwarder))
+	}
+	for _, cc := range s.model.GetAllClientConnections() {
+		event.ClientConnections = append(event.ClientConnections, clientConnectionProto(cc))
+	}
+	for _, circuit := range s.model.GetEndpointCircuits() {
+		event.EndpointCircuits = append(event.EndpointCircuits, endpointCircuitProto(circuit))
+	}
+	return event
+}
+
+// modelEventListener - converts model changes into events, events are never dropped: once the buffer is full
+// overflow is closed and the monitor has to start over with the initial state
+type modelEventListener struct {
+	events   chan *nsmdapi.ModelEvent
+	overflow chan struct{}
+	closed   int32
+}
+
+func newModelEventListener() *modelEventListener {
+	return &modelEventListener{
+		events:   make(chan *nsmdapi.ModelEvent, modelMonitorBufferSize),
+		overflow: make(chan struct{}),
+	}
+}
+
+func (l *modelEventListener) send(event *nsmdapi.ModelEvent) {
+	select {
+	case l.events <- event:
+	default:
+		if atomic.CompareAndSwapInt32(&l.closed, 0, 1) {
+			close(l.overflow)
+		}
+	}
+}
+
+func (l *modelEventListener) EndpointAdded(_ context.Context, endpoint *model.Endpoint) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_ADD)
+	event.Endpoints = []*nsmdapi.ModelEndpoint{endpointProto(endpoint)}
+	l.send(event)
+}
+
+func (l *modelEventListener) EndpointUpdated(_ context.Context, endpoint *model.Endpoint) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_UPDATE)
+	event.Endpoints = []*nsmdapi.ModelEndpoint{endpointProto(endpoint)}
+	l.send(event)
+}
+
+func (l *modelEventListener) EndpointDeleted(_ context.Context, endpoint *model.Endpoint) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_DELETE)
+	event.Endpoints = []*nsmdapi.ModelEndpoint{endpointProto(endpoint)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ForwarderAdded(_ context.Context, forwarder *model.Forwarder) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_ADD)
+	event.Forwarders = []*nsmdapi.ModelForwarder{forwarderProto(forwarder)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ForwarderUpdated(_ context.Context, forwarder *model.Forwarder) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_UPDATE)
+	event.Forwarders = []*nsmdapi.ModelForwarder{forwarderProto(forwarder)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ForwarderDeleted(_ context.Context, forwarder *model.Forwarder) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_DELETE)
+	event.Forwarders = []*nsmdapi.ModelForwarder{forwarderProto(forwarder)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ClientConnectionAdded(_ context.Context, cc *model.ClientConnection) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_ADD)
+	event.ClientConnections = []*nsmdapi.ModelClientConnection{clientConnectionProto(cc)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ClientConnectionUpdated(_ context.Context, _, cc *model.ClientConnection) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_UPDATE)
+	event.ClientConnections = []*nsmdapi.ModelClientConnection{clientConnectionProto(cc)}
+	l.send(event)
+}
+
+func (l *modelEventListener) ClientConnectionDeleted(_ context.Context, cc *model.ClientConnection) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_DELETE)
+	event.ClientConnections = []*nsmdapi.ModelClientConnection{clientConnectionProto(cc)}
+	l.send(event)
+}
+
+func (l *modelEventListener) EndpointCircuitUpdated(_ context.Context, circuit *model.EndpointCircuit) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_UPDATE)
+	event.EndpointCircuits = []*nsmdapi.ModelEndpointCircuit{endpointCircuitProto(circuit)}
+	l.send(event)
+}
+
+func (l *modelEventListener) EndpointCircuitDeleted(_ context.Context, circuit *model.EndpointCircuit) {
+	event := newModelEvent(nsmdapi.ModelEventType_MODEL_DELETE)
+	event.EndpointCircuits = []*nsmdapi.ModelEndpointCircuit{endpointCircuitProto(circuit)}
+	l.send(event)
+}
+
+func newModelEvent(eventType nsmdapi.ModelEventType) *nsmdapi.ModelEvent {
+	return &nsmdapi.ModelEvent{
+		Type:      eventType,
+		Timestamp: ptypes.TimestampNow(),
+	}
+}
+
+func endpointProto(endpoint *model.Endpoint) *nsmdapi.ModelEndpoint {
+	return &nsmdapi.ModelEndpoint{
+		Endpoint:       endpoint.Endpoint,
+		SocketLocation: endpoint.SocketLocation,
+		Workspace:      endpoint.Workspace,
+	}
+}
+
+func forwarderProto(forwarder *model.Forwarder) *nsmdapi.ModelForwarder {
+	return &nsmdapi.ModelForwarder{
+		RegisteredName:       forwarder.RegisteredName,
+		SocketLocation:       forwarder.SocketLocation,
+		LocalMechanisms:      forwarder.LocalMechanisms,
+		RemoteMechanisms:     forwarder.RemoteMechanisms,
+		MechanismsConfigured: forwarder.MechanismsConfigured,
+		Draining:             forwarder.Draining,
+		Implementation:       forwarder.Implementation,
+		Version:              forwarder.Version,
+		ProtocolVersion:      forwarder.ProtocolVersion,
+		Capabilities:         capabilitiesProto(forwarder.Capabilities),
+		MaxCrossConnects:     forwarder.MaxCrossConnects,
+		Labels:               forwarder.Labels,
+	}
+}
+
+func capabilitiesProto(capabilities []*model.MechanismCapability) []*nsmdapi.ModelMechanismCapability {
+	var rv []*nsmdapi.ModelMechanismCapability
+	for _, c := range capabilities {
+		capability := &nsmdapi.ModelMechanismCapability{
+			Type:   c.Type,
+			Remote: c.Remote,
+		}
+		for _, p := range c.Parameters {
+			capability.Parameters = append(capability.Parameters, &nsmdapi.ModelMechanismParameter{
+				Name:     p.Name,
+				Type:     p.Type,
+				Required: p.Required,
+			})
+		}
+		rv = append(rv, capability)
+	}
+	return rv
+}
+
+func clientConnectionProto(cc *model.ClientConnection) *nsmdapi.ModelClientConnection {
+	return &nsmdapi.ModelClientConnection{
+		ConnectionId:            cc.ConnectionID,
+		Request:                 cc.Request,
+		Xcon:                    cc.Xcon,
+		RemoteNsm:               cc.RemoteNsm,
+		Endpoint:                cc.Endpoint,
+		ForwarderRegisteredName: cc.ForwarderRegisteredName,
+		ConnectionState:         nsmdapi.ClientConnectionState(cc.ConnectionState),
+		ForwarderState:          nsmdapi.ForwarderState(cc.ForwarderState),
+	}
+}
+
+func endpointCircuitProto(circuit *model.EndpointCircuit) *nsmdapi.ModelEndpointCircuit {
+	since, err := ptypes.TimestampProto(circuit.Since)
+	if err != nil {
+		logrus.Errorf("Failed to convert endpoint %v circuit time: %v", circuit.EndpointName, err)
+	}
+	return &nsmdapi.ModelEndpointCircuit{
+		EndpointName: string(circuit.EndpointName),
+		State:        circuit.State.String(),
+		Failures:     int32(circuit.Failures),
+		Since:        since,
+	}
+}
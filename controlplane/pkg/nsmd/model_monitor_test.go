@@ -0,0 +1,123 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+func TestModelMonitor_InitialStateAndChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m := model.NewModel()
+	m.AddForwarder(ctx, &model.Forwarder{RegisteredName: "forwarder"})
+	m.AddEndpoint(ctx, &model.Endpoint{
+		Endpoint: &registry.NSERegistration{
+			NetworkService:         &registry.NetworkService{Name: "golden-network"},
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "endpoint"},
+		},
+		Workspace: "nsm-1",
+	})
+
+	grpcServer := tools.NewServerInsecure()
+	nsmdapi.RegisterModelMonitorServer(grpcServer, NewModelMonitorServer(m))
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).To(BeNil())
+	go func() {
+		_ = grpcServer.Serve(l)
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := tools.DialTCPInsecure(l.Addr().String())
+	g.Expect(err).To(BeNil())
+	defer func() { _ = conn.Close() }()
+
+	stream, err := nsmdapi.NewModelMonitorClient(conn).MonitorModel(ctx, &nsmdapi.MonitorModelRequest{})
+	g.Expect(err).To(BeNil())
+
+	initial, err := stream.Recv()
+	g.Expect(err).To(BeNil())
+	g.Expect(initial.GetType()).To(Equal(nsmdapi.ModelEventType_MODEL_INITIAL_STATE_TRANSFER))
+	g.Expect(initial.GetForwarders()).To(HaveLen(1))
+	g.Expect(initial.GetForwarders()[0].GetRegisteredName()).To(Equal("forwarder"))
+	g.Expect(initial.GetEndpoints()).To(HaveLen(1))
+	g.Expect(initial.GetEndpoints()[0].GetWorkspace()).To(Equal("nsm-1"))
+	g.Expect(initial.GetClientConnections()).To(BeEmpty())
+
+	m.AddClientConnection(ctx, &model.ClientConnection{
+		ConnectionID:            "1",
+		ForwarderRegisteredName: "forwarder",
+		ConnectionState:         model.ClientConnectionHealing,
+		ForwarderState:          model.ForwarderStateReady,
+	})
+	event := receiveModelEvent(g, stream, func(event *nsmdapi.ModelEvent) bool {
+		return len(event.GetClientConnections()) > 0
+	})
+	g.Expect(event.GetType()).To(Equal(nsmdapi.ModelEventType_MODEL_ADD))
+	g.Expect(event.GetClientConnections()[0].GetConnectionId()).To(Equal("1"))
+	g.Expect(event.GetClientConnections()[0].GetConnectionState()).To(Equal(nsmdapi.ClientConnectionState_CLIENT_CONNECTION_HEALING))
+	g.Expect(event.GetClientConnections()[0].GetForwarderState()).To(Equal(nsmdapi.ForwarderState_FORWARDER_STATE_READY))
+
+	m.DeleteForwarder(ctx, "forwarder")
+	event = receiveModelEvent(g, stream, func(event *nsmdapi.ModelEvent) bool {
+		return event.GetType() == nsmdapi.ModelEventType_MODEL_DELETE
+	})
+	g.Expect(event.GetForwarders()).To(HaveLen(1))
+	g.Expect(event.GetForwarders()[0].GetRegisteredName()).To(Equal("forwarder"))
+
+	cancel()
+	g.Eventually(m.ListenerCount).Should(BeZero())
+}
+
+func TestModelMonitor_SlowMonitorOverflow(t *testing.T) {
+	g := NewWithT(t)
+
+	listener := newModelEventListener()
+	for i := 0; i < modelMonitorBufferSize; i++ {
+		listener.ForwarderAdded(context.Background(), &model.Forwarder{RegisteredName: "forwarder"})
+	}
+	g.Expect(listener.overflow).NotTo(BeClosed())
+
+	listener.ForwarderAdded(context.Background(), &model.Forwarder{RegisteredName: "forwarder"})
+	listener.ForwarderAdded(context.Background(), &model.Forwarder{RegisteredName: "forwarder"})
+	g.Expect(listener.overflow).To(BeClosed())
+	g.Expect(listener.events).To(HaveLen(modelMonitorBufferSize))
+}
+
+// receiveModelEvent skips events replayed to the new model listener until the expected one is received
+func receiveModelEvent(g *WithT, stream nsmdapi.ModelMonitor_MonitorModelClient, expected func(*nsmdapi.ModelEvent) bool) *nsmdapi.ModelEvent {
+	for {
+		event, err := stream.Recv()
+		g.Expect(err).To(BeNil())
+		if expected(event) {
+			return event
+		}
+	}
+}
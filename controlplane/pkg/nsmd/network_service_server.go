@@ -17,7 +17,7 @@ func NewNetworkServiceServer(model model.Model, ws *Workspace,
 		local.NewWorkspaceService(ws.Name()),
 		local.NewConnectionService(model),
 		local.NewForwarderService(model, nsmManager.ServiceRegistry()),
-		local.NewEndpointSelectorService(nsmManager.NseManager()),
+		local.NewEndpointSelectorService(nsmManager.NseManager(), model),
 		common.NewExcludedPrefixesService(),
 		local.NewEndpointService(nsmManager.NseManager(), nsmManager.GetHealProperties(), nsmManager.Model()),
 		common.NewCrossConnectService(),
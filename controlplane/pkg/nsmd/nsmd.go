@@ -29,6 +29,7 @@ import (
 	unified "github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connectionstore"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nseregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
@@ -65,6 +66,7 @@ type nsmServer struct {
 	manager          nsm.NetworkServiceManager
 	locationProvider serviceregistry.WorkspaceLocationProvider
 	localRegistry    *nseregistry.NSERegistry
+	connectionStore  *connectionstore.Store
 	registerServer   *grpc.Server
 	registerSock     net.Listener
 	regServer        *ForwarderRegistrarServer
@@ -205,6 +207,19 @@ func (nsm *nsmServer) EnumConnection(context context.Context, request *nsmdapi.E
 	return &nsmdapi.EnumConnectionReply{Workspace: workspaces}, nil
 }
 
+// ExplainEndpointSelection describes how an endpoint would be selected for the requested connection, no connection is created
+func (nsm *nsmServer) ExplainEndpointSelection(ctx context.Context, request *nsmdapi.ExplainEndpointSelectionRequest) (*nsmdapi.ExplainEndpointSelectionReply, error) {
+	span := spanhelper.FromContext(ctx, "ExplainEndpointSelection")
+	defer span.Finish()
+
+	if request.GetConnection().GetNetworkService() == "" {
+		err := errors.New("network service is not specified")
+		span.LogError(err)
+		return nil, err
+	}
+	return nsm.manager.NseManager().ExplainEndpoint(span.Context(), request.GetConnection(), request.GetIgnoreEndpoints())
+}
+
 func (nsm *nsmServer) restore(ctx context.Context, registeredEndpointsList *registry.NetworkServiceEndpointList) {
 	span := spanhelper.FromContext(ctx, "restore")
 	defer span.Finish()
@@ -214,6 +229,7 @@ func (nsm *nsmServer) restore(ctx context.Context, registeredEndpointsList *regi
 	if deleteClientRegistry {
 		logrus.Errorf("delete of local nse/client registry... by ENV VAR: %s", NsmdDeleteLocalRegistry)
 		nsm.localRegistry.Delete()
+		nsm.connectionStore.Delete()
 	}
 
 	clients, nses, err := nsm.localRegistry.LoadRegistry()
@@ -508,6 +524,8 @@ func StartNSMServer(ctx context.Context, model model.Model, manager nsm.NetworkS
 
 	// Restore existing clients in case of NSMd restart.
 	nsm.restore(span.Context(), endpoints)
+	// Connections are restored once forwarder reports its state.
+	nsm.manager.SetConnectionStore(nsm.connectionStore)
 
 	return nsm, nil
 }
@@ -520,6 +538,7 @@ func createNsmServer(model model.Model, manager nsm.NetworkServiceManager, locat
 		manager:          manager,
 		locationProvider: locationProvider,
 		localRegistry:    nseregistry.NewNSERegistry(locationProvider.NsmNSERegistryFile()),
+		connectionStore:  connectionstore.NewStore(locationProvider.NsmConnectionSnapshotFile()),
 	}
 	return nsm
 }
@@ -534,7 +553,7 @@ func (nsm *nsmServer) initMonitorServers() {
 
 func (nsm *nsmServer) StartForwarderRegistratorServer(ctx context.Context) error {
 	var err error
-	nsm.regServer, err = StartForwarderRegistrarServer(ctx, nsm.model)
+	nsm.regServer, err = StartForwarderRegistrarServer(ctx, nsm.model, nsm.manager)
 	return err
 }
 
@@ -577,6 +596,9 @@ func (nsm *nsmServer) StartAPIServerAt(ctx context.Context, sock net.Listener, p
 
 	crossconnect.RegisterMonitorCrossConnectServer(grpcServer, nsm.crossConnectMonitor)
 	connection.RegisterMonitorConnectionServer(grpcServer, nsm.remoteConnectionMonitor)
+	nsmdapi.RegisterHealHistoryServer(grpcServer, NewHealHistoryServer(nsm.manager.HealHistory()))
+	nsmdapi.RegisterNSMDPropertiesServer(grpcServer, NewPropertiesServer(nsm.manager.GetHealProperties()))
+	nsmdapi.RegisterModelMonitorServer(grpcServer, NewModelMonitorServer(nsm.model))
 	probes.Append(health.NewGrpcHealth(grpcServer, sock.Addr(), time.Minute))
 
 	// Register Remote NetworkServiceManager
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+)
+
+type propertiesServer struct {
+	props *properties.Properties
+}
+
+// NewPropertiesServer - creates a server exposing properties NSMD is currently running with
+func NewPropertiesServer(props *properties.Properties) nsmdapi.NSMDPropertiesServer {
+	return &propertiesServer{props: props}
+}
+
+func (s *propertiesServer) GetProperties(ctx context.Context, request *nsmdapi.PropertiesRequest) (*nsmdapi.PropertiesReply, error) {
+	props := s.props.Snapshot()
+	return &nsmdapi.PropertiesReply{
+		HealTimeout:                     ptypes.DurationProto(props.HealTimeout),
+		CloseTimeout:                    ptypes.DurationProto(props.CloseTimeout),
+		HealRequestTimeout:              ptypes.DurationProto(props.HealRequestTimeout),
+		HealRequestConnectTimeout:       ptypes.DurationProto(props.HealRequestConnectTimeout),
+		HealRetryCount:                  int32(props.HealRetryCount),
+		HealRetryBackoff:                backoffProto(&props.HealRetryBackoff),
+		HealRequestConnectCheckTimeout:  ptypes.DurationProto(props.HealRequestConnectCheckTimeout),
+		HealForwarderTimeout:            ptypes.DurationProto(props.HealForwarderTimeout),
+		HealDstNseWaitTimeout:           ptypes.DurationProto(props.HealDSTNSEWaitTimeout),
+		HealDstNseWaitBackoff:           backoffProto(&props.HealDSTNSEWaitBackoff),
+		HealEnabled:                     props.HealEnabled,
+		HealMakeBeforeBreak:             props.HealMakeBeforeBreak,
+		HealHistorySize:                 int32(props.HealHistorySize),
+		HealHistoryConnections:          int32(props.HealHistoryConnections),
+		EndpointFailureThreshold:        int32(props.EndpointFailureThreshold),
+		EndpointFailureWindow:           ptypes.DurationProto(props.EndpointFailureWindow),
+		EndpointQuarantine:              ptypes.DurationProto(props.EndpointQuarantine),
+		ConfigFile:                      props.ConfigFile,
+		ConnectionSnapshotInterval:      ptypes.DurationProto(props.ConnectionSnapshotInterval),
+		ForwarderPolicies:               forwarderPoliciesProto(props.ForwarderPolicies),
+		ForwarderHeartbeatInterval:      ptypes.DurationProto(props.ForwarderHeartbeatInterval),
+		ForwarderHeartbeatMissThreshold: int32(props.ForwarderHeartbeatMissThreshold),
+		WireguardKeyRotationInterval:    ptypes.DurationProto(props.WireguardKeyRotationInterval),
+	}, nil
+}
+
+func backoffProto(backoff *properties.Backoff) *nsmdapi.Backoff {
+	return &nsmdapi.Backoff{
+		Initial:    ptypes.DurationProto(backoff.Initial),
+		Multiplier: backoff.Multiplier,
+		Max:        ptypes.DurationProto(backoff.Max),
+		Jitter:     backoff.Jitter,
+	}
+}
+
+func forwarderPoliciesProto(policies []properties.ForwarderPolicy) []*nsmdapi.ForwarderPolicy {
+	var rv []*nsmdapi.ForwarderPolicy
+	for _, policy := range policies {
+		priorities := map[string]int32{}
+		for _, priority := range policy.Priorities {
+			priorities[priority.Forwarder] = int32(priority.Priority)
+		}
+		labels := map[string]string{}
+		for _, label := range policy.Labels {
+			labels[label.Key] = label.Value
+		}
+		rv = append(rv, &nsmdapi.ForwarderPolicy{
+			NetworkService: policy.NetworkService,
+			Mechanisms:     policy.Mechanisms,
+			Priorities:     priorities,
+			Labels:         labels,
+		})
+	}
+	return rv
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -98,6 +99,10 @@ func (es *registryServer) RegisterNSEWithClient(ctx context.Context, request *re
 		Url: es.nsm.serviceRegistry.GetPublicAPI(),
 	}
 
+	if err := selector.ValidateStrategy(request.GetNetworkService().GetSelectionStrategy()); err != nil {
+		return nil, errors.Wrap(err, "invalid network service")
+	}
+
 	registration, err := client.RegisterNSE(ctx, request)
 	if err != nil {
 		err = errors.Wrap(err, "attempt to pass through from nsm to upstream registry failed with")
@@ -105,6 +110,12 @@ func (es *registryServer) RegisterNSEWithClient(ctx context.Context, request *re
 		return nil, err
 	}
 
+	// Upstream registry fills the selection strategy declared by the NetworkService resource
+	if err := selector.ValidateStrategy(registration.GetNetworkService().GetSelectionStrategy()); err != nil {
+		_, _ = client.RemoveNSE(ctx, &registry.RemoveNSERequest{NetworkServiceEndpointName: registration.GetNetworkServiceEndpoint().GetName()})
+		return nil, errors.Wrap(err, "invalid network service")
+	}
+
 	ep := es.nsm.model.GetEndpoint(registration.GetNetworkServiceEndpoint().GetName())
 	modelEndpoint := &model.Endpoint{
 		SocketLocation: es.workspace.NsmClientSocket(),
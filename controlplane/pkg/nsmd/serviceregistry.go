@@ -239,7 +239,7 @@ func (impl *nsmdServiceRegistry) WaitForForwarderAvailable(ctx context.Context,
 
 	st := time.Now()
 	checkConfigured := func(dp *model.Forwarder) bool {
-		return dp.MechanismsConfigured
+		return dp.MechanismsConfigured && !dp.Draining
 	}
 	for ; true; <-time.After(100 * time.Millisecond) {
 		if dp, _ := mdl.SelectForwarder(checkConfigured); dp != nil {
@@ -269,6 +269,7 @@ type defaultWorkspaceProvider struct {
 	nsmServerSocket string
 	nsmClientSocket string
 	nseRegistryFile string
+	snapshotFile    string
 }
 
 func NewDefaultWorkspaceProvider() serviceregistry.WorkspaceLocationProvider {
@@ -286,6 +287,7 @@ func NewWorkspaceProvider(rootDir string) serviceregistry.WorkspaceLocationProvi
 		nsmServerSocket: "nsm.server.io.sock",
 		nsmClientSocket: "nsm.client.io.sock",
 		nseRegistryFile: "nse.registry",
+		snapshotFile:    "connections.snapshot",
 	}
 }
 
@@ -301,6 +303,10 @@ func (w *defaultWorkspaceProvider) NsmNSERegistryFile() string {
 	return w.nsmBaseDir + w.nseRegistryFile
 }
 
+func (w *defaultWorkspaceProvider) NsmConnectionSnapshotFile() string {
+	return w.nsmBaseDir + w.snapshotFile
+}
+
 func (w *defaultWorkspaceProvider) ClientBaseDir() string {
 	return w.clientBaseDir
 }
@@ -0,0 +1,59 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// maxBackoffDelay - bounds delay of backoff without Max, so it does not overflow Duration after enough attempts
+const maxBackoffDelay = time.Duration(1 << 62)
+
+// Backoff - exponential backoff policy, delays grow from Initial by Multiplier up to Max and are randomly
+// shortened by up to Jitter fraction so retries of many connections don't happen in lockstep
+type Backoff struct {
+	Initial    time.Duration `mapstructure:"initial"`
+	Multiplier float64       `mapstructure:"multiplier"`
+	Max        time.Duration `mapstructure:"max"`
+	Jitter     float64       `mapstructure:"jitter"`
+}
+
+// Delay - returns delay before the next retry after failed attempt, attempts are counted from 0
+func (b *Backoff) Delay(attempt int) time.Duration {
+	return b.delay(attempt, rand.Float64())
+}
+
+// delay - computes delay for attempt, random is expected to be in [0, 1)
+func (b *Backoff) delay(attempt int, random float64) time.Duration {
+	if b == nil || b.Initial <= 0 {
+		return 0
+	}
+
+	delay := float64(b.Initial) * math.Pow(math.Max(b.Multiplier, 1), float64(attempt))
+	limit := maxBackoffDelay
+	if b.Max > 0 {
+		limit = b.Max
+	}
+	if delay > float64(limit) {
+		delay = float64(limit)
+	}
+	jitter := math.Min(math.Max(b.Jitter, 0), 1)
+	delay -= delay * jitter * random
+	return time.Duration(delay)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestBackoffSchedule(t *testing.T) {
+	g := NewWithT(t)
+
+	backoff := &Backoff{
+		Initial:    time.Second,
+		Multiplier: 2,
+		Max:        10 * time.Second,
+	}
+
+	var schedule []time.Duration
+	for attempt := 0; attempt < 6; attempt++ {
+		schedule = append(schedule, backoff.Delay(attempt))
+	}
+	g.Expect(schedule).To(Equal([]time.Duration{
+		1 * time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		10 * time.Second,
+		10 * time.Second,
+	}))
+}
+
+func TestBackoffJitter(t *testing.T) {
+	g := NewWithT(t)
+
+	backoff := &Backoff{
+		Initial:    4 * time.Second,
+		Multiplier: 2,
+		Max:        10 * time.Second,
+		Jitter:     0.5,
+	}
+
+	g.Expect(backoff.delay(0, 0)).To(Equal(4 * time.Second))
+	g.Expect(backoff.delay(0, 0.5)).To(Equal(3 * time.Second))
+	g.Expect(backoff.delay(1, 0.5)).To(Equal(6 * time.Second))
+	g.Expect(backoff.delay(2, 0.5)).To(Equal(7500 * time.Millisecond))
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoff.Delay(attempt)
+		g.Expect(delay).To(BeNumerically(">", 2*time.Second))
+		g.Expect(delay).To(BeNumerically("<=", 10*time.Second))
+	}
+}
+
+func TestBackoffConstant(t *testing.T) {
+	g := NewWithT(t)
+
+	var nilBackoff *Backoff
+	g.Expect(nilBackoff.Delay(3)).To(BeZero())
+	g.Expect((&Backoff{}).Delay(3)).To(BeZero())
+
+	constant := &Backoff{Initial: 500 * time.Millisecond}
+	g.Expect(constant.Delay(0)).To(Equal(500 * time.Millisecond))
+	g.Expect(constant.Delay(5)).To(Equal(500 * time.Millisecond))
+}
+
+func TestBackoffUnbounded(t *testing.T) {
+	g := NewWithT(t)
+
+	backoff := &Backoff{
+		Initial:    time.Second,
+		Multiplier: 2,
+	}
+	g.Expect(backoff.Validate()).NotTo(BeNil())
+
+	// Delay of unbounded backoff is clamped instead of overflowing
+	g.Expect(backoff.Delay(10000)).To(Equal(maxBackoffDelay))
+
+	backoff.Max = time.Minute
+	g.Expect(backoff.Validate()).To(BeNil())
+	g.Expect(backoff.Delay(10000)).To(Equal(time.Minute))
+}
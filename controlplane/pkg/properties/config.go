@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Snapshot - returns a copy of properties, safe to be used while properties are reloaded
+func (p *Properties) Snapshot() *Properties {
+	defer p.rlock()()
+
+	values := *p
+	values.mtx = nil
+	return &values
+}
+
+// Update - replaces properties with values
+func (p *Properties) Update(values *Properties) {
+	defer p.lock()()
+
+	mtx := p.mtx
+	*p = *values
+	p.mtx = mtx
+}
+
+// rlock - locks properties for reading and returns unlock function, properties created without constructor are never
+// reloaded, so they have no lock
+func (p *Properties) rlock() func() {
+	if p.mtx == nil {
+		return func() {}
+	}
+	p.mtx.RLock()
+	return p.mtx.RUnlock
+}
+
+// lock - locks properties for writing and returns unlock function
+func (p *Properties) lock() func() {
+	if p.mtx == nil {
+		return func() {}
+	}
+	p.mtx.Lock()
+	return p.mtx.Unlock
+}
+
+// Validate - checks properties are consistent
+func (p *Properties) Validate() error {
+	timeouts := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"healTimeout", p.HealTimeout},
+		{"closeTimeout", p.CloseTimeout},
+		{"healRequestTimeout", p.HealRequestTimeout},
+		{"healRequestConnectTimeout", p.HealRequestConnectTimeout},
+		{"healRequestConnectCheckTimeout", p.HealRequestConnectCheckTimeout},
+		{"healForwarderTimeout", p.HealForwarderTimeout},
+		{"healDstNseWaitTimeout", p.HealDSTNSEWaitTimeout},
+		{"forwarderHeartbeatInterval", p.ForwarderHeartbeatInterval},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			return errors.Errorf("%s should be positive: %v", timeout.key, timeout.value)
+		}
+	}
+	if p.HealRetryCount <= 0 {
+		return errors.Errorf("healRetryCount should be positive: %v", p.HealRetryCount)
+	}
+	if err := p.HealRetryBackoff.Validate(); err != nil {
+		return errors.Wrap(err, "healRetryBackoff")
+	}
+	if err := p.HealDSTNSEWaitBackoff.Validate(); err != nil {
+		return errors.Wrap(err, "healDstNseWaitBackoff")
+	}
+	if p.HealHistorySize <= 0 || p.HealHistoryConnections <= 0 {
+		return errors.Errorf("healHistorySize and healHistoryConnections should be positive: %v, %v", p.HealHistorySize, p.HealHistoryConnections)
+	}
+	if p.EndpointFailureThreshold < 0 {
+		return errors.Errorf("endpointFailureThreshold should not be negative: %v", p.EndpointFailureThreshold)
+	}
+	if p.EndpointFailureThreshold > 0 && (p.EndpointFailureWindow <= 0 || p.EndpointQuarantine <= 0) {
+		return errors.Errorf("endpointFailureWindow and endpointQuarantine should be positive: %v, %v", p.EndpointFailureWindow, p.EndpointQuarantine)
+	}
+	if p.ConnectionSnapshotInterval < 0 {
+		return errors.Errorf("connectionSnapshotInterval should not be negative: %v", p.ConnectionSnapshotInterval)
+	}
+	if p.WireguardKeyRotationInterval < 0 {
+		return errors.Errorf("wireguardKeyRotationInterval should not be negative: %v", p.WireguardKeyRotationInterval)
+	}
+	if p.ForwarderHeartbeatMissThreshold <= 0 {
+		return errors.Errorf("forwarderHeartbeatMissThreshold should be positive: %v", p.ForwarderHeartbeatMissThreshold)
+	}
+	if err := validateForwarderPolicies(p.ForwarderPolicies); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate - checks backoff delays are not negative and bounded if growing, and jitter is a fraction
+func (b *Backoff) Validate() error {
+	if b.Initial < 0 || b.Max < 0 {
+		return errors.Errorf("initial and max should not be negative: %v, %v", b.Initial, b.Max)
+	}
+	if b.Multiplier < 0 {
+		return errors.Errorf("multiplier should not be negative: %v", b.Multiplier)
+	}
+	if b.Multiplier > 1 && b.Max <= 0 {
+		return errors.Errorf("max should be positive for multiplier above 1: %v", b.Multiplier)
+	}
+	if b.Jitter < 0 || b.Jitter > 1 {
+		return errors.Errorf("jitter should be in [0, 1]: %v", b.Jitter)
+	}
+	return nil
+}
+
+// WatchConfig - reloads properties from their config file whenever it is changed until ctx is done, invalid config is
+// reported and previous values are kept. onReload, if not nil, is called with the reloaded values.
+func (p *Properties) WatchConfig(ctx context.Context, onReload func(values *Properties)) error {
+	path := p.Snapshot().ConfigFile
+	if path == "" {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create config file watcher")
+	}
+	// Directory is watched, since config file is usually replaced rather than written, e.g. ConfigMap symlinks swap.
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return errors.Wrapf(err, "failed to watch config file %s", path)
+	}
+	realPath, _ := filepath.EvalSymlinks(path)
+
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				currentPath, _ := filepath.EvalSymlinks(path)
+				written := filepath.Clean(event.Name) == path && event.Op&(fsnotify.Write|fsnotify.Create) != 0
+				if !written && (currentPath == "" || currentPath == realPath) {
+					continue
+				}
+				realPath = currentPath
+				p.reload(path, onReload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logrus.Errorf("Config file watcher error: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *Properties) reload(path string, onReload func(values *Properties)) {
+	logrus.Infof("Config file changed: %s", path)
+	values, err := loadConfig(path)
+	if err != nil {
+		logrus.Errorf("Failed to reload config file, keeping previous values: %v", err)
+		return
+	}
+	values.ConfigFile = path
+	p.Update(values)
+	logrus.Infof("Reloaded properties: %+v", *values)
+	if onReload != nil {
+		onReload(values)
+	}
+}
+
+// loadConfig - reads properties from YAML config file at path, values missing in the file are taken from environment
+// variables or defaults
+func loadConfig(path string) (*Properties, error) {
+	config := viper.New()
+	config.SetConfigFile(path)
+	config.SetConfigType("yaml")
+	if err := config.ReadInConfig(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read config file %s", path)
+	}
+
+	values := newEnvProperties()
+	if err := config.UnmarshalExact(values); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse config file %s", path)
+	}
+	if err := values.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid config file %s", path)
+	}
+	return values, nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// writeConfig replaces config file atomically, so the watcher never reads partially written one
+func writeConfig(g *WithT, path, content string) {
+	g.Expect(ioutil.WriteFile(path+".tmp", []byte(content), 0600)).To(Succeed())
+	g.Expect(os.Rename(path+".tmp", path)).To(Succeed())
+}
+
+func configDir(g *WithT) (string, func()) {
+	dir, err := ioutil.TempDir("", "nsmd-config")
+	g.Expect(err).To(BeNil())
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestLoadConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, cleanup := configDir(g)
+	defer cleanup()
+	path := filepath.Join(dir, "nsmd.yaml")
+	writeConfig(g, path, `
+healEnabled: false
+healRetryCount: 3
+closeTimeout: 2s
+forwarderHeartbeatInterval: 1s
+wireguardKeyRotationInterval: 1h
+healRetryBackoff:
+  initial: 1s
+  multiplier: 2
+  max: 1m
+  jitter: 0.5
+`)
+
+	values, err := loadConfig(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(values.HealEnabled).To(BeFalse())
+	g.Expect(values.HealRetryCount).To(Equal(3))
+	g.Expect(values.CloseTimeout).To(Equal(2 * time.Second))
+	g.Expect(values.ForwarderHeartbeatInterval).To(Equal(time.Second))
+	g.Expect(values.WireguardKeyRotationInterval).To(Equal(time.Hour))
+	g.Expect(values.HealRetryBackoff).To(Equal(Backoff{
+		Initial:    time.Second,
+		Multiplier: 2,
+		Max:        time.Minute,
+		Jitter:     0.5,
+	}))
+	// Missing in the file are defaults
+	g.Expect(values.HealTimeout).To(Equal(time.Minute))
+	g.Expect(values.ForwarderHeartbeatMissThreshold).To(Equal(3))
+}
+
+func TestLoadConfigForwarderPolicies(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, cleanup := configDir(g)
+	defer cleanup()
+	path := filepath.Join(dir, "nsmd.yaml")
+	writeConfig(g, path, `
+forwarderPolicies:
+  - mechanisms: [MEMIF, KERNEL_INTERFACE]
+  - networkService: Secure-Intranet
+    priorities:
+      - forwarder: Kernel-Forwarder
+        priority: 10
+    labels:
+      - key: Zone
+        value: Secure
+`)
+
+	values, err := loadConfig(path)
+	g.Expect(err).To(BeNil())
+	g.Expect(values.ForwarderPolicies).To(Equal([]ForwarderPolicy{
+		{
+			Mechanisms: []string{"MEMIF", "KERNEL_INTERFACE"},
+		},
+		{
+			NetworkService: "Secure-Intranet",
+			Priorities: []ForwarderPriority{
+				{Forwarder: "Kernel-Forwarder", Priority: 10},
+			},
+			Labels: []ForwarderLabel{
+				{Key: "Zone", Value: "Secure"},
+			},
+		},
+	}))
+
+	writeConfig(g, path, `
+forwarderPolicies:
+  - mechanisms: [MEMIF]
+  - mechanisms: [KERNEL_INTERFACE]
+`)
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, `
+forwarderPolicies:
+  - priorities:
+      - forwarder: kernel-forwarder
+      - forwarder: kernel-forwarder
+`)
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, `
+forwarderPolicies:
+  - labels:
+      - value: secure
+`)
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, cleanup := configDir(g)
+	defer cleanup()
+	path := filepath.Join(dir, "nsmd.yaml")
+
+	writeConfig(g, path, "healRetryBackoff:\n  jitter: 2\n")
+	_, err := loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, "healRetryBackoff:\n  multiplier: 2\n")
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, "closeTimeout: -1s\n")
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, "wireguardKeyRotationInterval: -1h\n")
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, "forwarderHeartbeatMissThreshold: 0\n")
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	writeConfig(g, path, "healTimeot: 1s\n")
+	_, err = loadConfig(path)
+	g.Expect(err).NotTo(BeNil())
+
+	_, err = loadConfig(filepath.Join(dir, "missing.yaml"))
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestNewNsmPropertiesConfigFile(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, cleanup := configDir(g)
+	defer cleanup()
+	path := filepath.Join(dir, "nsmd.yaml")
+	writeConfig(g, path, "healRetryCount: 5\n")
+
+	g.Expect(os.Setenv(NsmdConfigFile, path)).To(Succeed())
+	defer func() { _ = os.Unsetenv(NsmdConfigFile) }()
+
+	values := NewNsmProperties()
+	g.Expect(values.HealRetryCount).To(Equal(5))
+	g.Expect(values.ConfigFile).To(Equal(path))
+
+	// Invalid config file is ignored
+	writeConfig(g, path, "healRetryCount: 0\n")
+	values = NewNsmProperties()
+	g.Expect(values.HealRetryCount).To(Equal(10))
+}
+
+func TestWatchConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, cleanup := configDir(g)
+	defer cleanup()
+	path := filepath.Join(dir, "nsmd.yaml")
+	writeConfig(g, path, "healRetryCount: 5\n")
+
+	values, err := loadConfig(path)
+	g.Expect(err).To(BeNil())
+	values.ConfigFile = path
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan *Properties, 10)
+	g.Expect(values.WatchConfig(ctx, func(values *Properties) {
+		reloaded <- values
+	})).To(Succeed())
+
+	writeConfig(g, path, "healRetryCount: 7\n")
+	g.Eventually(reloaded, 5*time.Second).Should(Receive())
+	g.Eventually(func() int { return values.Snapshot().HealRetryCount }).Should(Equal(7))
+
+	// Invalid config keeps previous values
+	writeConfig(g, path, "healRetryCount: -1\n")
+	g.Consistently(func() int { return values.Snapshot().HealRetryCount }, 500*time.Millisecond).Should(Equal(7))
+
+	// Changes are not watched after context is done
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	writeConfig(g, path, "healRetryCount: 9\n")
+	g.Consistently(func() int { return values.Snapshot().HealRetryCount }, 500*time.Millisecond).Should(Equal(7))
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import "github.com/pkg/errors"
+
+// ForwarderPolicy - configures selection of forwarders for connections of network service, the policy with empty
+// network service applies to network services without their own policy
+type ForwarderPolicy struct {
+	NetworkService string `mapstructure:"networkService"`
+	// Mechanisms - mechanism types in order of preference
+	Mechanisms []string `mapstructure:"mechanisms"`
+	// Priorities - administrative priorities of forwarders, higher is preferred
+	Priorities []ForwarderPriority `mapstructure:"priorities"`
+	// Labels - labels forwarders are required to be registered with
+	Labels []ForwarderLabel `mapstructure:"labels"`
+}
+
+// ForwarderPriority - administrative priority of forwarder by its registered name
+type ForwarderPriority struct {
+	Forwarder string `mapstructure:"forwarder"`
+	Priority  int    `mapstructure:"priority"`
+}
+
+// ForwarderLabel - label forwarder is required to be registered with
+type ForwarderLabel struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+// Validate - checks mechanisms, forwarders and label keys are listed once
+func (p *ForwarderPolicy) Validate() error {
+	mechanisms := map[string]bool{}
+	for _, mechanism := range p.Mechanisms {
+		if mechanism == "" || mechanisms[mechanism] {
+			return errors.Errorf("mechanisms should be unique and not empty: %v", p.Mechanisms)
+		}
+		mechanisms[mechanism] = true
+	}
+	forwarders := map[string]bool{}
+	for _, priority := range p.Priorities {
+		if priority.Forwarder == "" || forwarders[priority.Forwarder] {
+			return errors.Errorf("priorities forwarders should be unique and not empty: %v", p.Priorities)
+		}
+		forwarders[priority.Forwarder] = true
+	}
+	keys := map[string]bool{}
+	for _, label := range p.Labels {
+		if label.Key == "" || keys[label.Key] {
+			return errors.Errorf("labels keys should be unique and not empty: %v", p.Labels)
+		}
+		keys[label.Key] = true
+	}
+	return nil
+}
+
+func validateForwarderPolicies(policies []ForwarderPolicy) error {
+	networkServices := map[string]bool{}
+	for i := range policies {
+		policy := &policies[i]
+		if networkServices[policy.NetworkService] {
+			return errors.Errorf("forwarderPolicies has a duplicate policy of network service %q", policy.NetworkService)
+		}
+		networkServices[policy.NetworkService] = true
+		if err := policy.Validate(); err != nil {
+			return errors.Wrapf(err, "forwarderPolicies of network service %q", policy.NetworkService)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package properties
 import (
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -30,29 +31,104 @@ const (
 	NsmdHealDSTWaitTimeout = "NSMD_HEAL_DST_TIMEOUTs" // Wait timeout for DST in seconds
 	// NsmdHealRetryCount - amount of times healing will retry
 	NsmdHealRetryCount = "NSMD_HEAL_RETRY_COUNT"
+	// NsmdHealBackoffInitial - environment variable name - delay before the second heal attempt
+	NsmdHealBackoffInitial = "NSMD_HEAL_BACKOFF_INITIAL"
+	// NsmdHealBackoffMultiplier - environment variable name - factor each next heal attempt delay grows by
+	NsmdHealBackoffMultiplier = "NSMD_HEAL_BACKOFF_MULTIPLIER"
+	// NsmdHealBackoffMax - environment variable name - upper limit of heal attempt delay
+	NsmdHealBackoffMax = "NSMD_HEAL_BACKOFF_MAX"
+	// NsmdHealBackoffJitter - environment variable name - fraction of heal attempt delay randomly cut off
+	NsmdHealBackoffJitter = "NSMD_HEAL_BACKOFF_JITTER"
+	// NsmdHealMakeBeforeBreak - environment variable name - programs the new path before the previous one is closed
+	// when connection is healed to another endpoint
+	NsmdHealMakeBeforeBreak = "NSMD_HEAL_MAKE_BEFORE_BREAK"
+	// NsmdHealHistorySize - environment variable name - amount of heal events kept per connection
+	NsmdHealHistorySize = "NSMD_HEAL_HISTORY_SIZE"
+	// NsmdEndpointFailureThreshold - environment variable name - amount of consecutive failed requests quarantining
+	// network service endpoint, 0 disables quarantine
+	NsmdEndpointFailureThreshold = "NSMD_ENDPOINT_FAILURE_THRESHOLD"
+	// NsmdEndpointFailureWindow - environment variable name - time window consecutive failed requests are counted in
+	NsmdEndpointFailureWindow = "NSMD_ENDPOINT_FAILURE_WINDOW"
+	// NsmdEndpointQuarantine - environment variable name - time network service endpoint is excluded from selection
+	NsmdEndpointQuarantine = "NSMD_ENDPOINT_QUARANTINE"
+	// NsmdConnectionSnapshotInterval - environment variable name - minimal interval between saves of client connections
+	// snapshot, 0 disables the snapshot
+	NsmdConnectionSnapshotInterval = "NSMD_CONNECTION_SNAPSHOT_INTERVAL"
+	// NsmdForwarderHeartbeatInterval - environment variable name - interval of heartbeats sent to forwarders
+	NsmdForwarderHeartbeatInterval = "NSMD_FORWARDER_HEARTBEAT_INTERVAL"
+	// NsmdForwarderHeartbeatMissThreshold - environment variable name - amount of forwarder heartbeats missed in a row
+	// the forwarder is considered hung and its connections are healed after
+	NsmdForwarderHeartbeatMissThreshold = "NSMD_FORWARDER_HEARTBEAT_MISS_THRESHOLD"
+	// NsmdWireguardKeyRotationInterval - environment variable name - interval forwarders rotate Wireguard keys at,
+	// remote Wireguard connections are renegotiated with the new keys, 0 disables the rotation
+	NsmdWireguardKeyRotationInterval = "NSMD_WIREGUARD_KEY_ROTATION_INTERVAL"
+	// NsmdConfigFile - environment variable name - path of YAML config file with properties, reloaded on change
+	NsmdConfigFile = "NSMD_CONFIG_FILE"
 )
 
-// Properties - holds properties of NSM connection events processing
+// Properties - holds properties of NSM connection events processing, field tags are keys of the config file
 type Properties struct {
-	HealTimeout                    time.Duration
-	CloseTimeout                   time.Duration
-	HealRequestTimeout             time.Duration
-	HealRequestConnectTimeout      time.Duration
-	HealRetryCount                 int
-	HealRetryDelay                 time.Duration
-	HealRequestConnectCheckTimeout time.Duration
-	HealForwarderTimeout           time.Duration
+	HealTimeout                    time.Duration `mapstructure:"healTimeout"`
+	CloseTimeout                   time.Duration `mapstructure:"closeTimeout"`
+	HealRequestTimeout             time.Duration `mapstructure:"healRequestTimeout"`
+	HealRequestConnectTimeout      time.Duration `mapstructure:"healRequestConnectTimeout"`
+	HealRetryCount                 int           `mapstructure:"healRetryCount"`
+	HealRetryBackoff               Backoff       `mapstructure:"healRetryBackoff"`
+	HealRequestConnectCheckTimeout time.Duration `mapstructure:"healRequestConnectCheckTimeout"`
+	HealForwarderTimeout           time.Duration `mapstructure:"healForwarderTimeout"`
 
 	// Total DST heal timeout is 20 seconds.
-	HealDSTNSEWaitTimeout time.Duration
-	HealDSTNSEWaitTick    time.Duration
+	HealDSTNSEWaitTimeout time.Duration `mapstructure:"healDstNseWaitTimeout"`
+	HealDSTNSEWaitBackoff Backoff       `mapstructure:"healDstNseWaitBackoff"`
 
-	HealEnabled bool
+	HealEnabled         bool `mapstructure:"healEnabled"`
+	HealMakeBeforeBreak bool `mapstructure:"healMakeBeforeBreak"`
+
+	HealHistorySize        int `mapstructure:"healHistorySize"`
+	HealHistoryConnections int `mapstructure:"healHistoryConnections"`
+
+	EndpointFailureThreshold int           `mapstructure:"endpointFailureThreshold"`
+	EndpointFailureWindow    time.Duration `mapstructure:"endpointFailureWindow"`
+	EndpointQuarantine       time.Duration `mapstructure:"endpointQuarantine"`
+
+	ConnectionSnapshotInterval time.Duration `mapstructure:"connectionSnapshotInterval"`
+
+	ForwarderPolicies []ForwarderPolicy `mapstructure:"forwarderPolicies"`
+
+	ForwarderHeartbeatInterval      time.Duration `mapstructure:"forwarderHeartbeatInterval"`
+	ForwarderHeartbeatMissThreshold int           `mapstructure:"forwarderHeartbeatMissThreshold"`
+
+	WireguardKeyRotationInterval time.Duration `mapstructure:"wireguardKeyRotationInterval"`
+
+	// ConfigFile - path of the config file properties are loaded from, empty if there is no one
+	ConfigFile string `mapstructure:"-"`
+
+	// mtx - guards properties updated by config file reload
+	mtx *sync.RWMutex
 }
 
-// NewNsmProperties creates NsmProperties with defined default values and reading values from environment variables
+// NewNsmProperties creates NsmProperties with defined default values and reading values from environment variables,
+// values from the config file referred by NSMD_CONFIG_FILE take precedence
 func NewNsmProperties() *Properties {
+	values := newEnvProperties()
+	if path := os.Getenv(NsmdConfigFile); path != "" {
+		logrus.Infof("Loading properties from config file: %s", path)
+		loaded, err := loadConfig(path)
+		if err != nil {
+			logrus.Errorf("Failed to load config file, using default and environment values: %v", err)
+			loaded = values
+		}
+		loaded.ConfigFile = path
+		return loaded
+	}
+	return values
+}
+
+// newEnvProperties creates Properties with defined default values and reading values from environment variables
+func newEnvProperties() *Properties {
 	values := &Properties{
+		mtx: &sync.RWMutex{},
+
 		HealTimeout:                    time.Minute * 1,
 		CloseTimeout:                   time.Second * 5,
 		HealRequestTimeout:             time.Second * 20,
@@ -60,18 +136,41 @@ func NewNsmProperties() *Properties {
 		HealRequestConnectCheckTimeout: time.Second * 1,
 		HealForwarderTimeout:           time.Minute * 1,
 		HealRetryCount:                 10,
-		HealRetryDelay:                 time.Second * 5,
+		// Constant delay between heal attempts, exponential backoff is enabled by a multiplier above 1
+		HealRetryBackoff: Backoff{
+			Initial:    time.Second * 5,
+			Multiplier: 1,
+		},
 
 		// Total DST heal timeout is 20 seconds.
-		HealDSTNSEWaitTimeout: time.Second * 30,       // Maximum time to wait for NSMD/NSE to re-appear
-		HealDSTNSEWaitTick:    500 * time.Millisecond, // Wait timeout to appear of NSE
-		HealEnabled:           true,
+		HealDSTNSEWaitTimeout: time.Second * 30, // Maximum time to wait for NSMD/NSE to re-appear
+		HealDSTNSEWaitBackoff: Backoff{ // Wait timeout to appear of NSE
+			Initial:    500 * time.Millisecond,
+			Multiplier: 1,
+		},
+		HealEnabled:            true,
+		HealHistorySize:        32,
+		HealHistoryConnections: 1024,
+
+		EndpointFailureThreshold: 3,
+		EndpointFailureWindow:    time.Minute * 1,
+		EndpointQuarantine:       time.Second * 30,
+
+		ConnectionSnapshotInterval: time.Second * 5,
+
+		ForwarderHeartbeatInterval:      time.Second * 5,
+		ForwarderHeartbeatMissThreshold: 3,
+
+		WireguardKeyRotationInterval: time.Hour * 24,
 	}
 
 	// Parse few Environment variables.
 	if os.Getenv(NsmdHealEnabled) == "false" {
 		values.HealEnabled = false
 	}
+	if os.Getenv(NsmdHealMakeBeforeBreak) == "true" {
+		values.HealMakeBeforeBreak = true
+	}
 	dstWaitTimeout := os.Getenv(NsmdHealDSTWaitTimeout)
 	if len(dstWaitTimeout) > 0 {
 		logrus.Infof("Override HealDSTWaitTimeout: %s", dstWaitTimeout)
@@ -92,5 +191,93 @@ func NewNsmProperties() *Properties {
 		values.HealRetryCount = int(value)
 	}
 
+	historySize := os.Getenv(NsmdHealHistorySize)
+	if historySize != "" {
+		value, err := strconv.ParseInt(historySize, 10, 32)
+		if err == nil {
+			values.HealHistorySize = int(value)
+		} else {
+			logrus.Errorf("Failed to parse heal history size value... %v", err)
+		}
+	}
+
+	failureThreshold := os.Getenv(NsmdEndpointFailureThreshold)
+	if failureThreshold != "" {
+		value, err := strconv.ParseInt(failureThreshold, 10, 32)
+		if err == nil {
+			values.EndpointFailureThreshold = int(value)
+		} else {
+			logrus.Errorf("Failed to parse endpoint failure threshold value... %v", err)
+		}
+	}
+	if window, ok := parseDuration(NsmdEndpointFailureWindow); ok {
+		values.EndpointFailureWindow = window
+	}
+	if quarantine, ok := parseDuration(NsmdEndpointQuarantine); ok {
+		values.EndpointQuarantine = quarantine
+	}
+
+	if interval, ok := parseDuration(NsmdConnectionSnapshotInterval); ok {
+		values.ConnectionSnapshotInterval = interval
+	}
+
+	if interval, ok := parseDuration(NsmdForwarderHeartbeatInterval); ok {
+		values.ForwarderHeartbeatInterval = interval
+	}
+	missThreshold := os.Getenv(NsmdForwarderHeartbeatMissThreshold)
+	if missThreshold != "" {
+		value, err := strconv.ParseInt(missThreshold, 10, 32)
+		if err == nil {
+			values.ForwarderHeartbeatMissThreshold = int(value)
+		} else {
+			logrus.Errorf("Failed to parse forwarder heartbeat miss threshold value... %v", err)
+		}
+	}
+
+	if interval, ok := parseDuration(NsmdWireguardKeyRotationInterval); ok {
+		values.WireguardKeyRotationInterval = interval
+	}
+
+	if initial, ok := parseDuration(NsmdHealBackoffInitial); ok {
+		values.HealRetryBackoff.Initial = initial
+	}
+	if multiplier, ok := parseFloat(NsmdHealBackoffMultiplier); ok {
+		values.HealRetryBackoff.Multiplier = multiplier
+	}
+	if max, ok := parseDuration(NsmdHealBackoffMax); ok {
+		values.HealRetryBackoff.Max = max
+	}
+	if jitter, ok := parseFloat(NsmdHealBackoffJitter); ok {
+		values.HealRetryBackoff.Jitter = jitter
+	}
+
 	return values
 }
+
+func parseDuration(env string) (time.Duration, bool) {
+	value := os.Getenv(env)
+	if value == "" {
+		return 0, false
+	}
+	logrus.Infof("Override %s: %s", env, value)
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		logrus.Errorf("Failed to parse %s value... %v", env, err)
+		return 0, false
+	}
+	return duration, true
+}
+
+func parseFloat(env string) (float64, bool) {
+	value := os.Getenv(env)
+	if value == "" {
+		return 0, false
+	}
+	logrus.Infof("Override %s: %s", env, value)
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logrus.Errorf("Failed to parse %s value... %v", env, err)
+		return 0, false
+	}
+	return number, true
+}
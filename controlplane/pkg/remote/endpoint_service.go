@@ -55,7 +55,7 @@ func (cce *endpointService) closeEndpoint(ctx context.Context, cc *model.ClientC
 		logger.Infof("No need to close, since NSE is we know is dead at this point.")
 		return nil
 	}
-	closeCtx, closeCancel := context.WithTimeout(ctx, cce.props.CloseTimeout)
+	closeCtx, closeCancel := context.WithTimeout(ctx, cce.props.Snapshot().CloseTimeout)
 	defer closeCancel()
 
 	client, nseClientError := cce.nseManager.CreateNSEClient(closeCtx, cc.Endpoint)
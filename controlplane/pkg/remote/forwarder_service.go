@@ -23,9 +23,10 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
@@ -58,16 +59,13 @@ type forwarderService struct {
 }
 
 func (cce *forwarderService) selectForwarder(request *networkservice.NetworkServiceRequest) (*model.Forwarder, error) {
-	dp, err := cce.model.SelectForwarder(func(dp *model.Forwarder) bool {
-		for _, m := range request.GetRequestMechanismPreferences() {
-			if cce.findMechanism(dp.RemoteMechanisms, m.GetType()) != nil {
-				return true
-			}
-		}
-		return false
+	return cce.model.SelectForwarderFor(&model.ForwarderRequest{
+		NetworkService: request.GetConnection().GetNetworkService(),
+		Mechanisms:     request.GetRequestMechanismPreferences(),
+		Remote:         true,
 	})
-	return dp, err
 }
+
 func (cce *forwarderService) findMechanism(mechanismPreferences []*connection.Mechanism, mechanismType string) *connection.Mechanism {
 	for _, m := range mechanismPreferences {
 		if m.GetType() == mechanismType {
@@ -94,7 +92,8 @@ func (cce *forwarderService) selectRemoteMechanism(request *networkservice.Netwo
 	}
 
 	if mechanism == nil {
-		for _, m := range request.GetRequestMechanismPreferences() {
+		policy := cce.model.GetForwarderPolicy(request.GetConnection().GetNetworkService())
+		for _, m := range policy.OrderMechanisms(request.GetRequestMechanismPreferences()) {
 			dpm := cce.findMechanism(dp.RemoteMechanisms, m.GetType())
 			if dpm != nil {
 				mechanism = m
@@ -116,11 +115,16 @@ func (cce *forwarderService) selectRemoteMechanism(request *networkservice.Netwo
 	case vxlan.MECHANISM:
 		cce.configureVXLANParameters(parameters, dpParameters)
 
+	case gre.MECHANISM:
+		cce.configureGREParameters(parameters, dpParameters)
+
 	case srv6.MECHANISM:
 		cce.configureSRv6Parameters(connectionID, parameters, dpParameters)
 
 	case wireguard.MECHANISM:
-		cce.configureWireguardParameters(connectionID, parameters, dpParameters)
+		if err := cce.configureWireguardParameters(connectionID, parameters, dpParameters); err != nil {
+			return nil, err
+		}
 	}
 
 	logrus.Infof("NSM:(5.1) Remote mechanism selected %v", mechanism)
@@ -129,28 +133,34 @@ func (cce *forwarderService) selectRemoteMechanism(request *networkservice.Netwo
 
 func (cce *forwarderService) configureVXLANParameters(parameters, dpParameters map[string]string) {
 	parameters[vxlan.DstIP] = dpParameters[vxlan.SrcIP]
+	parameters[vxlan.VNI] = strconv.FormatUint(uint64(cce.allocateTunnelID(parameters, dpParameters)), 10)
+}
+
+// configureGREParameters - GRE keys share the allocator with VXLAN VNIs, so tunnels of both are distinguished the same way
+func (cce *forwarderService) configureGREParameters(parameters, dpParameters map[string]string) {
+	parameters[gre.DstIP] = dpParameters[gre.SrcIP]
+	parameters[gre.Key] = strconv.FormatUint(uint64(cce.allocateTunnelID(parameters, dpParameters)), 10)
+}
 
-	extSrcIP := parameters[vxlan.SrcIP]
-	extDstIP := dpParameters[vxlan.SrcIP]
-	srcIP := parameters[vxlan.SrcIP]
-	dstIP := dpParameters[vxlan.SrcIP]
+// allocateTunnelID allocates an identifier of the tunnel, unique for the pair of source and destination hosts
+func (cce *forwarderService) allocateTunnelID(parameters, dpParameters map[string]string) uint32 {
+	extSrcIP := parameters[mechanismCommon.SrcIP]
+	extDstIP := dpParameters[mechanismCommon.SrcIP]
+	srcIP := parameters[mechanismCommon.SrcIP]
+	dstIP := dpParameters[mechanismCommon.SrcIP]
 
-	if ip, ok := parameters[vxlan.SrcOriginalIP]; ok {
+	if ip, ok := parameters[mechanismCommon.SrcOriginalIP]; ok {
 		srcIP = ip
 	}
 
-	if ip, ok := parameters[vxlan.DstExternalIP]; ok {
+	if ip, ok := parameters[mechanismCommon.DstExternalIP]; ok {
 		extDstIP = ip
 	}
 
-	var vni uint32
 	if extDstIP != extSrcIP {
-		vni = cce.serviceRegistry.VniAllocator().Vni(extDstIP, extSrcIP)
-	} else {
-		vni = cce.serviceRegistry.VniAllocator().Vni(dstIP, srcIP)
+		return cce.serviceRegistry.VniAllocator().Vni(extDstIP, extSrcIP)
 	}
-
-	parameters[vxlan.VNI] = strconv.FormatUint(uint64(vni), 10)
+	return cce.serviceRegistry.VniAllocator().Vni(dstIP, srcIP)
 }
 
 func (cce *forwarderService) configureSRv6Parameters(connectionID string, parameters, dpParameters map[string]string) {
@@ -161,18 +171,16 @@ func (cce *forwarderService) configureSRv6Parameters(connectionID string, parame
 	parameters[srv6.DstLocalSID] = cce.serviceRegistry.SIDAllocator().SID(connectionID)
 }
 
-func (cce *forwarderService) configureWireguardParameters(connectionID string, parameters, dpParameters map[string]string) {
-	parameters[wireguard.DstIP] = dpParameters[wireguard.SrcIP]
-
-	key, err := wgtypes.GeneratePrivateKey()
-	if err != nil {
-		return
+// configureWireguardParameters - only public keys are exchanged, private keys are kept by the forwarders
+func (cce *forwarderService) configureWireguardParameters(connectionID string, parameters, dpParameters map[string]string) error {
+	publicKey, ok := dpParameters[wireguard.SrcPublicKey]
+	if !ok {
+		return errors.Errorf("forwarder does not advertise its %s public key", wireguard.MECHANISM)
 	}
-
-	parameters[wireguard.DstPrivateKey] = key.String()
-	parameters[wireguard.DstPublicKey] = key.PublicKey().String()
-
+	parameters[wireguard.DstIP] = dpParameters[wireguard.SrcIP]
+	parameters[wireguard.DstPublicKey] = publicKey
 	parameters[wireguard.DstPort] = wireguard.AssignPort(connectionID)
+	return nil
 }
 
 func (cce *forwarderService) updateMechanism(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) error {
@@ -0,0 +1,158 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// affinityReplicas is a number of points every endpoint has on the hash ring
+const affinityReplicas = 100
+
+// AffinitySelector is a Selector mapping connections with the same affinity labels to the same endpoint
+type AffinitySelector interface {
+	Selector
+	// AffinityLabels returns names of the request connection labels used as the affinity key
+	AffinityLabels() []string
+}
+
+type affinitySelector struct {
+	Selector
+	labels []string
+}
+
+type hashRingSelector struct {
+	labels     []string
+	roundRobin Selector
+}
+
+// NewAffinitySelector creates a new selector matching endpoints against NetworkService matches and mapping
+// the values of the given request connection labels onto the matched endpoints with a consistent hash ring,
+// so adding or removing an endpoint remaps only a minimal share of clients.
+// Connections without any of the labels are distributed with round robin.
+func NewAffinitySelector(labels []string) AffinitySelector {
+	return &affinitySelector{
+		Selector: newMatchSelector(&hashRingSelector{
+			labels:     labels,
+			roundRobin: NewRoundRobinSelector(),
+		}),
+		labels: labels,
+	}
+}
+
+// DefaultAffinityLabels returns the request connection labels identifying the client pod
+func DefaultAffinityLabels() []string {
+	return []string{connection.PodNameKey, connection.NamespaceKey}
+}
+
+func (s *affinitySelector) AffinityLabels() []string {
+	return s.labels
+}
+
+func (s *affinitySelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return DryRunSelectEndpoint(s.Selector, requestConnection, ns, networkServiceEndpoints)
+}
+
+func (hr *hashRingSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return hr.selectEndpoint(requestConnection, ns, networkServiceEndpoints, false)
+}
+
+func (hr *hashRingSelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return hr.selectEndpoint(requestConnection, ns, networkServiceEndpoints, true)
+}
+
+func (hr *hashRingSelector) selectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
+	if len(networkServiceEndpoints) == 0 {
+		return nil
+	}
+
+	key, ok := affinityKey(requestConnection.GetLabels(), hr.labels)
+	if !ok {
+		return selectEndpoint(hr.roundRobin, requestConnection, ns, networkServiceEndpoints, dryRun)
+	}
+
+	endpoint := newHashRing(networkServiceEndpoints).get(key)
+	logrus.Infof("Affinity selected %v for %v", endpoint, key)
+	return endpoint
+}
+
+// affinityKey builds a hash ring key from the labels values, returns false if none of the labels are present
+func affinityKey(connectionLabels map[string]string, labels []string) (string, bool) {
+	found := false
+	values := make([]string, 0, len(labels))
+	for _, label := range labels {
+		value, ok := connectionLabels[label]
+		found = found || ok
+		values = append(values, label+"="+value)
+	}
+	return strings.Join(values, ","), found
+}
+
+type hashRing struct {
+	hashes    []uint64
+	endpoints map[uint64]*registry.NetworkServiceEndpoint
+}
+
+func newHashRing(endpoints []*registry.NetworkServiceEndpoint) *hashRing {
+	ring := &hashRing{
+		hashes:    make([]uint64, 0, len(endpoints)*affinityReplicas),
+		endpoints: make(map[uint64]*registry.NetworkServiceEndpoint, len(endpoints)*affinityReplicas),
+	}
+	for _, endpoint := range endpoints {
+		for i := 0; i < affinityReplicas; i++ {
+			h := hash(endpoint.GetName() + "#" + strconv.Itoa(i))
+			if _, ok := ring.endpoints[h]; ok {
+				continue
+			}
+			ring.hashes = append(ring.hashes, h)
+			ring.endpoints[h] = endpoint
+		}
+	}
+	sort.Slice(ring.hashes, func(i, j int) bool {
+		return ring.hashes[i] < ring.hashes[j]
+	})
+	return ring
+}
+
+// get returns the endpoint owning the first point of the ring clockwise from the key hash
+func (r *hashRing) get(key string) *registry.NetworkServiceEndpoint {
+	if len(r.hashes) == 0 {
+		return nil
+	}
+	h := hash(key)
+	idx := sort.Search(len(r.hashes), func(i int) bool {
+		return r.hashes[i] >= h
+	})
+	if idx == len(r.hashes) {
+		idx = 0
+	}
+	return r.endpoints[r.hashes[idx]]
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
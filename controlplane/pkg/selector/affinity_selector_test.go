@@ -0,0 +1,153 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func affinityEndpoints(count int) []*registry.NetworkServiceEndpoint {
+	endpoints := []*registry.NetworkServiceEndpoint{}
+	for i := 1; i <= count; i++ {
+		endpoints = append(endpoints, &registry.NetworkServiceEndpoint{
+			Name: "NSE-" + strconv.Itoa(i),
+		})
+	}
+	return endpoints
+}
+
+func affinityConnection(i int) *connection.Connection {
+	return &connection.Connection{
+		Labels: map[string]string{
+			connection.PodNameKey:   "pod-" + strconv.Itoa(i),
+			connection.NamespaceKey: "default",
+		},
+	}
+}
+
+func Test_affinitySelector_SameEndpoint(t *testing.T) {
+	s := NewAffinitySelector([]string{connection.PodNameKey, connection.NamespaceKey})
+	ns := &registry.NetworkService{
+		Name: "vpn",
+	}
+	endpoints := affinityEndpoints(5)
+
+	for i := 0; i < 100; i++ {
+		want := s.SelectEndpoint(affinityConnection(i), ns, endpoints)
+		for pass := 0; pass < 3; pass++ {
+			if got := s.SelectEndpoint(affinityConnection(i), ns, endpoints); got != want {
+				t.Errorf("affinitySelector.SelectEndpoint() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func Test_affinitySelector_MinimalRemap(t *testing.T) {
+	s := NewAffinitySelector([]string{connection.PodNameKey, connection.NamespaceKey})
+	ns := &registry.NetworkService{
+		Name: "vpn",
+	}
+	endpoints := affinityEndpoints(5)
+	clients := 1000
+
+	selected := map[int]string{}
+	perEndpoint := map[string]int{}
+	for i := 0; i < clients; i++ {
+		selected[i] = s.SelectEndpoint(affinityConnection(i), ns, endpoints).GetName()
+		perEndpoint[selected[i]]++
+	}
+	for _, endpoint := range endpoints {
+		if perEndpoint[endpoint.GetName()] == 0 {
+			t.Errorf("%v has no connections", endpoint.GetName())
+		}
+	}
+
+	// Remove NSE-3, only its clients should be remapped.
+	removed := append(append([]*registry.NetworkServiceEndpoint{}, endpoints[:2]...), endpoints[3:]...)
+	for i := 0; i < clients; i++ {
+		got := s.SelectEndpoint(affinityConnection(i), ns, removed).GetName()
+		if selected[i] != "NSE-3" && got != selected[i] {
+			t.Errorf("client %v is remapped from %v to %v", i, selected[i], got)
+		}
+		if got == "NSE-3" {
+			t.Errorf("client %v is mapped to removed endpoint", i)
+		}
+	}
+
+	// Add NSE-6, only clients mapped to it should be remapped.
+	added := append(affinityEndpoints(5), &registry.NetworkServiceEndpoint{Name: "NSE-6"})
+	remapped := 0
+	for i := 0; i < clients; i++ {
+		got := s.SelectEndpoint(affinityConnection(i), ns, added).GetName()
+		if got != selected[i] {
+			remapped++
+			if got != "NSE-6" {
+				t.Errorf("client %v is remapped from %v to %v", i, selected[i], got)
+			}
+		}
+	}
+	if remapped == 0 || remapped > clients/2 {
+		t.Errorf("%v of %v clients are remapped to the added endpoint", remapped, clients)
+	}
+}
+
+func Test_affinitySelector_NoLabels(t *testing.T) {
+	s := NewAffinitySelector([]string{connection.PodNameKey})
+	ns := &registry.NetworkService{
+		Name: "vpn",
+	}
+	endpoints := affinityEndpoints(3)
+
+	for i := 0; i < 6; i++ {
+		want := endpoints[i%len(endpoints)]
+		if got := s.SelectEndpoint(&connection.Connection{}, ns, endpoints); got != want {
+			t.Errorf("affinitySelector.SelectEndpoint() = %v, want %v", got, want)
+		}
+	}
+}
+
+func Test_affinitySelector_Matches(t *testing.T) {
+	s := NewAffinitySelector([]string{connection.PodNameKey})
+	ns := &registry.NetworkService{
+		Name: "vpn",
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector: map[string]string{"app": "vpn-gateway"},
+					},
+				},
+			},
+		},
+	}
+	endpoints := affinityEndpoints(5)
+	gateway := &registry.NetworkServiceEndpoint{
+		Name:   "vpn-gateway",
+		Labels: map[string]string{"app": "vpn-gateway"},
+	}
+	endpoints = append(endpoints, gateway)
+
+	for i := 0; i < 10; i++ {
+		if got := s.SelectEndpoint(affinityConnection(i), ns, endpoints); got != gateway {
+			t.Errorf("affinitySelector.SelectEndpoint() = %v, want %v", got, gateway)
+		}
+	}
+}
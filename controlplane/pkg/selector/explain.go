@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// MatchExplanation is a result of evaluating a single NetworkService match against the request labels
+type MatchExplanation struct {
+	Match         *registry.Match
+	SourceMatched bool
+	Routes        []*RouteExplanation
+}
+
+// RouteExplanation holds the endpoints passing the destination selector of a route
+type RouteExplanation struct {
+	Destination *registry.Destination
+	Endpoints   []*registry.NetworkServiceEndpoint
+}
+
+// ExplainMatches evaluates the NetworkService matches the same way the match selector does, routes are evaluated
+// for the matches with matched source selector only.
+func ExplainMatches(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) []*MatchExplanation {
+	nsLabels := requestConnection.GetLabels()
+
+	var result []*MatchExplanation
+	matchedNonEmptySelector := false
+	for _, match := range ns.GetMatches() {
+		explanation := &MatchExplanation{
+			Match:         match,
+			SourceMatched: sourceMatched(match, nsLabels, &matchedNonEmptySelector),
+		}
+		if explanation.SourceMatched {
+			for _, destination := range match.GetRoutes() {
+				explanation.Routes = append(explanation.Routes, &RouteExplanation{
+					Destination: destination,
+					Endpoints:   destinationEndpoints(destination, networkServiceEndpoints, nsLabels),
+				})
+			}
+		}
+		result = append(result, explanation)
+	}
+	return result
+}
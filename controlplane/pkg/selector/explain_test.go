@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func Test_DryRunSelectEndpoint_KeepsState(t *testing.T) {
+	selectors := map[string]Selector{
+		"match":             NewMatchSelector(),
+		"least-connections": NewLeastConnectionsSelector(testConnectionCounter{}),
+		"network-service":   NewNetworkServiceSelector(NewMatchSelector()),
+	}
+	for name, s := range selectors {
+		t.Run(name, func(t *testing.T) {
+			ns := canaryNetworkService(1, 1)
+			endpoints := canaryEndpoints()
+			for i := 0; i < 3; i++ {
+				want := DryRunSelectEndpoint(s, &connection.Connection{}, ns, endpoints)
+				if want == nil {
+					t.Fatalf("DryRunSelectEndpoint() = nil")
+				}
+				if again := DryRunSelectEndpoint(s, &connection.Connection{}, ns, endpoints); again != want {
+					t.Errorf("DryRunSelectEndpoint() = %v, want %v", again.GetName(), want.GetName())
+				}
+				if got := s.SelectEndpoint(&connection.Connection{}, ns, endpoints); got != want {
+					t.Errorf("SelectEndpoint() = %v, want %v", got.GetName(), want.GetName())
+				}
+			}
+		})
+	}
+}
+
+func Test_DryRunSelectEndpoint_Unsupported(t *testing.T) {
+	if got := DryRunSelectEndpoint(&firstEndpointSelector{}, &connection.Connection{}, &registry.NetworkService{}, canaryEndpoints()); got != nil {
+		t.Errorf("DryRunSelectEndpoint() = %v, want nil", got)
+	}
+}
+
+func Test_ExplainMatches(t *testing.T) {
+	ns := &registry.NetworkService{
+		Name: "explain-ns",
+		Matches: []*registry.Match{
+			{
+				SourceSelector: map[string]string{"app": "firewall"},
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"version": "v1"}},
+				},
+			},
+			{
+				SourceSelector: map[string]string{"app": "client"},
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"version": "v2"}},
+				},
+			},
+			{
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"version": "v2"}},
+				},
+			},
+		},
+	}
+
+	explanations := ExplainMatches(&connection.Connection{Labels: map[string]string{"app": "firewall"}}, ns, canaryEndpoints())
+	if len(explanations) != 3 {
+		t.Fatalf("ExplainMatches() returned %v matches, want 3", len(explanations))
+	}
+
+	wantMatched := []bool{true, false, false}
+	for i, explanation := range explanations {
+		if explanation.SourceMatched != wantMatched[i] {
+			t.Errorf("match %v SourceMatched = %v, want %v", i, explanation.SourceMatched, wantMatched[i])
+		}
+	}
+
+	routes := explanations[0].Routes
+	if len(routes) != 1 || len(routes[0].Endpoints) != 2 {
+		t.Fatalf("ExplainMatches() routes = %v, want a single route with 2 endpoints", routes)
+	}
+	for _, endpoint := range routes[0].Endpoints {
+		if endpoint.GetLabels()["version"] != "v1" {
+			t.Errorf("unexpected endpoint %v passed destination selector", endpoint.GetName())
+		}
+	}
+}
+
+type firstEndpointSelector struct{}
+
+func (s *firstEndpointSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return networkServiceEndpoints[0]
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// isSelected checks if labels match both the plain selector and all of the set-based requirements
+func isSelected(labels, selector map[string]string, requirements []*registry.LabelSelectorRequirement, nsLabels map[string]string) bool {
+	if !isSubset(labels, selector, nsLabels) {
+		return false
+	}
+	for _, requirement := range requirements {
+		if !matchesRequirement(labels, requirement) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRequirement checks if labels satisfy the requirement, requirements with unknown operators never match
+func matchesRequirement(labels map[string]string, requirement *registry.LabelSelectorRequirement) bool {
+	value, ok := labels[requirement.GetKey()]
+	switch requirement.GetOperator() {
+	case registry.LabelSelectorOpIn:
+		return ok && containsValue(requirement.GetValues(), value)
+	case registry.LabelSelectorOpNotIn:
+		return !ok || !containsValue(requirement.GetValues(), value)
+	case registry.LabelSelectorOpExists:
+		return ok
+	case registry.LabelSelectorOpDoesNotExist:
+		return !ok
+	default:
+		logrus.Errorf("Unknown label selector operator %q for key %q", requirement.GetOperator(), requirement.GetKey())
+		return false
+	}
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
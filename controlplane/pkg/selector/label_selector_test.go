@@ -0,0 +1,109 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func Test_matchesRequirement(t *testing.T) {
+	labels := map[string]string{
+		"app":     "firewall",
+		"version": "v2",
+	}
+	tests := []struct {
+		name        string
+		requirement *registry.LabelSelectorRequirement
+		want        bool
+	}{
+		{"In match", &registry.LabelSelectorRequirement{Key: "version", Operator: registry.LabelSelectorOpIn, Values: []string{"v1", "v2"}}, true},
+		{"In no match", &registry.LabelSelectorRequirement{Key: "version", Operator: registry.LabelSelectorOpIn, Values: []string{"v1"}}, false},
+		{"In missing label", &registry.LabelSelectorRequirement{Key: "zone", Operator: registry.LabelSelectorOpIn, Values: []string{"a"}}, false},
+		{"NotIn match", &registry.LabelSelectorRequirement{Key: "version", Operator: registry.LabelSelectorOpNotIn, Values: []string{"v1"}}, true},
+		{"NotIn no match", &registry.LabelSelectorRequirement{Key: "version", Operator: registry.LabelSelectorOpNotIn, Values: []string{"v2"}}, false},
+		{"NotIn missing label", &registry.LabelSelectorRequirement{Key: "zone", Operator: registry.LabelSelectorOpNotIn, Values: []string{"a"}}, true},
+		{"Exists", &registry.LabelSelectorRequirement{Key: "app", Operator: registry.LabelSelectorOpExists}, true},
+		{"Exists missing label", &registry.LabelSelectorRequirement{Key: "zone", Operator: registry.LabelSelectorOpExists}, false},
+		{"DoesNotExist", &registry.LabelSelectorRequirement{Key: "zone", Operator: registry.LabelSelectorOpDoesNotExist}, true},
+		{"DoesNotExist present label", &registry.LabelSelectorRequirement{Key: "app", Operator: registry.LabelSelectorOpDoesNotExist}, false},
+		{"unknown operator", &registry.LabelSelectorRequirement{Key: "app", Operator: "Gt", Values: []string{"1"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesRequirement(labels, tt.requirement); got != tt.want {
+				t.Errorf("matchesRequirement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_matchSelector_SelectorExpressions(t *testing.T) {
+	ns := &registry.NetworkService{
+		Name: "expressions-ns",
+		Matches: []*registry.Match{
+			{
+				SourceSelectorExpressions: []*registry.LabelSelectorRequirement{
+					{Key: "app", Operator: registry.LabelSelectorOpIn, Values: []string{"firewall", "passthrough"}},
+				},
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector: map[string]string{"app": "vpn-gateway"},
+						DestinationSelectorExpressions: []*registry.LabelSelectorRequirement{
+							{Key: "version", Operator: registry.LabelSelectorOpNotIn, Values: []string{"v1"}},
+						},
+					},
+				},
+			},
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelectorExpressions: []*registry.LabelSelectorRequirement{
+							{Key: "app", Operator: registry.LabelSelectorOpDoesNotExist},
+						},
+					},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "vpn-v1", Labels: map[string]string{"app": "vpn-gateway", "version": "v1"}},
+		{Name: "vpn-v2", Labels: map[string]string{"app": "vpn-gateway", "version": "v2"}},
+		{Name: "default", Labels: map[string]string{"zone": "a"}},
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{"source In", map[string]string{"app": "passthrough"}, "vpn-v2"},
+		{"source not In", map[string]string{"app": "client"}, "default"},
+		{"no labels", nil, "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchSelector()
+			conn := &connection.Connection{Labels: tt.labels}
+			if got := m.SelectEndpoint(conn, ns, endpoints); got.GetName() != tt.want {
+				t.Errorf("matchSelector.SelectEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// ConnectionCounter provides the number of active client connections per network service endpoint
+type ConnectionCounter interface {
+	// EndpointConnections returns a map of network service endpoint name to the number of its active connections
+	EndpointConnections() map[string]int
+}
+
+type leastConnectionsSelector struct {
+	counter    ConnectionCounter
+	roundRobin Selector
+}
+
+// NewLeastConnectionsSelector creates a new selector matching endpoints against NetworkService matches
+// and choosing the one with the fewest active connections, ties are broken with round robin.
+func NewLeastConnectionsSelector(counter ConnectionCounter) Selector {
+	return newMatchSelector(&leastConnectionsSelector{
+		counter:    counter,
+		roundRobin: NewRoundRobinSelector(),
+	})
+}
+
+func (lc *leastConnectionsSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return lc.selectEndpoint(requestConnection, ns, networkServiceEndpoints, false)
+}
+
+func (lc *leastConnectionsSelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return lc.selectEndpoint(requestConnection, ns, networkServiceEndpoints, true)
+}
+
+func (lc *leastConnectionsSelector) selectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
+	if len(networkServiceEndpoints) == 0 {
+		return nil
+	}
+
+	connections := lc.counter.EndpointConnections()
+
+	var candidates []*registry.NetworkServiceEndpoint
+	minConnections := -1
+	for _, endpoint := range networkServiceEndpoints {
+		count := connections[endpoint.GetName()]
+		switch {
+		case minConnections == -1 || count < minConnections:
+			minConnections = count
+			candidates = []*registry.NetworkServiceEndpoint{endpoint}
+		case count == minConnections:
+			candidates = append(candidates, endpoint)
+		}
+	}
+
+	logrus.Infof("LeastConnections candidates with %d connections: %v", minConnections, candidates)
+	return selectEndpoint(lc.roundRobin, requestConnection, ns, candidates, dryRun)
+}
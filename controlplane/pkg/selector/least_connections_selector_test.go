@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type testConnectionCounter map[string]int
+
+func (c testConnectionCounter) EndpointConnections() map[string]int {
+	return c
+}
+
+func Test_leastConnectionsSelector_SelectEndpoint(t *testing.T) {
+	counter := testConnectionCounter{
+		"NSE-1": 100,
+		"NSE-2": 3,
+		"NSE-3": 3,
+	}
+	ns := &registry.NetworkService{
+		Name: "network-service-1",
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "NSE-1"},
+		{Name: "NSE-2"},
+		{Name: "NSE-3"},
+		{Name: "NSE-4"},
+	}
+
+	lc := NewLeastConnectionsSelector(counter)
+
+	if got := lc.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != "NSE-4" {
+		t.Errorf("leastConnectionsSelector.SelectEndpoint() = %v, want NSE-4", got)
+	}
+
+	counter["NSE-4"] = 10
+	want := []string{"NSE-3", "NSE-2", "NSE-3"}
+	for _, name := range want {
+		if got := lc.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != name {
+			t.Errorf("leastConnectionsSelector.SelectEndpoint() = %v, want %v", got, name)
+		}
+	}
+
+	if got := lc.SelectEndpoint(&connection.Connection{}, ns, nil); got != nil {
+		t.Errorf("leastConnectionsSelector.SelectEndpoint() = %v, want nil", got)
+	}
+}
+
+func Test_leastConnectionsSelector_Matches(t *testing.T) {
+	counter := testConnectionCounter{
+		"firewall-1": 5,
+		"firewall-2": 1,
+		"vpn":        0,
+	}
+	ns := &registry.NetworkService{
+		Name: "secure-intranet-connectivity",
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector: map[string]string{
+							"app": "firewall",
+						},
+					},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "firewall-1", Labels: map[string]string{"app": "firewall"}},
+		{Name: "firewall-2", Labels: map[string]string{"app": "firewall"}},
+		{Name: "vpn", Labels: map[string]string{"app": "vpn"}},
+	}
+
+	lc := NewLeastConnectionsSelector(counter)
+	if got := lc.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != "firewall-2" {
+		t.Errorf("leastConnectionsSelector.SelectEndpoint() = %v, want firewall-2", got)
+	}
+}
+
+func Test_networkServiceSelector_SelectEndpoint(t *testing.T) {
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "NSE-1"},
+		{Name: "NSE-2"},
+	}
+	counter := testConnectionCounter{
+		"NSE-1": 1,
+	}
+
+	s := NewNetworkServiceSelector(NewRoundRobinSelector())
+	s.SetNetworkServiceSelector("least-connections", NewLeastConnectionsSelector(counter))
+
+	for i := 0; i < 2; i++ {
+		if got := s.SelectEndpoint(nil, &registry.NetworkService{Name: "least-connections"}, endpoints); got.GetName() != "NSE-2" {
+			t.Errorf("networkServiceSelector.SelectEndpoint() = %v, want NSE-2", got)
+		}
+	}
+	for _, name := range []string{"NSE-1", "NSE-2"} {
+		if got := s.SelectEndpoint(nil, &registry.NetworkService{Name: "round-robin"}, endpoints); got.GetName() != name {
+			t.Errorf("networkServiceSelector.SelectEndpoint() = %v, want %v", got, name)
+		}
+	}
+
+	s.SetNetworkServiceSelector("least-connections", nil)
+	if got := s.SelectEndpoint(nil, &registry.NetworkService{Name: "least-connections"}, endpoints); got.GetName() != "NSE-1" {
+		t.Errorf("networkServiceSelector.SelectEndpoint() = %v, want NSE-1", got)
+	}
+}
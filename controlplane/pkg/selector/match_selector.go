@@ -17,9 +17,12 @@ package selector
 
 import (
 	"bytes"
+	"container/list"
+	"strconv"
 	"sync"
 	"text/template"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
@@ -28,24 +31,46 @@ import (
 
 type matchSelector struct {
 	sync.Mutex
-	roundRobin Selector
+	endpointSelector Selector
+	weighted         *weightedSelector
 }
 
-// NewMatchSelector creates a new
+// NewMatchSelector creates a new selector matching endpoints against NetworkService matches.
+// If routes of the matched source selector have weights, the route group is chosen in proportion to them.
+// Routes with zero weight are only used when no weighted route of the match has endpoints, so if none
+// of the routes have weights, endpoints of all of them are selected alike.
 func NewMatchSelector() Selector {
+	return newMatchSelector(NewRoundRobinSelector())
+}
+
+// newMatchSelector creates a match selector choosing between matched endpoints with endpointSelector
+func newMatchSelector(endpointSelector Selector) Selector {
 	return &matchSelector{
-		roundRobin: NewRoundRobinSelector(),
+		endpointSelector: endpointSelector,
+		weighted:         newWeightedSelector(),
+	}
+}
+
+// newUnweightedMatchSelector creates a match selector choosing between endpoints of all the matched routes regardless of their weights
+func newUnweightedMatchSelector(endpointSelector Selector) Selector {
+	return &matchSelector{
+		endpointSelector: endpointSelector,
 	}
 }
 
 // isSubset checks if B is a subset of A. TODO: reconsider this as a part of "tools"
+// Values of B failed to be processed as templates never match.
 func isSubset(a, b, nsLabels map[string]string) bool {
 	if len(a) < len(b) {
 		return false
 	}
 	for k, v := range b {
 		if a[k] != v {
-			result := ProcessLabels(v, nsLabels)
+			result, err := ProcessLabels(v, nsLabels)
+			if err != nil {
+				logrus.Errorf("Failed to process selector %s=%s: %v", k, v, err)
+				return false
+			}
 			if a[k] != result {
 				return false
 			}
@@ -54,40 +79,72 @@ func isSubset(a, b, nsLabels map[string]string) bool {
 	return true
 }
 
-func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+// sourceMatched checks if the match source selector matches the request labels, matchedNonEmptySelector
+// tracks if any non empty source selector is already matched by the previous matches.
+func sourceMatched(match *registry.Match, nsLabels map[string]string, matchedNonEmptySelector *bool) bool {
+	// All match source selector labels should be present in the requested labels map and satisfy the expressions
+	if !isSelected(nsLabels, match.GetSourceSelector(), match.GetSourceSelectorExpressions(), nsLabels) {
+		return false
+	}
+
+	emptySelector := len(match.GetSourceSelector()) == 0 && len(match.GetSourceSelectorExpressions()) == 0
+	// If we already have matched any non empty selector we shouldn't match empty selector
+	if emptySelector && *matchedNonEmptySelector {
+		return false
+	}
+
+	if !emptySelector {
+		*matchedNonEmptySelector = true
+	}
+	return true
+}
+
+// destinationEndpoints returns endpoints matching the destination selector
+func destinationEndpoints(destination *registry.Destination, networkServiceEndpoints []*registry.NetworkServiceEndpoint, nsLabels map[string]string) []*registry.NetworkServiceEndpoint {
+	var result []*registry.NetworkServiceEndpoint
+	for _, nse := range networkServiceEndpoints {
+		if isSelected(nse.GetLabels(), destination.GetDestinationSelector(), destination.GetDestinationSelectorExpressions(), nsLabels) {
+			result = append(result, nse)
+		}
+	}
+	return result
+}
+
+func (m *matchSelector) matchEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
+	nsLabels := requestConnection.GetLabels()
 	logrus.Infof("Matching endpoint for labels %v", nsLabels)
 
 	matchedNonEmptySelector := false
 	//Iterate through the matches
-	for _, match := range ns.GetMatches() {
-		// All match source selector labels should be present in the requested labels map
-		if !isSubset(nsLabels, match.GetSourceSelector(), nsLabels) {
-			continue
-		}
-
-		// If we already have matched any non empty selector we shouldn't match empty selector
-		if len(match.GetSourceSelector()) == 0 && matchedNonEmptySelector {
+	for i, match := range ns.GetMatches() {
+		if !sourceMatched(match, nsLabels, &matchedNonEmptySelector) {
 			continue
 		}
 
-		if len(match.GetSourceSelector()) != 0 {
-			matchedNonEmptySelector = true
-		}
-
 		nseCandidates := []*registry.NetworkServiceEndpoint{}
+		routes := []*weightedRoute{}
 		// Check all Destinations in that match
 		for _, destination := range match.GetRoutes() {
-			// Each NSE should be matched against that destination
-			for _, nse := range networkServiceEndpoints {
-				if isSubset(nse.GetLabels(), destination.GetDestinationSelector(), nsLabels) {
-					nseCandidates = append(nseCandidates, nse)
-				}
+			route := &weightedRoute{
+				destination: destination,
+				endpoints:   destinationEndpoints(destination, networkServiceEndpoints, nsLabels),
 			}
+			nseCandidates = append(nseCandidates, route.endpoints...)
+			routes = append(routes, route)
+		}
+
+		// Weighted routes take precedence, all candidates are used if there are no weighted routes to select
+		routeKey := ns.GetName() + "/" + strconv.Itoa(i)
+		if j := m.weighted.selectRoute(routeKey, routes, dryRun); j >= 0 {
+			logrus.Infof("Weighted route selected %v", routes[j].destination)
+			return selectEndpoint(m.endpointSelector, requestConnection, &registry.NetworkService{
+				Name: routeKey + "/" + strconv.Itoa(j),
+			}, routes[j].endpoints, dryRun)
 		}
 
 		if len(nseCandidates) > 0 {
-			// We found candidates. Use RoundRobin to select one
-			return m.roundRobin.SelectEndpoint(nil, ns, nseCandidates)
+			// We found candidates. Use endpoint selector to select one
+			return selectEndpoint(m.endpointSelector, requestConnection, ns, nseCandidates, dryRun)
 		}
 	}
 	return nil
@@ -95,29 +152,91 @@ func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.N
 
 func (m *matchSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
 	logrus.Infof("Selecting endpoint for %s with %d matches.", requestConnection.GetNetworkService(), len(ns.GetMatches()))
+	return m.selectEndpoint(requestConnection, ns, networkServiceEndpoints, false)
+}
+
+func (m *matchSelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return m.selectEndpoint(requestConnection, ns, networkServiceEndpoints, true)
+}
+
+func (m *matchSelector) selectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
 	if len(ns.GetMatches()) == 0 {
-		return m.roundRobin.SelectEndpoint(nil, ns, networkServiceEndpoints)
+		return selectEndpoint(m.endpointSelector, requestConnection, ns, networkServiceEndpoints, dryRun)
 	}
 
-	return m.matchEndpoint(requestConnection.GetLabels(), ns, networkServiceEndpoints)
+	return m.matchEndpoint(requestConnection, ns, networkServiceEndpoints, dryRun)
 }
 
 // ProcessLabels generates matches based on destination label selectors that specify templating.
-func ProcessLabels(str string, vars interface{}) string {
-	tmpl, err := template.New("tmpl").Parse(str)
-
+func ProcessLabels(str string, vars interface{}) (string, error) {
+	tmpl, err := templates.get(str)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 	return process(tmpl, vars)
 }
 
-func process(t *template.Template, vars interface{}) string {
+func process(t *template.Template, vars interface{}) (string, error) {
 	var tmplBytes bytes.Buffer
 
 	err := t.Execute(&tmplBytes, vars)
 	if err != nil {
-		panic(err)
+		return "", errors.Wrap(err, "failed to execute selector template")
+	}
+	return tmplBytes.String(), nil
+}
+
+// maxCachedTemplates bounds the number of compiled selector templates kept by templateCache
+const maxCachedTemplates = 1024
+
+// templateCache keeps the most recently used selector templates compiled, invalid templates are never cached
+type templateCache struct {
+	sync.Mutex
+	size      int
+	order     *list.List
+	templates map[string]*list.Element
+}
+
+type cachedTemplate struct {
+	str  string
+	tmpl *template.Template
+}
+
+var templates = newTemplateCache(maxCachedTemplates)
+
+func newTemplateCache(size int) *templateCache {
+	return &templateCache{
+		size:      size,
+		order:     list.New(),
+		templates: make(map[string]*list.Element),
+	}
+}
+
+func (c *templateCache) get(str string) (*template.Template, error) {
+	c.Lock()
+	if element, ok := c.templates[str]; ok {
+		c.order.MoveToFront(element)
+		c.Unlock()
+		return element.Value.(*cachedTemplate).tmpl, nil
+	}
+	c.Unlock()
+
+	tmpl, err := registry.ParseSelectorTemplate(str)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector template %q", str)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if element, ok := c.templates[str]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*cachedTemplate).tmpl, nil
+	}
+	c.templates[str] = c.order.PushFront(&cachedTemplate{str: str, tmpl: tmpl})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.templates, oldest.Value.(*cachedTemplate).str)
 	}
-	return tmplBytes.String()
+	return tmpl, nil
 }
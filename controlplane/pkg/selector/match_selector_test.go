@@ -597,6 +597,88 @@ func Test_matchSelector_SelectEndpoint(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid template does not match",
+			args: args{
+				requestConnection: &connection.Connection{
+					Labels: map[string]string{
+						"app": "firewall",
+					},
+				},
+				ns: &registry.NetworkService{
+					Name: "invalid-template-ns",
+					Matches: []*registry.Match{
+						{
+							Routes: []*registry.Destination{
+								{
+									DestinationSelector: map[string]string{
+										"app": "{{index . \"app\"",
+									},
+								},
+								{
+									DestinationSelector: map[string]string{
+										"app": "icmp-responder",
+									},
+								},
+							},
+						},
+					},
+				},
+				networkServiceEndpoints: []*registry.NetworkServiceEndpoint{
+					{
+						Name: "icmp-responder",
+						Labels: map[string]string{
+							"app": "icmp-responder",
+						},
+					},
+					{
+						Name: "firewall",
+						Labels: map[string]string{
+							"app": "firewall",
+						},
+					},
+				},
+			},
+			want: &registry.NetworkServiceEndpoint{
+				Name: "icmp-responder",
+				Labels: map[string]string{
+					"app": "icmp-responder",
+				},
+			},
+		},
+		{
+			name: "template execution failure does not match",
+			args: args{
+				requestConnection: &connection.Connection{
+					Labels: map[string]string{
+						"app": "firewall",
+					},
+				},
+				ns: &registry.NetworkService{
+					Name: "failing-template-ns",
+					Matches: []*registry.Match{
+						{
+							Routes: []*registry.Destination{
+								{
+									DestinationSelector: map[string]string{
+										"app": "{{index . 1}}",
+									},
+								},
+							},
+						},
+					},
+				},
+				networkServiceEndpoints: []*registry.NetworkServiceEndpoint{
+					{
+						Name: "firewall",
+						Labels: map[string]string{
+							"app": "firewall",
+						},
+					},
+				},
+			},
+			want: nil,
+		},
 	}
 
 	m := NewMatchSelector()
@@ -609,3 +691,21 @@ func Test_matchSelector_SelectEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func Test_templateCache_Bounded(t *testing.T) {
+	cache := newTemplateCache(2)
+	for i := 0; i < 3; i++ {
+		if _, err := cache.get("{{.app" + strconv.Itoa(i) + "}}"); err != nil {
+			t.Fatalf("templateCache.get() error = %v", err)
+		}
+	}
+	if _, err := cache.get("{{.app"); err == nil {
+		t.Errorf("templateCache.get() expected error for invalid template")
+	}
+	if cache.order.Len() != 2 || len(cache.templates) != 2 {
+		t.Errorf("templateCache keeps %v templates, want 2", len(cache.templates))
+	}
+	if _, ok := cache.templates["{{.app0}}"]; ok {
+		t.Errorf("templateCache keeps the least recently used template")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// NetworkServiceSelector is a Selector delegating to the selection strategy declared by the requested network service
+// or to the selector configured for it
+type NetworkServiceSelector interface {
+	Selector
+	// SetNetworkServiceSelector configures selector to be used for networkService, nil restores the default one
+	SetNetworkServiceSelector(networkService string, selector Selector)
+	// SetDefaultSelector configures selector to be used for network services without their own selector
+	SetDefaultSelector(selector Selector)
+	// GetNetworkServiceSelector returns selector configured for networkService
+	GetNetworkServiceSelector(networkService string) Selector
+	// SetStrategyContext configures the state passed to selection strategies, already created strategies are dropped
+	SetStrategyContext(strategyContext *StrategyContext)
+	// SelectorFor returns selector used for ns, the selection strategy declared by ns takes precedence over
+	// the configured selectors
+	SelectorFor(ns *registry.NetworkService) Selector
+}
+
+type networkServiceSelector struct {
+	sync.RWMutex
+	defaultSelector Selector
+	selectors       map[string]Selector
+	strategyContext *StrategyContext
+	strategies      map[string]Selector
+}
+
+// NewNetworkServiceSelector creates a new NetworkServiceSelector using defaultSelector for not configured network services
+func NewNetworkServiceSelector(defaultSelector Selector) NetworkServiceSelector {
+	return &networkServiceSelector{
+		defaultSelector: defaultSelector,
+		selectors:       make(map[string]Selector),
+		strategyContext: &StrategyContext{},
+		strategies:      make(map[string]Selector),
+	}
+}
+
+func (s *networkServiceSelector) SetNetworkServiceSelector(networkService string, selector Selector) {
+	s.Lock()
+	defer s.Unlock()
+
+	if selector == nil {
+		delete(s.selectors, networkService)
+		return
+	}
+	s.selectors[networkService] = selector
+}
+
+func (s *networkServiceSelector) SetDefaultSelector(selector Selector) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.defaultSelector = selector
+}
+
+func (s *networkServiceSelector) GetNetworkServiceSelector(networkService string) Selector {
+	s.RLock()
+	defer s.RUnlock()
+
+	if selector, ok := s.selectors[networkService]; ok {
+		return selector
+	}
+	return s.defaultSelector
+}
+
+func (s *networkServiceSelector) SetStrategyContext(strategyContext *StrategyContext) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.strategyContext = strategyContext
+	s.strategies = make(map[string]Selector)
+}
+
+func (s *networkServiceSelector) SelectorFor(ns *registry.NetworkService) Selector {
+	if strategy := ns.GetSelectionStrategy(); strategy != "" {
+		if selector := s.strategySelector(strategy); selector != nil {
+			return selector
+		}
+	}
+	return s.GetNetworkServiceSelector(ns.GetName())
+}
+
+// strategySelector returns selector of the strategy shared by all the network services declaring it
+func (s *networkServiceSelector) strategySelector(strategy string) Selector {
+	s.Lock()
+	defer s.Unlock()
+
+	if selector, ok := s.strategies[strategy]; ok {
+		return selector
+	}
+	selector, err := NewStrategySelector(strategy, s.strategyContext)
+	if err != nil {
+		// Remember the failure, so it is reported once per strategy
+		logrus.Errorf("Falling back to the configured selector: %v", err)
+	}
+	s.strategies[strategy] = selector
+	return selector
+}
+
+func (s *networkServiceSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return s.SelectorFor(ns).SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+}
+
+func (s *networkServiceSelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return DryRunSelectEndpoint(s.SelectorFor(ns), requestConnection, ns, networkServiceEndpoints)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type randomSelector struct {
+	sync.Mutex
+	rand *rand.Rand
+}
+
+// NewRandomSelector creates a selector matching endpoints against NetworkService matches and choosing a random one.
+// It doesn't support dry run since the choice can't be known in advance.
+func NewRandomSelector() Selector {
+	return newMatchSelector(&randomSelector{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	})
+}
+
+func (rs *randomSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if len(networkServiceEndpoints) == 0 {
+		return nil
+	}
+
+	rs.Lock()
+	idx := rs.rand.Intn(len(networkServiceEndpoints))
+	rs.Unlock()
+
+	endpoint := networkServiceEndpoints[idx]
+	logrus.Infof("Random selected %v", endpoint)
+	return endpoint
+}
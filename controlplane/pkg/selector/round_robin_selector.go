@@ -21,6 +21,14 @@ func NewRoundRobinSelector() Selector {
 }
 
 func (rr *roundRobinSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return rr.selectEndpoint(ns, networkServiceEndpoints, false)
+}
+
+func (rr *roundRobinSelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return rr.selectEndpoint(ns, networkServiceEndpoints, true)
+}
+
+func (rr *roundRobinSelector) selectEndpoint(ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
 	if rr == nil {
 		return nil
 	}
@@ -31,8 +39,8 @@ func (rr *roundRobinSelector) SelectEndpoint(requestConnection *connection.Conne
 	defer rr.Unlock()
 	idx := rr.roundRobin[ns.GetName()] % len(networkServiceEndpoints)
 	endpoint := networkServiceEndpoints[idx]
-	if endpoint == nil {
-		return nil
+	if endpoint == nil || dryRun {
+		return endpoint
 	}
 	rr.roundRobin[ns.GetName()] = rr.roundRobin[ns.GetName()] + 1
 	logrus.Infof("RoundRobin selected %v", endpoint)
@@ -8,3 +8,24 @@ import (
 type Selector interface {
 	SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint
 }
+
+// DryRunSelector is implemented by selectors able to tell which endpoint they would select without updating their state
+type DryRunSelector interface {
+	DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint
+}
+
+// DryRunSelectEndpoint returns the endpoint selector would select, nil if selector doesn't support dry run
+func DryRunSelectEndpoint(selector Selector, requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if dryRunSelector, ok := selector.(DryRunSelector); ok {
+		return dryRunSelector.DryRunSelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+	}
+	return nil
+}
+
+// selectEndpoint selects the endpoint with selector, the selector state is not updated if dryRun is set
+func selectEndpoint(selector Selector, requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
+	if dryRun {
+		return DryRunSelectEndpoint(selector, requestConnection, ns, networkServiceEndpoints)
+	}
+	return selector.SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Built-in selection strategies a NetworkService can declare in its selection strategy
+const (
+	// RoundRobinStrategy selects matched endpoints in turn, route weights are ignored
+	RoundRobinStrategy = "round-robin"
+	// WeightedStrategy selects route groups in proportion to their weights and endpoints of a group in turn
+	WeightedStrategy = "weighted"
+	// LeastConnectionsStrategy selects the matched endpoint with the fewest active connections
+	LeastConnectionsStrategy = "least-connections"
+	// RandomStrategy selects a random matched endpoint
+	RandomStrategy = "random"
+	// AffinityStrategy maps request connection labels onto the matched endpoints with a consistent hash
+	AffinityStrategy = "affinity"
+)
+
+// StrategyContext holds the nsmd state selection strategies may depend on
+type StrategyContext struct {
+	// Connections provides numbers of active connections of endpoints
+	Connections ConnectionCounter
+	// Nsm provides the local network service manager
+	Nsm NsmProvider
+	// AffinityLabels are request connection labels used as the affinity key, DefaultAffinityLabels are used if empty
+	AffinityLabels []string
+}
+
+// StrategyFactory creates a selector implementing a selection strategy
+type StrategyFactory func(ctx *StrategyContext) (Selector, error)
+
+type strategyRegistry struct {
+	sync.RWMutex
+	factories map[string]StrategyFactory
+}
+
+var strategies = &strategyRegistry{
+	factories: map[string]StrategyFactory{
+		RoundRobinStrategy: func(*StrategyContext) (Selector, error) {
+			return newUnweightedMatchSelector(NewRoundRobinSelector()), nil
+		},
+		WeightedStrategy: func(*StrategyContext) (Selector, error) {
+			return NewMatchSelector(), nil
+		},
+		LeastConnectionsStrategy: func(ctx *StrategyContext) (Selector, error) {
+			if ctx.Connections == nil {
+				return nil, errors.New("least connections strategy requires a connection counter")
+			}
+			return NewLeastConnectionsSelector(ctx.Connections), nil
+		},
+		RandomStrategy: func(*StrategyContext) (Selector, error) {
+			return NewRandomSelector(), nil
+		},
+		AffinityStrategy: func(ctx *StrategyContext) (Selector, error) {
+			labels := ctx.AffinityLabels
+			if len(labels) == 0 {
+				labels = DefaultAffinityLabels()
+			}
+			return NewAffinitySelector(labels), nil
+		},
+	},
+}
+
+// RegisterStrategy registers a custom selection strategy, it is intended to be called from init functions
+// of the packages linked into nsmd.
+func RegisterStrategy(name string, factory StrategyFactory) error {
+	if name == "" || factory == nil {
+		return errors.New("selection strategy name and factory are required")
+	}
+
+	strategies.Lock()
+	defer strategies.Unlock()
+
+	if _, ok := strategies.factories[name]; ok {
+		return errors.Errorf("selection strategy %q is already registered", name)
+	}
+	strategies.factories[name] = factory
+	return nil
+}
+
+// unregisterStrategy removes the registered selection strategy
+func unregisterStrategy(name string) {
+	strategies.Lock()
+	defer strategies.Unlock()
+
+	delete(strategies.factories, name)
+}
+
+// ValidateStrategy checks the selection strategy declared by a NetworkService is registered, empty strategy is valid
+func ValidateStrategy(name string) error {
+	if name == "" {
+		return nil
+	}
+
+	strategies.RLock()
+	defer strategies.RUnlock()
+
+	if _, ok := strategies.factories[name]; !ok {
+		return errors.Errorf("selection strategy %q is not registered", name)
+	}
+	return nil
+}
+
+// NewStrategySelector creates a selector implementing the registered selection strategy
+func NewStrategySelector(name string, ctx *StrategyContext) (Selector, error) {
+	strategies.RLock()
+	factory, ok := strategies.factories[name]
+	strategies.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("selection strategy %q is not registered", name)
+	}
+
+	if ctx == nil {
+		ctx = &StrategyContext{}
+	}
+	selector, err := factory(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create selection strategy %q", name)
+	}
+	return selector, nil
+}
+
+// Strategies returns sorted names of the registered selection strategies
+func Strategies() []string {
+	strategies.RLock()
+	defer strategies.RUnlock()
+
+	names := make([]string, 0, len(strategies.factories))
+	for name := range strategies.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func Test_NewStrategySelector_BuiltIn(t *testing.T) {
+	ctx := &StrategyContext{
+		Connections: testConnectionCounter{},
+	}
+	for _, name := range []string{RoundRobinStrategy, WeightedStrategy, LeastConnectionsStrategy, RandomStrategy, AffinityStrategy} {
+		s, err := NewStrategySelector(name, ctx)
+		if err != nil {
+			t.Fatalf("NewStrategySelector(%v) error = %v", name, err)
+		}
+		if got := s.SelectEndpoint(&connection.Connection{}, canaryNetworkService(1, 1), canaryEndpoints()); got == nil {
+			t.Errorf("%v strategy selected nil", name)
+		}
+	}
+}
+
+func Test_NewStrategySelector_Errors(t *testing.T) {
+	if _, err := NewStrategySelector("unknown", nil); err == nil {
+		t.Errorf("NewStrategySelector() expected error for not registered strategy")
+	}
+	if _, err := NewStrategySelector(LeastConnectionsStrategy, &StrategyContext{}); err == nil {
+		t.Errorf("NewStrategySelector() expected error for least connections without connection counter")
+	}
+}
+
+func Test_ValidateStrategy(t *testing.T) {
+	for _, name := range []string{"", RoundRobinStrategy, AffinityStrategy} {
+		if err := ValidateStrategy(name); err != nil {
+			t.Errorf("ValidateStrategy(%q) error = %v", name, err)
+		}
+	}
+	if err := ValidateStrategy("unknown"); err == nil {
+		t.Errorf("ValidateStrategy() expected error for not registered strategy")
+	}
+}
+
+func Test_RegisterStrategy(t *testing.T) {
+	const name = "test-first-endpoint"
+	factory := func(*StrategyContext) (Selector, error) {
+		return &firstEndpointSelector{}, nil
+	}
+	if err := RegisterStrategy(name, factory); err != nil {
+		t.Fatalf("RegisterStrategy() error = %v", err)
+	}
+	defer unregisterStrategy(name)
+	if err := RegisterStrategy(name, factory); err == nil {
+		t.Errorf("RegisterStrategy() expected error for already registered strategy")
+	}
+	if err := RegisterStrategy("", factory); err == nil {
+		t.Errorf("RegisterStrategy() expected error for empty name")
+	}
+
+	found := false
+	for _, strategy := range Strategies() {
+		found = found || strategy == name
+	}
+	if !found {
+		t.Errorf("Strategies() = %v, want to contain %v", Strategies(), name)
+	}
+
+	s := NewNetworkServiceSelector(NewMatchSelector())
+	ns := &registry.NetworkService{
+		Name:              "custom-ns",
+		SelectionStrategy: name,
+	}
+	endpoints := canaryEndpoints()
+	for i := 0; i < 3; i++ {
+		if got := s.SelectEndpoint(&connection.Connection{}, ns, endpoints); got != endpoints[0] {
+			t.Errorf("networkServiceSelector.SelectEndpoint() = %v, want %v", got.GetName(), endpoints[0].GetName())
+		}
+	}
+}
+
+func Test_networkServiceSelector_SelectionStrategy(t *testing.T) {
+	s := NewNetworkServiceSelector(NewMatchSelector())
+	s.SetNetworkServiceSelector("ns", NewLeastConnectionsSelector(testConnectionCounter{}))
+
+	if _, ok := s.SelectorFor(&registry.NetworkService{Name: "ns", SelectionStrategy: AffinityStrategy}).(AffinitySelector); !ok {
+		t.Errorf("SelectorFor() should return affinity selector declared by network service")
+	}
+	if got, want := s.SelectorFor(&registry.NetworkService{Name: "ns", SelectionStrategy: "unknown"}), s.GetNetworkServiceSelector("ns"); got != want {
+		t.Errorf("SelectorFor() should fall back to the configured selector for unknown strategy")
+	}
+
+	// Weights are ignored by round robin strategy
+	ns := canaryNetworkService(1, 0)
+	ns.SelectionStrategy = RoundRobinStrategy
+	endpoints := canaryEndpoints()
+	for i := 0; i < 6; i++ {
+		want := endpoints[i%len(endpoints)]
+		if got := s.SelectEndpoint(&connection.Connection{}, ns, endpoints); got != want {
+			t.Errorf("networkServiceSelector.SelectEndpoint() = %v, want %v", got.GetName(), want.GetName())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// NsmProvider provides the local network service manager
+type NsmProvider interface {
+	GetNsm() *registry.NetworkServiceManager
+}
+
+// TopologyLabel is a label describing location of the local node, e.g. its zone or region
+type TopologyLabel struct {
+	Key   string
+	Value string
+}
+
+type topologySelector struct {
+	nsm              NsmProvider
+	topology         []TopologyLabel
+	endpointSelector Selector
+}
+
+// NewTopologySelector creates a new selector matching endpoints against NetworkService matches and preferring the closest ones:
+// endpoints registered on the local NSM first, then endpoints sharing topology labels with the local node in the given order
+// and all the other endpoints only if there are no closer candidates. Candidates of the same tier are chosen with round robin.
+func NewTopologySelector(nsm NsmProvider, topology []TopologyLabel) Selector {
+	return newMatchSelector(&topologySelector{
+		nsm:              nsm,
+		topology:         topology,
+		endpointSelector: NewRoundRobinSelector(),
+	})
+}
+
+func (ts *topologySelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return ts.selectEndpoint(requestConnection, ns, networkServiceEndpoints, false)
+}
+
+func (ts *topologySelector) DryRunSelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return ts.selectEndpoint(requestConnection, ns, networkServiceEndpoints, true)
+}
+
+func (ts *topologySelector) selectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint, dryRun bool) *registry.NetworkServiceEndpoint {
+	if len(networkServiceEndpoints) == 0 {
+		return nil
+	}
+
+	nsmName := ts.nsm.GetNsm().GetName()
+	if candidates := filterEndpoints(networkServiceEndpoints, func(nse *registry.NetworkServiceEndpoint) bool {
+		return nsmName != "" && nse.GetNetworkServiceManagerName() == nsmName
+	}); len(candidates) > 0 {
+		logrus.Infof("Topology selector: using %d local endpoints", len(candidates))
+		return selectEndpoint(ts.endpointSelector, requestConnection, ns, candidates, dryRun)
+	}
+
+	for _, label := range ts.topology {
+		if candidates := filterEndpoints(networkServiceEndpoints, func(nse *registry.NetworkServiceEndpoint) bool {
+			return label.Value != "" && nse.GetLabels()[label.Key] == label.Value
+		}); len(candidates) > 0 {
+			logrus.Infof("Topology selector: using %d endpoints with %s=%s", len(candidates), label.Key, label.Value)
+			return selectEndpoint(ts.endpointSelector, requestConnection, ns, candidates, dryRun)
+		}
+	}
+
+	logrus.Infof("Topology selector: no close endpoints, using all %d endpoints", len(networkServiceEndpoints))
+	return selectEndpoint(ts.endpointSelector, requestConnection, ns, networkServiceEndpoints, dryRun)
+}
+
+func filterEndpoints(endpoints []*registry.NetworkServiceEndpoint, predicate func(*registry.NetworkServiceEndpoint) bool) []*registry.NetworkServiceEndpoint {
+	var result []*registry.NetworkServiceEndpoint
+	for _, endpoint := range endpoints {
+		if predicate(endpoint) {
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type testNsmProvider string
+
+func (p testNsmProvider) GetNsm() *registry.NetworkServiceManager {
+	return &registry.NetworkServiceManager{
+		Name: string(p),
+	}
+}
+
+func Test_topologySelector_SelectEndpoint(t *testing.T) {
+	topology := []TopologyLabel{
+		{Key: "zone", Value: "zone-a"},
+		{Key: "region", Value: "region-1"},
+	}
+	local := &registry.NetworkServiceEndpoint{
+		Name:                      "local",
+		NetworkServiceManagerName: "nsm-1",
+		Labels:                    map[string]string{"zone": "zone-a", "region": "region-1"},
+	}
+	sameZone := &registry.NetworkServiceEndpoint{
+		Name:                      "same-zone",
+		NetworkServiceManagerName: "nsm-2",
+		Labels:                    map[string]string{"zone": "zone-a", "region": "region-1"},
+	}
+	sameRegion := &registry.NetworkServiceEndpoint{
+		Name:                      "same-region",
+		NetworkServiceManagerName: "nsm-3",
+		Labels:                    map[string]string{"zone": "zone-b", "region": "region-1"},
+	}
+	remote := &registry.NetworkServiceEndpoint{
+		Name:                      "remote",
+		NetworkServiceManagerName: "nsm-4",
+		Labels:                    map[string]string{"zone": "zone-c", "region": "region-2"},
+	}
+
+	tests := []struct {
+		name      string
+		endpoints []*registry.NetworkServiceEndpoint
+		want      string
+	}{
+		{
+			name:      "local",
+			endpoints: []*registry.NetworkServiceEndpoint{remote, sameRegion, sameZone, local},
+			want:      "local",
+		},
+		{
+			name:      "same zone",
+			endpoints: []*registry.NetworkServiceEndpoint{remote, sameRegion, sameZone},
+			want:      "same-zone",
+		},
+		{
+			name:      "same region",
+			endpoints: []*registry.NetworkServiceEndpoint{remote, sameRegion},
+			want:      "same-region",
+		},
+		{
+			name:      "remote",
+			endpoints: []*registry.NetworkServiceEndpoint{remote},
+			want:      "remote",
+		},
+		{
+			name: "no endpoints",
+		},
+	}
+
+	ts := NewTopologySelector(testNsmProvider("nsm-1"), topology)
+	ns := &registry.NetworkService{
+		Name: "network-service-1",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ts.SelectEndpoint(&connection.Connection{}, ns, tt.endpoints); got.GetName() != tt.want {
+				t.Errorf("topologySelector.SelectEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_topologySelector_Matches(t *testing.T) {
+	ns := &registry.NetworkService{
+		Name: "network-service-1",
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector: map[string]string{"app": "firewall"},
+					},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{
+			Name:                      "local-vpn",
+			NetworkServiceManagerName: "nsm-1",
+			Labels:                    map[string]string{"app": "vpn"},
+		},
+		{
+			Name:                      "remote-firewall",
+			NetworkServiceManagerName: "nsm-2",
+			Labels:                    map[string]string{"app": "firewall"},
+		},
+	}
+
+	ts := NewTopologySelector(testNsmProvider("nsm-1"), nil)
+	if got := ts.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != "remote-firewall" {
+		t.Errorf("topologySelector.SelectEndpoint() = %v, want remote-firewall", got)
+	}
+}
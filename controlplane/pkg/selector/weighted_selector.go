@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// weightedRoute is a group of endpoints matched by a single Destination of a NetworkService match.
+type weightedRoute struct {
+	destination *registry.Destination
+	endpoints   []*registry.NetworkServiceEndpoint
+}
+
+// weight returns the route weight, routes without endpoints have no weight
+func (r *weightedRoute) weight() int64 {
+	if len(r.endpoints) == 0 {
+		return 0
+	}
+	return int64(r.destination.GetWeight())
+}
+
+// weightedSelector distributes selections across route groups in proportion to their Destination.Weight.
+// It uses smooth weighted round robin, so the sequence of picks is deterministic for a given set of weights.
+// Routes are identified by their position in the match, so destinations with identical selectors are weighted separately.
+type weightedSelector struct {
+	sync.Mutex
+	currentWeights map[string][]int64
+}
+
+func newWeightedSelector() *weightedSelector {
+	return &weightedSelector{
+		currentWeights: make(map[string][]int64),
+	}
+}
+
+// selectRoute returns the index of one of routes with non zero weight and at least one endpoint, or -1 if there are no such routes.
+// Routes with zero weight never take part in the weighted selection.
+// The current weights are kept unchanged if dryRun is set.
+func (ws *weightedSelector) selectRoute(key string, routes []*weightedRoute, dryRun bool) int {
+	if ws == nil {
+		return -1
+	}
+	ws.Lock()
+	defer ws.Unlock()
+
+	current := ws.currentWeights[key]
+	if len(current) != len(routes) {
+		current = make([]int64, len(routes))
+	}
+
+	selected := -1
+	total, best := int64(0), int64(0)
+	for i, route := range routes {
+		weight := route.weight()
+		if weight == 0 {
+			continue
+		}
+		total += weight
+		if selected < 0 || current[i]+weight > best {
+			selected, best = i, current[i]+weight
+		}
+	}
+	if selected < 0 || dryRun {
+		return selected
+	}
+
+	for i, route := range routes {
+		if route.weight() == 0 && len(route.endpoints) > 0 {
+			logrus.Infof("Route %v has zero weight, its endpoints are skipped while weighted routes are available", route.destination)
+		}
+		current[i] += route.weight()
+	}
+	current[selected] -= total
+	ws.currentWeights[key] = current
+	return selected
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func canaryNetworkService(v1Weight, v2Weight uint32) *registry.NetworkService {
+	return &registry.NetworkService{
+		Name: "canary-ns",
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector: map[string]string{
+							"version": "v1",
+						},
+						Weight: v1Weight,
+					},
+					{
+						DestinationSelector: map[string]string{
+							"version": "v2",
+						},
+						Weight: v2Weight,
+					},
+				},
+			},
+		},
+	}
+}
+
+func canaryEndpoints() []*registry.NetworkServiceEndpoint {
+	return []*registry.NetworkServiceEndpoint{
+		{
+			Name:   "nse-v1-1",
+			Labels: map[string]string{"version": "v1"},
+		},
+		{
+			Name:   "nse-v1-2",
+			Labels: map[string]string{"version": "v1"},
+		},
+		{
+			Name:   "nse-v2-1",
+			Labels: map[string]string{"version": "v2"},
+		},
+	}
+}
+
+func Test_matchSelector_WeightedRoutes(t *testing.T) {
+	m := NewMatchSelector()
+	ns := canaryNetworkService(90, 10)
+	endpoints := canaryEndpoints()
+
+	selected := map[string]int{}
+	for i := 0; i < 100; i++ {
+		nse := m.SelectEndpoint(&connection.Connection{}, ns, endpoints)
+		if nse == nil {
+			t.Fatalf("matchSelector.SelectEndpoint() = nil on pass %v", i)
+		}
+		selected[nse.GetName()]++
+	}
+
+	want := map[string]int{
+		"nse-v1-1": 45,
+		"nse-v1-2": 45,
+		"nse-v2-1": 10,
+	}
+	for name, count := range want {
+		if selected[name] != count {
+			t.Errorf("%v selected %v times, want %v", name, selected[name], count)
+		}
+	}
+}
+
+func Test_matchSelector_WeightedRouteWithoutEndpoints(t *testing.T) {
+	m := NewMatchSelector()
+	ns := canaryNetworkService(90, 10)
+	endpoints := canaryEndpoints()[2:]
+
+	for i := 0; i < 10; i++ {
+		if got := m.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != "nse-v2-1" {
+			t.Errorf("matchSelector.SelectEndpoint() = %v, want nse-v2-1", got)
+		}
+	}
+}
+
+func Test_matchSelector_ZeroWeights(t *testing.T) {
+	m := NewMatchSelector()
+	ns := canaryNetworkService(0, 0)
+	endpoints := canaryEndpoints()
+
+	for i := 0; i < 6; i++ {
+		want := endpoints[i%len(endpoints)].GetName()
+		if got := m.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetName() != want {
+			t.Errorf("matchSelector.SelectEndpoint() = %v, want %v", got, want)
+		}
+	}
+}
+
+func Test_matchSelector_ZeroWeightRoute(t *testing.T) {
+	m := NewMatchSelector()
+	ns := canaryNetworkService(1, 0)
+	endpoints := canaryEndpoints()
+
+	for i := 0; i < 4; i++ {
+		if got := m.SelectEndpoint(&connection.Connection{}, ns, endpoints); got.GetLabels()["version"] != "v1" {
+			t.Errorf("matchSelector.SelectEndpoint() = %v, want version=v1 endpoint", got)
+		}
+	}
+	// Zero weight route is the fallback when weighted routes have no endpoints
+	if got := m.SelectEndpoint(&connection.Connection{}, ns, endpoints[2:]); got.GetName() != "nse-v2-1" {
+		t.Errorf("matchSelector.SelectEndpoint() = %v, want nse-v2-1", got)
+	}
+}
+
+func Test_weightedSelector_selectRoute(t *testing.T) {
+	ws := newWeightedSelector()
+	routes := []*weightedRoute{
+		{
+			destination: &registry.Destination{DestinationSelector: map[string]string{"app": "a"}, Weight: 3},
+			endpoints:   []*registry.NetworkServiceEndpoint{{Name: "a"}},
+		},
+		{
+			destination: &registry.Destination{DestinationSelector: map[string]string{"app": "b"}, Weight: 1},
+			endpoints:   []*registry.NetworkServiceEndpoint{{Name: "b"}},
+		},
+	}
+
+	want := []string{"a", "a", "b", "a", "a", "a", "b", "a"}
+	for i, name := range want {
+		if got := routes[ws.selectRoute("ns", routes, false)].endpoints[0].GetName(); got != name {
+			t.Errorf("weightedSelector.selectRoute() pass %v = %v, want %v", i, got, name)
+		}
+	}
+}
+
+func Test_weightedSelector_selectRouteIdenticalSelectors(t *testing.T) {
+	ws := newWeightedSelector()
+	routes := []*weightedRoute{
+		{
+			destination: &registry.Destination{DestinationSelector: map[string]string{"app": "a"}, Weight: 1},
+			endpoints:   []*registry.NetworkServiceEndpoint{{Name: "a"}},
+		},
+		{
+			destination: &registry.Destination{DestinationSelector: map[string]string{"app": "a"}, Weight: 1},
+			endpoints:   []*registry.NetworkServiceEndpoint{{Name: "b"}},
+		},
+	}
+
+	want := []string{"a", "b", "a", "b"}
+	for i, name := range want {
+		if got := routes[ws.selectRoute("ns", routes, false)].endpoints[0].GetName(); got != name {
+			t.Errorf("weightedSelector.selectRoute() pass %v = %v, want %v", i, got, name)
+		}
+	}
+}
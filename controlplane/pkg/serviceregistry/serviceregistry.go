@@ -57,4 +57,7 @@ type WorkspaceLocationProvider interface {
 
 	// A persistent file based NSE <-> Workspace registry.
 	NsmNSERegistryFile() string
+
+	// A persistent file based snapshot of client connections.
+	NsmConnectionSnapshotFile() string
 }
@@ -8,6 +8,7 @@ import (
 	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 
 	"github.com/pkg/errors"
 
@@ -167,6 +168,12 @@ func (m *ClientConnectionManager) RemoteDestinationUpdated(ctx context.Context,
 	}
 
 	if remoteDst.State == connection.State_UP {
+		if wireguardKeyRotated(cc.GetConnectionDestination(), remoteDst) {
+			// Remote forwarder rotated Wireguard key, so the connection is renegotiated with it.
+			logger.Infof("Wireguard key of remote forwarder is rotated... %v", cc.GetID())
+			m.manager.RenegotiateWireguardKeys(ctx, cc)
+			return
+		}
 		logger.Infof("State is already UP do not send")
 		// TODO: in order to update connection parameters we need to update model here
 		// We do not need to heal in case DST state is UP, remote NSM will try to recover and only when will send Update, Delete of connection.
@@ -205,9 +212,19 @@ func (m *ClientConnectionManager) destinationUpdated(ctx context.Context, cc nsm
 	m.manager.Heal(ctx, cc, nsm.HealStateDstUpdate)
 }
 
+// wireguardKeyRotated - checks if the remote side of Wireguard connection dst advertises a key other than the one
+// the connection is established with
+func wireguardKeyRotated(dst, remoteDst *connection.Connection) bool {
+	if dst.GetMechanism().GetType() != wireguard.MECHANISM || remoteDst.GetMechanism().GetType() != wireguard.MECHANISM {
+		return false
+	}
+	publicKey := remoteDst.GetMechanism().GetParameters()[wireguard.DstPublicKey]
+	return publicKey != "" && publicKey != dst.GetMechanism().GetParameters()[wireguard.DstPublicKey]
+}
+
 // GetClientConnectionByXcon - Since cross connect is ours, we could always use local connection id to identify client connection.
 func (m *ClientConnectionManager) GetClientConnectionByXcon(xcon *crossconnect.CrossConnect) *model.ClientConnection {
-	id := xcon.GetId()
+	id := model.XconConnectionID(xcon.GetId())
 	result := m.model.GetClientConnection(id)
 	if result != nil {
 		return result
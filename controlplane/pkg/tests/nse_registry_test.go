@@ -1,10 +1,14 @@
 package tests
 
 import (
+	"encoding/base64"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+
 	. "github.com/onsi/gomega"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
@@ -71,6 +75,101 @@ func TestNSEDeleteClientTest(t *testing.T) {
 	}}))
 }
 
+func TestNSERegistryLegacyMigration(t *testing.T) {
+	g := NewWithT(t)
+	fileName, err := tmpFile()
+	g.Expect(err).To(BeNil())
+	defer os.Remove(fileName)
+
+	bytes, err := proto.Marshal(createNSEReg("endpoint1"))
+	g.Expect(err).To(BeNil())
+	legacy := "CLE\tnsm-1\n" +
+		"CLE\tnsm-2\n" +
+		"NSE\tendpoint1\tnsm-1\t" + base64.StdEncoding.EncodeToString(bytes) + "\n"
+	g.Expect(ioutil.WriteFile(fileName, []byte(legacy), 0600)).To(Succeed())
+
+	reg := nseregistry.NewNSERegistry(fileName)
+	clients, nses, err := reg.LoadRegistry()
+	g.Expect(err).To(BeNil())
+	g.Expect(clients).To(Equal([]string{"nsm-1", "nsm-2"}))
+	g.Expect(nses).To(Equal(map[string]nseregistry.NSEEntry{"endpoint1": createEntry("nsm-1", "endpoint1")}))
+
+	// File is migrated to the journal format
+	content, err := ioutil.ReadFile(fileName)
+	g.Expect(err).To(BeNil())
+	g.Expect(strings.HasPrefix(string(content), "NSEREGISTRY\t2\n")).To(BeTrue())
+
+	g.Expect(reg.AppendClientRequest("nsm-3")).To(Succeed())
+	clients, _, err = nseregistry.NewNSERegistry(fileName).LoadRegistry()
+	g.Expect(err).To(BeNil())
+	g.Expect(clients).To(Equal([]string{"nsm-1", "nsm-2", "nsm-3"}))
+}
+
+func TestNSERegistryTornRecords(t *testing.T) {
+	g := NewWithT(t)
+	fileName, err := tmpFile()
+	g.Expect(err).To(BeNil())
+	defer os.Remove(fileName)
+
+	g.Expect(addValues(nseregistry.NewNSERegistry(fileName))).To(Succeed())
+
+	content, err := ioutil.ReadFile(fileName)
+	g.Expect(err).To(BeNil())
+	lines := strings.SplitAfter(string(content), "\n")
+	// Corrupt "nsm-2" client record and tear the last "endpoint2" one
+	lines[2] = strings.Replace(lines[2], "nsm-2", "nsm-X", 1)
+	lines[5] = lines[5][:len(lines[5])/2]
+	g.Expect(ioutil.WriteFile(fileName, []byte(strings.Join(lines, "")), 0600)).To(Succeed())
+
+	reg := nseregistry.NewNSERegistry(fileName)
+	clients, nses, err := reg.LoadRegistry()
+	g.Expect(err).To(BeNil())
+	g.Expect(clients).To(Equal([]string{"nsm-1", "nsm-3"}))
+	g.Expect(nses).To(Equal(map[string]nseregistry.NSEEntry{"endpoint1": createEntry("nsm-1", "endpoint1")}))
+
+	// Torn record is dropped, so appended records are readable
+	g.Expect(reg.AppendNSERegRequest("nsm-3", createNSEReg("endpoint3"))).To(Succeed())
+	_, nses, err = nseregistry.NewNSERegistry(fileName).LoadRegistry()
+	g.Expect(err).To(BeNil())
+	g.Expect(nses).To(HaveKey("endpoint3"))
+}
+
+func TestNSERegistryUnsupportedVersion(t *testing.T) {
+	g := NewWithT(t)
+	fileName, err := tmpFile()
+	g.Expect(err).To(BeNil())
+	defer os.Remove(fileName)
+
+	g.Expect(ioutil.WriteFile(fileName, []byte("NSEREGISTRY\t100\n"), 0600)).To(Succeed())
+	reg := nseregistry.NewNSERegistry(fileName)
+	_, _, err = reg.LoadRegistry()
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(reg.AppendClientRequest("nsm-1")).NotTo(Succeed())
+}
+
+func TestNSERegistryCompaction(t *testing.T) {
+	g := NewWithT(t)
+	fileName, err := tmpFile()
+	g.Expect(err).To(BeNil())
+	defer os.Remove(fileName)
+	reg := nseregistry.NewNSERegistry(fileName)
+
+	for i := 0; i < 600; i++ {
+		g.Expect(reg.AppendClientRequest("nsm-1")).To(Succeed())
+		g.Expect(reg.DeleteClient("nsm-1")).To(Succeed())
+	}
+	g.Expect(reg.AppendClientRequest("nsm-2")).To(Succeed())
+
+	content, err := ioutil.ReadFile(fileName)
+	g.Expect(err).To(BeNil())
+	g.Expect(strings.Count(string(content), "\n")).To(BeNumerically("<", 1000))
+
+	clients, nses, err := reg.LoadRegistry()
+	g.Expect(err).To(BeNil())
+	g.Expect(clients).To(Equal([]string{"nsm-2"}))
+	g.Expect(nses).To(BeEmpty())
+}
+
 func addValues(reg *nseregistry.NSERegistry) error {
 	err := reg.AppendClientRequest("nsm-1")
 	if err != nil {
@@ -7,7 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	empty "github.com/golang/protobuf/ptypes/empty"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -29,11 +29,22 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 // to advertise itself and inform NSM about the location of the forwarder socket
 // and its initially supported parameters.
 type ForwarderRegistrationRequest struct {
-	ForwarderName        string   `protobuf:"bytes,1,opt,name=forwarder_name,json=forwarderName,proto3" json:"forwarder_name,omitempty"`
-	ForwarderSocket      string   `protobuf:"bytes,2,opt,name=forwarder_socket,json=forwarderSocket,proto3" json:"forwarder_socket,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ForwarderName   string `protobuf:"bytes,1,opt,name=forwarder_name,json=forwarderName,proto3" json:"forwarder_name,omitempty"`
+	ForwarderSocket string `protobuf:"bytes,2,opt,name=forwarder_socket,json=forwarderSocket,proto3" json:"forwarder_socket,omitempty"`
+	// implementation is a type of the forwarder, e.g. "vppagent" or "kernel"
+	Implementation string `protobuf:"bytes,3,opt,name=implementation,proto3" json:"implementation,omitempty"`
+	// version is a version of the forwarder implementation
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// protocol_version is a version of the registrar protocol, forwarders not sending it are of version 1
+	ProtocolVersion uint32 `protobuf:"varint,5,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// mechanisms are mechanism types supported by the forwarder along with their parameters
+	Mechanisms []*MechanismCapability `protobuf:"bytes,6,rep,name=mechanisms,proto3" json:"mechanisms,omitempty"`
+	// max_cross_connects is a maximum amount of cross connects programmed by the forwarder, 0 means unlimited
+	MaxCrossConnects     uint32            `protobuf:"varint,7,opt,name=max_cross_connects,json=maxCrossConnects,proto3" json:"max_cross_connects,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ForwarderRegistrationRequest) Reset()         { *m = ForwarderRegistrationRequest{} }
@@ -75,8 +86,172 @@ func (m *ForwarderRegistrationRequest) GetForwarderSocket() string {
 	return ""
 }
 
+func (m *ForwarderRegistrationRequest) GetImplementation() string {
+	if m != nil {
+		return m.Implementation
+	}
+	return ""
+}
+
+func (m *ForwarderRegistrationRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ForwarderRegistrationRequest) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ForwarderRegistrationRequest) GetMechanisms() []*MechanismCapability {
+	if m != nil {
+		return m.Mechanisms
+	}
+	return nil
+}
+
+func (m *ForwarderRegistrationRequest) GetMaxCrossConnects() uint32 {
+	if m != nil {
+		return m.MaxCrossConnects
+	}
+	return 0
+}
+
+func (m *ForwarderRegistrationRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+// MechanismCapability describes a mechanism type supported by the forwarder.
+type MechanismCapability struct {
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// remote is set for mechanisms connecting to the forwarder of another NSM
+	Remote               bool                  `protobuf:"varint,2,opt,name=remote,proto3" json:"remote,omitempty"`
+	Parameters           []*MechanismParameter `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MechanismCapability) Reset()         { *m = MechanismCapability{} }
+func (m *MechanismCapability) String() string { return proto.CompactTextString(m) }
+func (*MechanismCapability) ProtoMessage()    {}
+func (*MechanismCapability) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf2c0f4975ef21fe, []int{1}
+}
+
+func (m *MechanismCapability) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MechanismCapability.Unmarshal(m, b)
+}
+func (m *MechanismCapability) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MechanismCapability.Marshal(b, m, deterministic)
+}
+func (m *MechanismCapability) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MechanismCapability.Merge(m, src)
+}
+func (m *MechanismCapability) XXX_Size() int {
+	return xxx_messageInfo_MechanismCapability.Size(m)
+}
+func (m *MechanismCapability) XXX_DiscardUnknown() {
+	xxx_messageInfo_MechanismCapability.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MechanismCapability proto.InternalMessageInfo
+
+func (m *MechanismCapability) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *MechanismCapability) GetRemote() bool {
+	if m != nil {
+		return m.Remote
+	}
+	return false
+}
+
+func (m *MechanismCapability) GetParameters() []*MechanismParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+// MechanismParameter describes a parameter of the mechanism.
+type MechanismParameter struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type is a type of the parameter value, e.g. "string", "ip" or "uint32"
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Required             bool     `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MechanismParameter) Reset()         { *m = MechanismParameter{} }
+func (m *MechanismParameter) String() string { return proto.CompactTextString(m) }
+func (*MechanismParameter) ProtoMessage()    {}
+func (*MechanismParameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf2c0f4975ef21fe, []int{2}
+}
+
+func (m *MechanismParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MechanismParameter.Unmarshal(m, b)
+}
+func (m *MechanismParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MechanismParameter.Marshal(b, m, deterministic)
+}
+func (m *MechanismParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MechanismParameter.Merge(m, src)
+}
+func (m *MechanismParameter) XXX_Size() int {
+	return xxx_messageInfo_MechanismParameter.Size(m)
+}
+func (m *MechanismParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_MechanismParameter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MechanismParameter proto.InternalMessageInfo
+
+func (m *MechanismParameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MechanismParameter) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *MechanismParameter) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
 type ForwarderRegistrationReply struct {
-	Registered           bool     `protobuf:"varint,1,opt,name=registered,proto3" json:"registered,omitempty"`
+	Registered bool `protobuf:"varint,1,opt,name=registered,proto3" json:"registered,omitempty"`
+	// protocol_version is a version of the registrar protocol used by NSM
+	ProtocolVersion uint32 `protobuf:"varint,2,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// wireguard_key_rotation_interval is an interval the forwarder rotates its Wireguard key at, not set if
+	// the rotation is disabled
+	WireguardKeyRotationInterval *duration.Duration `protobuf:"bytes,3,opt,name=wireguard_key_rotation_interval,json=wireguardKeyRotationInterval,proto3" json:"wireguard_key_rotation_interval,omitempty"`
+	// liveness_token is issued by NSM to the registered forwarder, the forwarder sends it in the liveness stream
+	// metadata, so the stream is bound to the registration
+	LivenessToken        string   `protobuf:"bytes,4,opt,name=liveness_token,json=livenessToken,proto3" json:"liveness_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -86,7 +261,7 @@ func (m *ForwarderRegistrationReply) Reset()         { *m = ForwarderRegistratio
 func (m *ForwarderRegistrationReply) String() string { return proto.CompactTextString(m) }
 func (*ForwarderRegistrationReply) ProtoMessage()    {}
 func (*ForwarderRegistrationReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bf2c0f4975ef21fe, []int{1}
+	return fileDescriptor_bf2c0f4975ef21fe, []int{3}
 }
 
 func (m *ForwarderRegistrationReply) XXX_Unmarshal(b []byte) error {
@@ -114,10 +289,105 @@ func (m *ForwarderRegistrationReply) GetRegistered() bool {
 	return false
 }
 
+func (m *ForwarderRegistrationReply) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ForwarderRegistrationReply) GetWireguardKeyRotationInterval() *duration.Duration {
+	if m != nil {
+		return m.WireguardKeyRotationInterval
+	}
+	return nil
+}
+
+func (m *ForwarderRegistrationReply) GetLivenessToken() string {
+	if m != nil {
+		return m.LivenessToken
+	}
+	return ""
+}
+
+// Heartbeat is periodically sent by both NSM and the forwarder on the liveness stream.
+// Peers of protocol version 1 send and receive empty messages, so all the fields are optional.
+type Heartbeat struct {
+	// forwarder_name is a name of the forwarder the liveness stream belongs to, informational only: NSM binds
+	// the stream to the forwarder by the liveness token
+	ForwarderName string `protobuf:"bytes,1,opt,name=forwarder_name,json=forwarderName,proto3" json:"forwarder_name,omitempty"`
+	// sequence is incremented by the sender with each heartbeat, starting from 1
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// ack is a sequence of the last heartbeat received from the peer
+	Ack uint64 `protobuf:"varint,3,opt,name=ack,proto3" json:"ack,omitempty"`
+	// interval is a time between heartbeats of the sender, the peer considers the sender
+	// hung after missing configured amount of heartbeats in a row
+	Interval             *duration.Duration `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Heartbeat) Reset()         { *m = Heartbeat{} }
+func (m *Heartbeat) String() string { return proto.CompactTextString(m) }
+func (*Heartbeat) ProtoMessage()    {}
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf2c0f4975ef21fe, []int{4}
+}
+
+func (m *Heartbeat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Heartbeat.Unmarshal(m, b)
+}
+func (m *Heartbeat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Heartbeat.Marshal(b, m, deterministic)
+}
+func (m *Heartbeat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heartbeat.Merge(m, src)
+}
+func (m *Heartbeat) XXX_Size() int {
+	return xxx_messageInfo_Heartbeat.Size(m)
+}
+func (m *Heartbeat) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heartbeat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heartbeat proto.InternalMessageInfo
+
+func (m *Heartbeat) GetForwarderName() string {
+	if m != nil {
+		return m.ForwarderName
+	}
+	return ""
+}
+
+func (m *Heartbeat) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Heartbeat) GetAck() uint64 {
+	if m != nil {
+		return m.Ack
+	}
+	return 0
+}
+
+func (m *Heartbeat) GetInterval() *duration.Duration {
+	if m != nil {
+		return m.Interval
+	}
+	return nil
+}
+
 // ForwarderUnRegistrationRequest is sent by the forwarder to NSM
 // to remove itself from the list of available forwarders.
+// If drain is set, NSM stops placing new connections on the forwarder and migrates
+// its connections to other forwarders before removing it.
 type ForwarderUnRegistrationRequest struct {
 	ForwarderName        string   `protobuf:"bytes,1,opt,name=forwarder_name,json=forwarderName,proto3" json:"forwarder_name,omitempty"`
+	Drain                bool     `protobuf:"varint,2,opt,name=drain,proto3" json:"drain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -127,7 +397,7 @@ func (m *ForwarderUnRegistrationRequest) Reset()         { *m = ForwarderUnRegis
 func (m *ForwarderUnRegistrationRequest) String() string { return proto.CompactTextString(m) }
 func (*ForwarderUnRegistrationRequest) ProtoMessage()    {}
 func (*ForwarderUnRegistrationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bf2c0f4975ef21fe, []int{2}
+	return fileDescriptor_bf2c0f4975ef21fe, []int{5}
 }
 
 func (m *ForwarderUnRegistrationRequest) XXX_Unmarshal(b []byte) error {
@@ -155,8 +425,17 @@ func (m *ForwarderUnRegistrationRequest) GetForwarderName() string {
 	return ""
 }
 
+func (m *ForwarderUnRegistrationRequest) GetDrain() bool {
+	if m != nil {
+		return m.Drain
+	}
+	return false
+}
+
 type ForwarderUnRegistrationReply struct {
-	UnRegistered         bool     `protobuf:"varint,1,opt,name=un_registered,json=unRegistered,proto3" json:"un_registered,omitempty"`
+	UnRegistered bool `protobuf:"varint,1,opt,name=un_registered,json=unRegistered,proto3" json:"un_registered,omitempty"`
+	// drained is set if all connections were migrated from the forwarder
+	Drained              bool     `protobuf:"varint,2,opt,name=drained,proto3" json:"drained,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -166,7 +445,7 @@ func (m *ForwarderUnRegistrationReply) Reset()         { *m = ForwarderUnRegistr
 func (m *ForwarderUnRegistrationReply) String() string { return proto.CompactTextString(m) }
 func (*ForwarderUnRegistrationReply) ProtoMessage()    {}
 func (*ForwarderUnRegistrationReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bf2c0f4975ef21fe, []int{3}
+	return fileDescriptor_bf2c0f4975ef21fe, []int{6}
 }
 
 func (m *ForwarderUnRegistrationReply) XXX_Unmarshal(b []byte) error {
@@ -194,9 +473,20 @@ func (m *ForwarderUnRegistrationReply) GetUnRegistered() bool {
 	return false
 }
 
+func (m *ForwarderUnRegistrationReply) GetDrained() bool {
+	if m != nil {
+		return m.Drained
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ForwarderRegistrationRequest)(nil), "forwarderregistrar.ForwarderRegistrationRequest")
+	proto.RegisterMapType((map[string]string)(nil), "forwarderregistrar.ForwarderRegistrationRequest.LabelsEntry")
+	proto.RegisterType((*MechanismCapability)(nil), "forwarderregistrar.MechanismCapability")
+	proto.RegisterType((*MechanismParameter)(nil), "forwarderregistrar.MechanismParameter")
 	proto.RegisterType((*ForwarderRegistrationReply)(nil), "forwarderregistrar.ForwarderRegistrationReply")
+	proto.RegisterType((*Heartbeat)(nil), "forwarderregistrar.Heartbeat")
 	proto.RegisterType((*ForwarderUnRegistrationRequest)(nil), "forwarderregistrar.ForwarderUnRegistrationRequest")
 	proto.RegisterType((*ForwarderUnRegistrationReply)(nil), "forwarderregistrar.ForwarderUnRegistrationReply")
 }
@@ -204,26 +494,50 @@ func init() {
 func init() { proto.RegisterFile("forwarderregistrar.proto", fileDescriptor_bf2c0f4975ef21fe) }
 
 var fileDescriptor_bf2c0f4975ef21fe = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xcf, 0x4a, 0xc3, 0x40,
-	0x18, 0xc4, 0x59, 0x0f, 0xa2, 0x1f, 0xd6, 0xca, 0x82, 0x1a, 0x42, 0x09, 0x12, 0x11, 0xea, 0x65,
-	0x0d, 0xf1, 0xea, 0x4d, 0xaa, 0x17, 0xf1, 0x10, 0xf1, 0x5c, 0x52, 0xfb, 0x35, 0x04, 0x93, 0xdd,
-	0x75, 0x77, 0xa3, 0xe4, 0xe6, 0xc9, 0x07, 0xf1, 0xcd, 0x7c, 0x13, 0xc9, 0x1f, 0x63, 0x49, 0x93,
-	0x42, 0x7b, 0xc9, 0x61, 0xf2, 0x9b, 0xdd, 0x99, 0x4c, 0xc0, 0x5a, 0x08, 0xf5, 0x11, 0xaa, 0x39,
-	0x2a, 0x85, 0x51, 0xac, 0x8d, 0x0a, 0x15, 0x93, 0x4a, 0x18, 0x41, 0xe9, 0xea, 0x1b, 0xdb, 0x92,
-	0x26, 0x97, 0xa8, 0xaf, 0x30, 0x95, 0x26, 0xaf, 0x9e, 0x15, 0xed, 0x4a, 0x18, 0xdd, 0xfd, 0xf1,
-	0x41, 0xcd, 0x9b, 0x58, 0xf0, 0x00, 0xdf, 0x32, 0xd4, 0x86, 0x5e, 0xc0, 0x61, 0x73, 0xde, 0x94,
-	0x87, 0x29, 0x5a, 0xe4, 0x8c, 0x8c, 0xf7, 0x83, 0x41, 0xa3, 0x3e, 0x86, 0x29, 0xd2, 0x4b, 0x38,
-	0xfa, 0xc7, 0xb4, 0x78, 0x79, 0x45, 0x63, 0xed, 0x94, 0xe0, 0xb0, 0xd1, 0x9f, 0x4a, 0xd9, 0xbd,
-	0x01, 0xbb, 0xe7, 0x46, 0x99, 0xe4, 0xd4, 0x01, 0xa8, 0x62, 0xa3, 0xc2, 0x79, 0x79, 0xd7, 0x5e,
-	0xb0, 0xa4, 0xb8, 0xf7, 0xe0, 0x34, 0xee, 0x67, 0xbe, 0x7d, 0x62, 0xf7, 0x76, 0xa9, 0x78, 0xfb,
-	0xa0, 0x22, 0xc8, 0x39, 0x0c, 0x32, 0x3e, 0x5d, 0xc9, 0x72, 0x90, 0xd5, 0x6c, 0xa1, 0xf9, 0x3f,
-	0x04, 0x8e, 0x3b, 0xcb, 0xd0, 0x4f, 0x02, 0xa3, 0x3a, 0x51, 0x37, 0xe0, 0xb1, 0x8e, 0x05, 0xd7,
-	0x4d, 0x61, 0xb3, 0x0d, 0x1c, 0x45, 0x83, 0x09, 0x0c, 0x6b, 0xeb, 0x43, 0xfc, 0x8e, 0x1c, 0xb5,
-	0xa6, 0x27, 0x2c, 0x12, 0x22, 0x4a, 0xb0, 0x1a, 0x7f, 0x96, 0x2d, 0xd8, 0xa4, 0xf8, 0x17, 0xec,
-	0x1e, 0x7d, 0x4c, 0x3c, 0xe2, 0x7f, 0x13, 0x38, 0xed, 0xf9, 0x52, 0xf4, 0x8b, 0x80, 0xd3, 0x6e,
-	0xd9, 0x42, 0xfc, 0xb5, 0xa9, 0x3b, 0x27, 0xb4, 0xbd, 0x8d, 0x3c, 0x32, 0xc9, 0x67, 0xbb, 0x65,
-	0xf0, 0xeb, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xaf, 0x67, 0xb2, 0x17, 0x03, 0x00, 0x00,
+	// 687 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4f, 0x6f, 0xd3, 0x4e,
+	0x10, 0x95, 0x93, 0x34, 0x4d, 0xa7, 0xbf, 0xb4, 0xd5, 0xfe, 0x0a, 0x18, 0x2b, 0x84, 0xca, 0x88,
+	0x12, 0x24, 0x14, 0xaa, 0x20, 0x24, 0x40, 0xdc, 0x0a, 0x05, 0x44, 0x41, 0xc8, 0x2d, 0x88, 0x4b,
+	0x65, 0x36, 0xce, 0x34, 0x58, 0xf1, 0xbf, 0xee, 0xae, 0xd3, 0xfa, 0xc6, 0x09, 0x89, 0x4f, 0xc0,
+	0x9d, 0xef, 0xc6, 0x19, 0x3e, 0x02, 0xda, 0xf5, 0xda, 0x44, 0xad, 0x1b, 0xa8, 0xb8, 0xed, 0x3c,
+	0xbf, 0x37, 0x3b, 0x33, 0xfb, 0xc6, 0x60, 0x1e, 0xc6, 0xec, 0x98, 0xb2, 0x11, 0x32, 0x86, 0x63,
+	0x9f, 0x0b, 0x46, 0x59, 0x3f, 0x61, 0xb1, 0x88, 0x09, 0x39, 0xfb, 0xc5, 0xea, 0x26, 0x22, 0x4b,
+	0x90, 0xdf, 0x1d, 0xa5, 0x8c, 0x0a, 0x3f, 0x8e, 0xca, 0x43, 0xae, 0xb1, 0xbf, 0xd7, 0xa1, 0xb3,
+	0x53, 0xc8, 0x1c, 0x2d, 0x93, 0xdf, 0x1d, 0x3c, 0x4a, 0x91, 0x0b, 0x72, 0x13, 0x56, 0xca, 0xb4,
+	0x6e, 0x44, 0x43, 0x34, 0x8d, 0x0d, 0xa3, 0xb7, 0xe4, 0xb4, 0x4b, 0xf4, 0x35, 0x0d, 0x91, 0xdc,
+	0x86, 0xb5, 0xdf, 0x34, 0x1e, 0x7b, 0x13, 0x14, 0x66, 0x4d, 0x11, 0x57, 0x4b, 0x7c, 0x4f, 0xc1,
+	0x64, 0x13, 0x56, 0xfc, 0x30, 0x09, 0x30, 0xc4, 0x48, 0xa8, 0xab, 0xcc, 0xba, 0x22, 0x9e, 0x42,
+	0x89, 0x09, 0x8b, 0x53, 0x64, 0x5c, 0x12, 0x1a, 0x8a, 0x50, 0x84, 0xf2, 0x32, 0x55, 0xbd, 0x17,
+	0x07, 0x6e, 0x41, 0x59, 0xd8, 0x30, 0x7a, 0x6d, 0x67, 0xb5, 0xc0, 0xdf, 0x69, 0xea, 0x33, 0x80,
+	0x10, 0xbd, 0x8f, 0x34, 0xf2, 0x79, 0xc8, 0xcd, 0xe6, 0x46, 0xbd, 0xb7, 0x3c, 0xb8, 0xd5, 0xaf,
+	0x18, 0xe1, 0xab, 0x82, 0xb5, 0x4d, 0x13, 0x3a, 0xf4, 0x03, 0x5f, 0x64, 0xce, 0x8c, 0x94, 0xdc,
+	0x01, 0x12, 0xd2, 0x13, 0xd7, 0x63, 0x31, 0xe7, 0xae, 0x17, 0x47, 0x11, 0x7a, 0x82, 0x9b, 0x8b,
+	0xea, 0xd6, 0xb5, 0x90, 0x9e, 0x6c, 0xcb, 0x0f, 0xdb, 0x1a, 0x27, 0xfb, 0xd0, 0x0c, 0xe8, 0x10,
+	0x03, 0x6e, 0xb6, 0xd4, 0x95, 0x8f, 0xab, 0xae, 0x9c, 0x37, 0xf7, 0xfe, 0xae, 0x92, 0x3f, 0x8d,
+	0x04, 0xcb, 0x1c, 0x9d, 0xcb, 0x7a, 0x08, 0xcb, 0x33, 0x30, 0x59, 0x83, 0xfa, 0x04, 0x33, 0xfd,
+	0x1e, 0xf2, 0x48, 0xd6, 0x61, 0x61, 0x4a, 0x83, 0x14, 0xf5, 0xe8, 0xf3, 0xe0, 0x51, 0xed, 0x81,
+	0x61, 0x7f, 0x31, 0xe0, 0xff, 0x8a, 0x16, 0x09, 0x81, 0x86, 0x34, 0x88, 0x4e, 0xa2, 0xce, 0xe4,
+	0x32, 0x34, 0x19, 0x86, 0xb1, 0xc8, 0xd3, 0xb4, 0x1c, 0x1d, 0x91, 0x1d, 0x80, 0x84, 0x32, 0x1a,
+	0xa2, 0x40, 0xc6, 0xcd, 0xba, 0x6a, 0x6c, 0x73, 0xee, 0x2c, 0xdf, 0x14, 0x74, 0x67, 0x46, 0x69,
+	0xbf, 0x07, 0x72, 0x96, 0x21, 0x2b, 0x99, 0xb1, 0x97, 0x3a, 0x97, 0xd5, 0xd5, 0x66, 0xaa, 0xb3,
+	0xa0, 0xc5, 0xf0, 0x28, 0xf5, 0x19, 0x8e, 0x94, 0x71, 0x5a, 0x4e, 0x19, 0xdb, 0x3f, 0x0d, 0xb0,
+	0xce, 0x99, 0x6a, 0x12, 0x64, 0xa4, 0x0b, 0x90, 0x17, 0x89, 0x52, 0x6c, 0x28, 0xf1, 0x0c, 0x52,
+	0xe9, 0xab, 0x5a, 0xb5, 0xaf, 0x3e, 0xc0, 0xf5, 0x63, 0x9f, 0xe1, 0x38, 0xa5, 0x6c, 0xe4, 0x4e,
+	0x30, 0x73, 0x59, 0x9c, 0xdb, 0xd6, 0xf5, 0x23, 0x81, 0x6c, 0x4a, 0x03, 0x55, 0xdc, 0xf2, 0xe0,
+	0x6a, 0x7f, 0x1c, 0xc7, 0xe3, 0x00, 0xf3, 0x7d, 0x1b, 0xa6, 0x87, 0xfd, 0x27, 0x7a, 0x03, 0x9d,
+	0x4e, 0x99, 0xe1, 0x25, 0x66, 0x8e, 0xd6, 0xbf, 0xd0, 0x72, 0xb9, 0x78, 0x81, 0x3f, 0xc5, 0x08,
+	0x39, 0x77, 0x45, 0x3c, 0xc1, 0x62, 0x0b, 0xda, 0x05, 0xba, 0x2f, 0x41, 0xfb, 0xab, 0x01, 0x4b,
+	0xcf, 0x91, 0x32, 0x31, 0x44, 0xfa, 0xd7, 0xdb, 0x6a, 0x41, 0x8b, 0x4b, 0x9f, 0x45, 0x5e, 0x3e,
+	0xdb, 0x86, 0x53, 0xc6, 0xd2, 0x55, 0xd4, 0x9b, 0xa8, 0xea, 0x1b, 0x8e, 0x3c, 0x92, 0xfb, 0xd0,
+	0x2a, 0x9b, 0x6a, 0xfc, 0xa9, 0xa9, 0x92, 0x6a, 0x1f, 0x40, 0xb7, 0x7c, 0x8b, 0xb7, 0xd1, 0x3f,
+	0xfc, 0x5b, 0xd6, 0x61, 0x61, 0xc4, 0xa8, 0x1f, 0x69, 0x3b, 0xe6, 0x81, 0x7d, 0x00, 0x9d, 0x73,
+	0xd3, 0xcb, 0xc7, 0xbe, 0x01, 0xed, 0x34, 0x72, 0xcf, 0xbc, 0xf7, 0x7f, 0x69, 0xe4, 0x94, 0x98,
+	0xfc, 0xc7, 0xa8, 0x6c, 0x38, 0xd2, 0xc9, 0x8b, 0x70, 0xf0, 0xc3, 0x80, 0x4b, 0x95, 0x56, 0x22,
+	0x9f, 0x0c, 0xe8, 0xe8, 0x0e, 0xaa, 0x09, 0x5b, 0x17, 0x5d, 0x76, 0xab, 0x7f, 0x01, 0x85, 0xec,
+	0x6d, 0x0f, 0x56, 0xb5, 0x74, 0x57, 0x9b, 0x81, 0x5c, 0xab, 0x4a, 0x51, 0x1a, 0xc3, 0x9a, 0xff,
+	0xb9, 0x67, 0x6c, 0x19, 0x83, 0x6f, 0x06, 0x5c, 0x39, 0x67, 0xa2, 0xe4, 0xb3, 0x01, 0xdd, 0xd3,
+	0x3d, 0x9f, 0xa2, 0x0c, 0xe6, 0xf6, 0x50, 0x69, 0x00, 0x6b, 0xeb, 0x42, 0x9a, 0x24, 0xc8, 0x86,
+	0x4d, 0xe5, 0xb8, 0x7b, 0xbf, 0x06, 0x00, 0x09, 0xbd, 0x4e, 0x07, 0x06, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -239,9 +553,11 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ForwarderRegistrationClient interface {
 	RequestForwarderRegistration(ctx context.Context, in *ForwarderRegistrationRequest, opts ...grpc.CallOption) (*ForwarderRegistrationReply, error)
-	// RequestLiveness is a stream initiated by NSM to inform the forwarder that NSM is still alive and
-	// no re-registration is required. Detection a failure on this "channel" will mean
-	// that NSM is gone and the forwarder needs to start re-registration logic.
+	// RequestLiveness is a bidirectional heartbeat stream between NSM and the forwarder.
+	// Detection a failure or missed heartbeats on this "channel" will mean for the forwarder
+	// that NSM is gone and it needs to start re-registration logic, and for NSM that the forwarder
+	// is gone and its connections need to be healed. Forwarders sending heartbeats have to send the liveness
+	// token of their registration in the stream metadata.
 	RequestLiveness(ctx context.Context, opts ...grpc.CallOption) (ForwarderRegistration_RequestLivenessClient, error)
 }
 
@@ -272,8 +588,8 @@ func (c *forwarderRegistrationClient) RequestLiveness(ctx context.Context, opts
 }
 
 type ForwarderRegistration_RequestLivenessClient interface {
-	Send(*empty.Empty) error
-	Recv() (*empty.Empty, error)
+	Send(*Heartbeat) error
+	Recv() (*Heartbeat, error)
 	grpc.ClientStream
 }
 
@@ -281,12 +597,12 @@ type forwarderRegistrationRequestLivenessClient struct {
 	grpc.ClientStream
 }
 
-func (x *forwarderRegistrationRequestLivenessClient) Send(m *empty.Empty) error {
+func (x *forwarderRegistrationRequestLivenessClient) Send(m *Heartbeat) error {
 	return x.ClientStream.SendMsg(m)
 }
 
-func (x *forwarderRegistrationRequestLivenessClient) Recv() (*empty.Empty, error) {
-	m := new(empty.Empty)
+func (x *forwarderRegistrationRequestLivenessClient) Recv() (*Heartbeat, error) {
+	m := new(Heartbeat)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
@@ -296,9 +612,11 @@ func (x *forwarderRegistrationRequestLivenessClient) Recv() (*empty.Empty, error
 // ForwarderRegistrationServer is the server API for ForwarderRegistration service.
 type ForwarderRegistrationServer interface {
 	RequestForwarderRegistration(context.Context, *ForwarderRegistrationRequest) (*ForwarderRegistrationReply, error)
-	// RequestLiveness is a stream initiated by NSM to inform the forwarder that NSM is still alive and
-	// no re-registration is required. Detection a failure on this "channel" will mean
-	// that NSM is gone and the forwarder needs to start re-registration logic.
+	// RequestLiveness is a bidirectional heartbeat stream between NSM and the forwarder.
+	// Detection a failure or missed heartbeats on this "channel" will mean for the forwarder
+	// that NSM is gone and it needs to start re-registration logic, and for NSM that the forwarder
+	// is gone and its connections need to be healed. Forwarders sending heartbeats have to send the liveness
+	// token of their registration in the stream metadata.
 	RequestLiveness(ForwarderRegistration_RequestLivenessServer) error
 }
 
@@ -340,8 +658,8 @@ func _ForwarderRegistration_RequestLiveness_Handler(srv interface{}, stream grpc
 }
 
 type ForwarderRegistration_RequestLivenessServer interface {
-	Send(*empty.Empty) error
-	Recv() (*empty.Empty, error)
+	Send(*Heartbeat) error
+	Recv() (*Heartbeat, error)
 	grpc.ServerStream
 }
 
@@ -349,12 +667,12 @@ type forwarderRegistrationRequestLivenessServer struct {
 	grpc.ServerStream
 }
 
-func (x *forwarderRegistrationRequestLivenessServer) Send(m *empty.Empty) error {
+func (x *forwarderRegistrationRequestLivenessServer) Send(m *Heartbeat) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *forwarderRegistrationRequestLivenessServer) Recv() (*empty.Empty, error) {
-	m := new(empty.Empty)
+func (x *forwarderRegistrationRequestLivenessServer) Recv() (*Heartbeat, error) {
+	m := new(Heartbeat)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
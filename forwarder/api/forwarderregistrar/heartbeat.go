@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarderregistrar
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// DefaultHeartbeatInterval is a heartbeat interval of peers not advertising it, NSM of protocol version 1
+	// sends empty message every 5 seconds
+	DefaultHeartbeatInterval = 5 * time.Second
+	// DefaultHeartbeatMissThreshold is an amount of heartbeats missed in a row the peer is considered hung after
+	DefaultHeartbeatMissThreshold = 3
+	// LivenessTokenKey is a key of the liveness stream metadata the forwarder sends its liveness token with
+	LivenessTokenKey = "forwarder-liveness-token"
+)
+
+// HeartbeatTracker tracks heartbeats received from the peer on the liveness stream and detects missed ones.
+// It is not thread safe.
+type HeartbeatTracker struct {
+	missThreshold int
+	started       bool
+	lastReceived  time.Time
+	lastSequence  uint64
+	peerInterval  time.Duration
+}
+
+// NewHeartbeatTracker creates a tracker considering the peer hung after missThreshold heartbeats missed in a row
+func NewHeartbeatTracker(missThreshold int) *HeartbeatTracker {
+	if missThreshold <= 0 {
+		missThreshold = DefaultHeartbeatMissThreshold
+	}
+	return &HeartbeatTracker{
+		missThreshold: missThreshold,
+		peerInterval:  DefaultHeartbeatInterval,
+	}
+}
+
+// Start starts detection of missed heartbeats, as if a heartbeat of default interval was received at now
+func (t *HeartbeatTracker) Start(now time.Time) {
+	t.started = true
+	t.lastReceived = now
+}
+
+// Receive records heartbeat received at now and starts detection of missed heartbeats if it is not started yet,
+// returns an amount of heartbeats lost since the previously received one
+func (t *HeartbeatTracker) Receive(heartbeat *Heartbeat, now time.Time) uint64 {
+	t.Start(now)
+	t.peerInterval = DefaultHeartbeatInterval
+	if interval, err := ptypes.Duration(heartbeat.GetInterval()); err == nil && interval > 0 {
+		t.peerInterval = interval
+	}
+
+	var lost uint64
+	if sequence := heartbeat.GetSequence(); sequence > t.lastSequence+1 && t.lastSequence > 0 {
+		lost = sequence - t.lastSequence - 1
+	}
+	t.lastSequence = heartbeat.GetSequence()
+	return lost
+}
+
+// Expired returns true if the peer missed the threshold amount of heartbeats in a row at now,
+// it is always false until the detection is started
+func (t *HeartbeatTracker) Expired(now time.Time) bool {
+	return t.started && now.Sub(t.lastReceived) > t.Deadline()
+}
+
+// Deadline returns a time since the last received heartbeat the peer is considered hung after
+func (t *HeartbeatTracker) Deadline() time.Duration {
+	return t.peerInterval * time.Duration(t.missThreshold)
+}
+
+// LastSequence returns a sequence of the last received heartbeat to be acknowledged to the peer
+func (t *HeartbeatTracker) LastSequence() uint64 {
+	return t.lastSequence
+}
+
+// NewHeartbeat creates heartbeat of sequence sent every interval, acknowledging heartbeats tracked by t
+func (t *HeartbeatTracker) NewHeartbeat(forwarderName string, sequence uint64, interval time.Duration) *Heartbeat {
+	return &Heartbeat{
+		ForwarderName: forwarderName,
+		Sequence:      sequence,
+		Ack:           t.lastSequence,
+		Interval:      ptypes.DurationProto(interval),
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwarderregistrar
+
+const (
+	// ProtocolVersion is a version of the forwarder registrar protocol, version 2 advertises forwarder capabilities,
+	// version 3 exchanges sequenced heartbeats on the liveness stream
+	ProtocolVersion uint32 = 3
+	// MinProtocolVersion is the oldest version of the forwarder registrar protocol NSM accepts registrations of,
+	// forwarders not sending protocol version are of version 1
+	MinProtocolVersion uint32 = 1
+	// HeartbeatProtocolVersion is the oldest version of the forwarder registrar protocol reading heartbeats
+	// from the liveness stream
+	HeartbeatProtocolVersion uint32 = 3
+)
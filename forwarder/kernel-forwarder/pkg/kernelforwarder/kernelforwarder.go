@@ -17,12 +17,14 @@ package kernelforwarder
 
 import (
 	"context"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
@@ -40,6 +42,9 @@ type KernelForwarder struct {
 	monitoring    *monitoring.Metrics
 	localConnect  *local.Connect
 	remoteConnect *remote.Connect
+
+	remoteSetupsMutex sync.Mutex
+	remoteSetups      map[string]*remoteSetup
 }
 
 // CreateKernelForwarder creates an instance of the KernelForwarder
@@ -47,6 +52,7 @@ func CreateKernelForwarder() *KernelForwarder {
 	return &KernelForwarder{
 		localConnect:  local.NewConnect(),
 		remoteConnect: remote.NewConnect(),
+		remoteSetups:  make(map[string]*remoteSetup),
 	}
 }
 
@@ -54,6 +60,8 @@ func CreateKernelForwarder() *KernelForwarder {
 func (k *KernelForwarder) Init(common *common.ForwarderConfig) error {
 	k.common = common
 	k.common.Name = "kernel-forwarder"
+	k.common.Implementation = "kernel"
+	k.remoteConnect.SetWireguardKeys(common.WireguardKeys)
 	k.configureKernelForwarder()
 	return nil
 }
@@ -102,7 +110,7 @@ func (k *KernelForwarder) connectOrDisconnect(crossConnect *crossconnect.CrossCo
 	}
 
 	/* 0. Sanity check whether the forwarding plane supports the connection type in the request */
-	if err = common.SanityCheckConnectionType(k.common.Mechanisms, crossConnect); err != nil {
+	if err = common.SanityCheckConnectionType(k.common.GetMechanisms(), crossConnect); err != nil {
 		return err
 	}
 
@@ -130,7 +138,7 @@ func (k *KernelForwarder) connectOrDisconnect(crossConnect *crossconnect.CrossCo
 // configureKernelForwarder setups the Kernel forwarding plane
 func (k *KernelForwarder) configureKernelForwarder() {
 	k.common.MechanismsUpdateChannel = make(chan *common.Mechanisms, 1)
-	k.common.Mechanisms = &common.Mechanisms{
+	k.common.SetMechanisms(&common.Mechanisms{
 		LocalMechanisms: []*connection.Mechanism{
 			{
 				Type: kernel.MECHANISM,
@@ -146,14 +154,22 @@ func (k *KernelForwarder) configureKernelForwarder() {
 			{
 				Type: wireguard.MECHANISM,
 				Parameters: map[string]string{
-					wireguard.SrcIP: k.common.EgressInterface.SrcIPNet().IP.String(),
+					wireguard.SrcIP:        k.common.EgressInterface.SrcIPNet().IP.String(),
+					wireguard.SrcPublicKey: k.common.WireguardKeys.PublicKey(),
+				},
+			},
+			{
+				Type: gre.MECHANISM,
+				Parameters: map[string]string{
+					gre.SrcIP: k.common.EgressInterface.SrcIPNet().IP.String(),
 				},
 			},
 		},
-	}
+	})
 	// Metrics monitoring
 	if k.common.MetricsEnabled {
 		k.monitoring = monitoring.CreateMetricsMonitor(k.common.MetricsPeriod)
+		k.monitoring.SetDeviceMetrics(k.remoteConnect.WireguardMetrics)
 		k.monitoring.Start(k.common.Monitor)
 	}
 	// Network Service monitoring
@@ -162,9 +178,10 @@ func (k *KernelForwarder) configureKernelForwarder() {
 
 // MonitorMechanisms handler
 func (k *KernelForwarder) MonitorMechanisms(empty *empty.Empty, updateSrv forwarder.MechanismsMonitor_MonitorMechanismsServer) error {
+	mechanisms := k.common.GetMechanisms()
 	initialUpdate := &forwarder.MechanismUpdate{
-		RemoteMechanisms: k.common.Mechanisms.RemoteMechanisms,
-		LocalMechanisms:  k.common.Mechanisms.LocalMechanisms,
+		RemoteMechanisms: mechanisms.RemoteMechanisms,
+		LocalMechanisms:  mechanisms.LocalMechanisms,
 	}
 
 	logrus.Infof("kernel-forwarder: sending MonitorMechanisms update: %v", initialUpdate)
@@ -175,7 +192,7 @@ func (k *KernelForwarder) MonitorMechanisms(empty *empty.Empty, updateSrv forwar
 	// Waiting for any updates which might occur during a life of forwarder module and communicating
 	// them back to NSM.
 	for update := range k.common.MechanismsUpdateChannel {
-		k.common.Mechanisms = update
+		k.common.SetMechanisms(update)
 		logrus.Infof("kernel-forwarder: sending MonitorMechanisms update: %v", update)
 
 		updateMsg := &forwarder.MechanismUpdate{
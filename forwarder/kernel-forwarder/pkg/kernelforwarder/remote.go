@@ -18,16 +18,25 @@ package kernelforwarder
 import (
 	"runtime"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	common2 "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	. "github.com/networkservicemesh/networkservicemesh/forwarder/kernel-forwarder/pkg/kernelforwarder/remote"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/kernel-forwarder/pkg/monitoring"
 )
 
+// remoteSetup is a pair of connections the interface of remote connection is set up with
+type remoteSetup struct {
+	localConnection  *connection.Connection
+	remoteConnection *connection.Connection
+	direction        uint8
+}
+
 // handleRemoteConnection handles remote connect/disconnect requests for either incoming or outgoing connections
 func (k *KernelForwarder) handleRemoteConnection(crossConnect *crossconnect.CrossConnect, connect bool) (map[string]monitoring.Device, error) {
 	if crossConnect.GetSource().IsRemote() && !crossConnect.GetDestination().IsRemote() {
@@ -48,6 +57,10 @@ func (k *KernelForwarder) handleRemoteConnection(crossConnect *crossconnect.Cros
 func (k *KernelForwarder) handleConnection(connID string, localConnection, remoteConnection *connection.Connection, connect bool, direction uint8) (map[string]monitoring.Device, error) {
 	var devices map[string]monitoring.Device
 	var err error
+
+	k.remoteSetupsMutex.Lock()
+	defer k.remoteSetupsMutex.Unlock()
+
 	if connect {
 		/* 2. Create a connection */
 		devices, err = k.createRemoteConnection(connID, localConnection, remoteConnection, direction)
@@ -80,6 +93,31 @@ func (k *KernelForwarder) createRemoteConnection(connID string, localConnection,
 	var nsInode string
 	var err error
 
+	setup := &remoteSetup{
+		localConnection:  localConnection.Clone(),
+		remoteConnection: remoteConnection.Clone(),
+		direction:        direction,
+	}
+	if previous, ok := k.remoteSetups[ifaceName]; ok {
+		if onlyKeysChanged(previous, setup) {
+			/* Existing interface is updated in place with rotated Wireguard keys, so its setup is kept */
+			updated, err := k.remoteConnect.UpdateInterface(ifaceName, remoteConnection, direction)
+			if err != nil {
+				logrus.Errorf("remote: %v", err)
+				return nil, err
+			}
+			if updated {
+				k.remoteSetups[ifaceName] = setup
+				logrus.Infof("remote: update completed for device - %s", ifaceName)
+				return nil, nil
+			}
+		} else if _, err = k.deleteRemoteConnection(connID, previous.localConnection, previous.remoteConnection, previous.direction); err != nil {
+			/* Any other change requires the interface to be recreated along with its setup */
+			logrus.Errorf("remote: failed to delete previous connection - %v", err)
+			return nil, err
+		}
+	}
+
 	/* Lock the OS thread so we don't accidentally switch namespaces */
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
@@ -94,6 +132,7 @@ func (k *KernelForwarder) createRemoteConnection(connID string, localConnection,
 		return nil, err
 	}
 
+	k.remoteSetups[ifaceName] = setup
 	logrus.Infof("remote: creation completed for device - %s", ifaceName)
 	return map[string]monitoring.Device{nsInode: {Name: ifaceName, XconName: xconName}}, nil
 }
@@ -116,6 +155,7 @@ func (k *KernelForwarder) deleteRemoteConnection(connID string, localConnection,
 
 	nsInode, localErr := ClearInterfaceSetup(ifaceName, localConnection)
 	remoteErr := k.remoteConnect.DeleteInterface(ifaceName, remoteConnection)
+	delete(k.remoteSetups, ifaceName)
 
 	if localErr != nil || remoteErr != nil {
 		return nil, errors.Errorf("remote: %v - %v", localErr, remoteErr)
@@ -124,3 +164,27 @@ func (k *KernelForwarder) deleteRemoteConnection(connID string, localConnection,
 	logrus.Infof("remote: deletion completed for device - %s", ifaceName)
 	return map[string]monitoring.Device{nsInode: {Name: ifaceName, XconName: xconName}}, nil
 }
+
+// onlyKeysChanged returns true if the Wireguard interface set up with previous differs from current in the public
+// keys only, so it can be updated in place
+func onlyKeysChanged(previous, current *remoteSetup) bool {
+	if current.remoteConnection.GetMechanism().GetType() != wireguard.MECHANISM || previous.direction != current.direction {
+		return false
+	}
+	return sameSetup(previous.localConnection, current.localConnection) &&
+		sameSetup(withoutWireguardKeys(previous.remoteConnection), withoutWireguardKeys(current.remoteConnection))
+}
+
+// sameSetup returns true if the interfaces of previous and current connections are set up in the same way
+func sameSetup(previous, current *connection.Connection) bool {
+	return proto.Equal(previous.GetMechanism(), current.GetMechanism()) &&
+		proto.Equal(previous.GetContext(), current.GetContext())
+}
+
+// withoutWireguardKeys returns a copy of conn without Wireguard public keys
+func withoutWireguardKeys(conn *connection.Connection) *connection.Connection {
+	conn = conn.Clone()
+	delete(conn.GetMechanism().GetParameters(), wireguard.SrcPublicKey)
+	delete(conn.GetMechanism().GetParameters(), wireguard.DstPublicKey)
+	return conn
+}
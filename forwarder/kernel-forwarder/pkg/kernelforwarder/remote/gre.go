@@ -0,0 +1,103 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
+)
+
+// createGREInterface creates a GRE or GRETAP interface
+func (c *Connect) createGREInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) error {
+	mechanism := gre.ToMechanism(remoteConnection.GetMechanism())
+
+	/* Create interface - host namespace */
+	srcIPStr, err := mechanism.SrcIP()
+	if err != nil {
+		return err
+	}
+	dstIPStr, err := mechanism.DstIP()
+	if err != nil {
+		return err
+	}
+	key, err := mechanism.Key()
+	if err != nil {
+		return err
+	}
+	mode, err := mechanism.Mode()
+	if err != nil {
+		return err
+	}
+
+	var localIP net.IP
+	var remoteIP net.IP
+	if direction == INCOMING {
+		localIP = net.ParseIP(dstIPStr)
+		remoteIP = net.ParseIP(srcIPStr)
+	} else {
+		localIP = net.ParseIP(srcIPStr)
+		remoteIP = net.ParseIP(dstIPStr)
+	}
+
+	if err := netlink.LinkAdd(newGRE(ifaceName, mode, localIP, remoteIP, key)); err != nil {
+		return errors.Wrapf(err, "failed to create %s interface", mode)
+	}
+	return nil
+}
+
+func (c *Connect) deleteGREInterface(ifaceName string) error {
+	/* Get a link object for interface */
+	ifaceLink, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return errors.Errorf("failed to get link for %q - %v", ifaceName, err)
+	}
+
+	/* Delete the GRE interface - host namespace */
+	if err = netlink.LinkDel(ifaceLink); err != nil {
+		return errors.Errorf("failed to delete GRE interface - %v", err)
+	}
+
+	return nil
+}
+
+// newGRE returns a GRE interface instance of mode, the same key is used for both directions of the tunnel
+func newGRE(ifaceName, mode string, egressIP, remoteIP net.IP, key uint32) netlink.Link {
+	attrs := netlink.LinkAttrs{
+		Name: ifaceName,
+	}
+	if mode == gre.ModeGRE {
+		return &netlink.Gretun{
+			LinkAttrs: attrs,
+			IKey:      key,
+			OKey:      key,
+			Local:     egressIP,
+			Remote:    remoteIP,
+		}
+	}
+	return &netlink.Gretap{
+		LinkAttrs: attrs,
+		IKey:      key,
+		OKey:      key,
+		Local:     egressIP,
+		Remote:    remoteIP,
+	}
+}
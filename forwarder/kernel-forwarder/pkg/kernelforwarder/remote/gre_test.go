@@ -0,0 +1,123 @@
+package remote
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
+)
+
+// inNewNetNS runs f in a new network namespace, the test is skipped if it could not be created
+func inNewNetNS(t *testing.T, f func()) {
+	if os.Geteuid() != 0 {
+		t.Skip("network namespaces require root privileges")
+	}
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		t.Skipf("failed to get current network namespace: %v", err)
+	}
+	defer func() { _ = origin.Close() }()
+
+	ns, err := netns.New()
+	if err != nil {
+		t.Skipf("failed to create network namespace: %v", err)
+	}
+	defer func() {
+		_ = netns.Set(origin)
+		_ = ns.Close()
+	}()
+
+	f()
+}
+
+// requireLinkSupport skips the test if the kernel does not support links of the link type
+func requireLinkSupport(t *testing.T, link netlink.Link) {
+	if err := netlink.LinkAdd(link); err != nil {
+		t.Skipf("%s links are not supported: %v", link.Type(), err)
+	}
+	_ = netlink.LinkDel(link)
+}
+
+func greConnection(parameters map[string]string) *connection.Connection {
+	parameters[gre.SrcIP] = "10.0.0.1"
+	parameters[gre.DstIP] = "10.0.0.2"
+	parameters[gre.Key] = "7"
+	return &connection.Connection{
+		Mechanism: &connection.Mechanism{
+			Type:       gre.MECHANISM,
+			Parameters: parameters,
+		},
+	}
+}
+
+func TestCreateGRETAPInterface(t *testing.T) {
+	inNewNetNS(t, func() {
+		requireLinkSupport(t, &netlink.Gretap{LinkAttrs: netlink.LinkAttrs{Name: "gretap-probe"}, Local: net.ParseIP("10.0.0.3"), Remote: net.ParseIP("10.0.0.4")})
+		g := gomega.NewWithT(t)
+
+		c := NewConnect()
+		g.Expect(c.CreateInterface("gre-out", greConnection(map[string]string{}), OUTGOING)).To(gomega.Succeed())
+
+		link, err := netlink.LinkByName("gre-out")
+		g.Expect(err).To(gomega.BeNil())
+		gretap, ok := link.(*netlink.Gretap)
+		g.Expect(ok).To(gomega.BeTrue())
+		g.Expect(gretap.IKey).To(gomega.Equal(uint32(7)))
+		g.Expect(gretap.OKey).To(gomega.Equal(uint32(7)))
+		g.Expect(gretap.Local.Equal(net.ParseIP("10.0.0.1"))).To(gomega.BeTrue())
+		g.Expect(gretap.Remote.Equal(net.ParseIP("10.0.0.2"))).To(gomega.BeTrue())
+
+		g.Expect(c.DeleteInterface("gre-out", greConnection(map[string]string{}))).To(gomega.Succeed())
+		_, err = netlink.LinkByName("gre-out")
+		g.Expect(err).NotTo(gomega.BeNil())
+	})
+}
+
+func TestCreateGREInterface(t *testing.T) {
+	inNewNetNS(t, func() {
+		requireLinkSupport(t, &netlink.Gretun{LinkAttrs: netlink.LinkAttrs{Name: "gre-probe"}, Local: net.ParseIP("10.0.0.3"), Remote: net.ParseIP("10.0.0.4")})
+		g := gomega.NewWithT(t)
+
+		c := NewConnect()
+		conn := greConnection(map[string]string{gre.Mode: gre.ModeGRE})
+		g.Expect(c.CreateInterface("gre-in", conn, INCOMING)).To(gomega.Succeed())
+
+		link, err := netlink.LinkByName("gre-in")
+		g.Expect(err).To(gomega.BeNil())
+		gretun, ok := link.(*netlink.Gretun)
+		g.Expect(ok).To(gomega.BeTrue())
+		g.Expect(gretun.IKey).To(gomega.Equal(uint32(7)))
+		// Incoming tunnel is terminated on the destination host
+		g.Expect(gretun.Local.Equal(net.ParseIP("10.0.0.2"))).To(gomega.BeTrue())
+		g.Expect(gretun.Remote.Equal(net.ParseIP("10.0.0.1"))).To(gomega.BeTrue())
+
+		g.Expect(c.DeleteInterface("gre-in", conn)).To(gomega.Succeed())
+	})
+}
+
+func TestCreateGREInterfaceInvalid(t *testing.T) {
+	inNewNetNS(t, func() {
+		g := gomega.NewWithT(t)
+
+		c := NewConnect()
+		conn := greConnection(map[string]string{gre.Mode: "ipip"})
+		g.Expect(c.CreateInterface("gre-invalid", conn, OUTGOING)).NotTo(gomega.Succeed())
+
+		conn = greConnection(map[string]string{})
+		delete(conn.GetMechanism().GetParameters(), gre.Key)
+		g.Expect(c.CreateInterface("gre-invalid", conn, OUTGOING)).NotTo(gomega.Succeed())
+
+		_, err := netlink.LinkByName("gre-invalid")
+		g.Expect(err).NotTo(gomega.BeNil())
+	})
+}
@@ -23,9 +23,9 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
-	wg "golang.zx2c4.com/wireguard/device"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 )
@@ -39,13 +39,14 @@ const (
 // Connect - struct with remote mechanism interfaces creation and deletion methods
 type Connect struct {
 	wireguardDevicesMutex sync.Mutex
-	wireguardDevices      map[string]*wg.Device
+	wireguardDevices      map[string]*wireguardDevice
+	wireguardKeys         WireguardKeys
 }
 
 // NewConnect - creates instance of remote Connect
 func NewConnect() *Connect {
 	return &Connect{
-		wireguardDevices: make(map[string]*wg.Device),
+		wireguardDevices: make(map[string]*wireguardDevice),
 	}
 }
 
@@ -56,10 +57,22 @@ func (c *Connect) CreateInterface(ifaceName string, remoteConnection *connection
 		return c.createVXLANInterface(ifaceName, remoteConnection, direction)
 	case wireguard.MECHANISM:
 		return c.createWireguardInterface(ifaceName, remoteConnection, direction)
+	case gre.MECHANISM:
+		return c.createGREInterface(ifaceName, remoteConnection, direction)
 	}
 	return errors.Errorf("unknown remote mechanism - %v", remoteConnection.GetMechanism().GetType())
 }
 
+// UpdateInterface - applies changes of remote connection to the existing interface in place, returns false if there is
+// no such interface or the mechanism does not support updates, so the interface is to be created
+func (c *Connect) UpdateInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) (bool, error) {
+	switch remoteConnection.GetMechanism().GetType() {
+	case wireguard.MECHANISM:
+		return c.updateWireguardInterface(ifaceName, remoteConnection, direction)
+	}
+	return false, nil
+}
+
 // DeleteInterface - deletes interface to remote connection
 func (c *Connect) DeleteInterface(ifaceName string, remoteConnection *connection.Connection) error {
 	switch remoteConnection.GetMechanism().GetType() {
@@ -67,6 +80,8 @@ func (c *Connect) DeleteInterface(ifaceName string, remoteConnection *connection
 		return c.deleteVXLANInterface(ifaceName)
 	case wireguard.MECHANISM:
 		return c.deleteWireguardInterface(ifaceName)
+	case gre.MECHANISM:
+		return c.deleteGREInterface(ifaceName)
 	}
 	return errors.Errorf("unknown remote mechanism - %v", remoteConnection.GetMechanism().GetType())
 }
@@ -32,71 +32,133 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 )
 
-// CreateVXLANInterface creates a VXLAN interface
+// WireguardKeys provides private keys of the forwarder by their public keys
+type WireguardKeys interface {
+	PrivateKey(publicKey string) (wgtypes.Key, error)
+}
+
+// SetWireguardKeys configures private keys used for Wireguard interfaces
+func (c *Connect) SetWireguardKeys(keys WireguardKeys) {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+
+	c.wireguardKeys = keys
+}
+
+// wireguardDevice is a Wireguard device of remote connection along with the keys it is configured with
+type wireguardDevice struct {
+	device          *device.Device
+	localPublicKey  string
+	remotePublicKey string
+	keyRotations    uint64
+}
+
+// wireguardPeer is a configuration of Wireguard device for one side of remote connection
+type wireguardPeer struct {
+	localPublicKey  string
+	remotePublicKey string
+	localPort       int
+	remotePort      int
+	dstIP           net.IP
+}
+
+// createWireguardInterface creates a Wireguard interface
 func (c *Connect) createWireguardInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) error {
 	c.wireguardDevicesMutex.Lock()
 	defer c.wireguardDevicesMutex.Unlock()
-	mechanism := wireguard.ToMechanism(remoteConnection.GetMechanism())
 
 	/* Create interface - host namespace */
-	var localPrivateKeyStr string
-	var remotePublicKeyStr string
-	var localPort int
-	var remotePort int
-	var dstIPStr string
-	var err error
-	if direction == INCOMING {
-		if localPrivateKeyStr, err = mechanism.DstPrivateKey(); err != nil {
-			return err
-		}
-		if remotePublicKeyStr, err = mechanism.SrcPublicKey(); err != nil {
-			return err
-		}
-		if dstIPStr, err = mechanism.SrcIP(); err != nil {
-			return err
-		}
-		if localPort, err = mechanism.DstPort(); err != nil {
-			return err
-		}
-		if remotePort, err = mechanism.SrcPort(); err != nil {
-			return err
-		}
-	} else {
-		if localPrivateKeyStr, err = mechanism.SrcPrivateKey(); err != nil {
-			return err
-		}
-		if remotePublicKeyStr, err = mechanism.DstPublicKey(); err != nil {
-			return err
-		}
-		if dstIPStr, err = mechanism.DstIP(); err != nil {
-			return err
-		}
-		if localPort, err = mechanism.SrcPort(); err != nil {
-			return err
-		}
-		if remotePort, err = mechanism.DstPort(); err != nil {
-			return err
-		}
+	peer, err := newWireguardPeer(remoteConnection, direction)
+	if err != nil {
+		return err
 	}
 
-	dstIP := net.ParseIP(dstIPStr)
-	localPrivateKey, err := wgtypes.ParseKey(localPrivateKeyStr)
+	wgDevice, err := createWireguardDevice(ifaceName)
 	if err != nil {
-		return errors.Errorf("failed to parse local private key: %v", err)
+		return errors.Errorf("Wireguard error: %v", err)
 	}
-	remotePublicKey, err := wgtypes.ParseKey(remotePublicKeyStr)
+	if err := c.configureWireguard(ifaceName, wgDevice, peer); err != nil {
+		wgDevice.Close()
+		return err
+	}
+	c.wireguardDevices[ifaceName] = &wireguardDevice{
+		device:          wgDevice,
+		localPublicKey:  peer.localPublicKey,
+		remotePublicKey: peer.remotePublicKey,
+	}
+
+	return nil
+}
+
+// updateWireguardInterface configures the existing Wireguard interface with keys renegotiated by NSM in place, so
+// the interface keeps its setup and the traffic goes on once the peers handshake with the new keys
+func (c *Connect) updateWireguardInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) (bool, error) {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+
+	wgDevice, ok := c.wireguardDevices[ifaceName]
+	if !ok {
+		return false, nil
+	}
+	peer, err := newWireguardPeer(remoteConnection, direction)
 	if err != nil {
-		return errors.Errorf("failed to parse remote public key: %v", err)
+		return true, err
+	}
+	if err := c.configureWireguard(ifaceName, wgDevice.device, peer); err != nil {
+		return true, err
 	}
 
-	wgDevice, err := createWireguardDevice(ifaceName)
+	if peer.localPublicKey != wgDevice.localPublicKey || peer.remotePublicKey != wgDevice.remotePublicKey {
+		wgDevice.keyRotations++
+		logrus.Infof("Wireguard (%v): keys are rotated, rotations count %v", ifaceName, wgDevice.keyRotations)
+	}
+	wgDevice.localPublicKey = peer.localPublicKey
+	wgDevice.remotePublicKey = peer.remotePublicKey
+	return true, nil
+}
+
+// WireguardMetrics returns metrics of Wireguard interface kept by the forwarder, nil if there is no such interface
+func (c *Connect) WireguardMetrics(ifaceName string) map[string]string {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+
+	wgDevice, ok := c.wireguardDevices[ifaceName]
+	if !ok {
+		return nil
+	}
+	return map[string]string{
+		"wireguard_key_rotations": fmt.Sprint(wgDevice.keyRotations),
+	}
+}
+
+func (c *Connect) deleteWireguardInterface(ifaceName string) error {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+	if wgDevice, ok := c.wireguardDevices[ifaceName]; ok {
+		wgDevice.device.Close()
+		delete(c.wireguardDevices, ifaceName)
+	}
+
+	return nil
+}
+
+// configureWireguard configures wgDevice with the private key of the forwarder matching the local public key of peer
+func (c *Connect) configureWireguard(ifaceName string, wgDevice *device.Device, peer *wireguardPeer) error {
+	// Private key is never sent by NSM, it is kept by the forwarder that advertised the public one
+	if c.wireguardKeys == nil {
+		return errors.New("Wireguard keys are not configured")
+	}
+	localPrivateKey, err := c.wireguardKeys.PrivateKey(peer.localPublicKey)
 	if err != nil {
-		return errors.Errorf("Wireguard error: %v", err)
+		return err
+	}
+	remotePublicKey, err := wgtypes.ParseKey(peer.remotePublicKey)
+	if err != nil {
+		return errors.Errorf("failed to parse remote public key: %v", err)
 	}
-	c.wireguardDevices[ifaceName] = wgDevice
+
 	uapi, err := startWireguardAPI(ifaceName, wgDevice)
 	if err != nil {
-		wgDevice.Close()
 		return errors.Errorf("Wireguard error: %v", err)
 	}
 	defer func() {
@@ -105,24 +167,55 @@ func (c *Connect) createWireguardInterface(ifaceName string, remoteConnection *c
 		}
 	}()
 
-	err = configureWireguardDevice(ifaceName, localPrivateKey, remotePublicKey, localPort, remotePort, dstIP)
+	err = configureWireguardDevice(ifaceName, localPrivateKey, remotePublicKey, peer.localPort, peer.remotePort, peer.dstIP)
 	if err != nil {
-		wgDevice.Close()
 		return errors.Errorf("Wireguard error: %v", err)
 	}
-
 	return nil
 }
 
-func (c *Connect) deleteWireguardInterface(ifaceName string) error {
-	c.wireguardDevicesMutex.Lock()
-	defer c.wireguardDevicesMutex.Unlock()
-	if wgDevice, ok := c.wireguardDevices[ifaceName]; ok {
-		wgDevice.Close()
-		delete(c.wireguardDevices, ifaceName)
-	}
+// newWireguardPeer returns configuration of Wireguard device for the side of remoteConnection given by direction
+func newWireguardPeer(remoteConnection *connection.Connection, direction uint8) (*wireguardPeer, error) {
+	mechanism := wireguard.ToMechanism(remoteConnection.GetMechanism())
 
-	return nil
+	peer := &wireguardPeer{}
+	var dstIPStr string
+	var err error
+	if direction == INCOMING {
+		if peer.localPublicKey, err = mechanism.DstPublicKey(); err != nil {
+			return nil, err
+		}
+		if peer.remotePublicKey, err = mechanism.SrcPublicKey(); err != nil {
+			return nil, err
+		}
+		if dstIPStr, err = mechanism.SrcIP(); err != nil {
+			return nil, err
+		}
+		if peer.localPort, err = mechanism.DstPort(); err != nil {
+			return nil, err
+		}
+		if peer.remotePort, err = mechanism.SrcPort(); err != nil {
+			return nil, err
+		}
+	} else {
+		if peer.localPublicKey, err = mechanism.SrcPublicKey(); err != nil {
+			return nil, err
+		}
+		if peer.remotePublicKey, err = mechanism.DstPublicKey(); err != nil {
+			return nil, err
+		}
+		if dstIPStr, err = mechanism.DstIP(); err != nil {
+			return nil, err
+		}
+		if peer.localPort, err = mechanism.SrcPort(); err != nil {
+			return nil, err
+		}
+		if peer.remotePort, err = mechanism.DstPort(); err != nil {
+			return nil, err
+		}
+	}
+	peer.dstIP = net.ParseIP(dstIPStr)
+	return peer, nil
 }
 
 func createWireguardDevice(ifaceName string) (*device.Device, error) {
@@ -174,9 +267,11 @@ func configureWireguardDevice(ifaceName string, localPrivateKey, remotePublicKey
 	if err != nil {
 		return errors.Errorf("failed to configure device: %v", err)
 	}
+	// Peers are replaced, so the peer of previous remote public key is removed on update
 	err = client.ConfigureDevice(ifaceName, wgtypes.Config{
-		ListenPort: intPtr(localPort),
-		PrivateKey: &localPrivateKey,
+		ListenPort:   intPtr(localPort),
+		PrivateKey:   &localPrivateKey,
+		ReplacePeers: true,
 		Peers: []wgtypes.PeerConfig{
 			{
 				PublicKey: remotePublicKey,
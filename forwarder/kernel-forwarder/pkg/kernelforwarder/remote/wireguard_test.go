@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+)
+
+// testWireguardKeys keeps private keys of the forwarder by their public keys
+type testWireguardKeys map[string]wgtypes.Key
+
+func (k testWireguardKeys) PrivateKey(publicKey string) (wgtypes.Key, error) {
+	key, ok := k[publicKey]
+	if !ok {
+		return wgtypes.Key{}, errors.Errorf("no private key for %s", publicKey)
+	}
+	return key, nil
+}
+
+// generate returns public keys of a new local private key kept in k and a remote one
+func (k testWireguardKeys) generate(g *gomega.WithT) (localPublicKey, remotePublicKey string) {
+	localKey, err := wgtypes.GeneratePrivateKey()
+	g.Expect(err).To(gomega.BeNil())
+	remoteKey, err := wgtypes.GeneratePrivateKey()
+	g.Expect(err).To(gomega.BeNil())
+	k[localKey.PublicKey().String()] = localKey
+	return localKey.PublicKey().String(), remoteKey.PublicKey().String()
+}
+
+func wireguardConnection(srcPublicKey, dstPublicKey string) *connection.Connection {
+	return &connection.Connection{
+		Mechanism: &connection.Mechanism{
+			Type: wireguard.MECHANISM,
+			Parameters: map[string]string{
+				wireguard.SrcIP:        "10.0.0.1",
+				wireguard.DstIP:        "10.0.0.2",
+				wireguard.SrcPort:      "51820",
+				wireguard.DstPort:      "51821",
+				wireguard.SrcPublicKey: srcPublicKey,
+				wireguard.DstPublicKey: dstPublicKey,
+			},
+		},
+	}
+}
+
+func TestUpdateWireguardInterface(t *testing.T) {
+	inNewNetNS(t, func() {
+		g := gomega.NewWithT(t)
+
+		keys := testWireguardKeys{}
+		c := NewConnect()
+		c.SetWireguardKeys(keys)
+
+		conn := wireguardConnection(keys.generate(g))
+		if err := c.CreateInterface("wg-out", conn, OUTGOING); err != nil {
+			t.Skipf("Wireguard interfaces are not supported: %v", err)
+		}
+		defer func() { _ = c.DeleteInterface("wg-out", conn) }()
+		g.Expect(c.WireguardMetrics("wg-out")).To(gomega.Equal(map[string]string{"wireguard_key_rotations": "0"}))
+
+		// Request with the same keys is not a rotation
+		updated, err := c.UpdateInterface("wg-out", conn, OUTGOING)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(updated).To(gomega.BeTrue())
+		g.Expect(c.WireguardMetrics("wg-out")).To(gomega.Equal(map[string]string{"wireguard_key_rotations": "0"}))
+
+		// Interface is kept on rotation
+		link, err := netlink.LinkByName("wg-out")
+		g.Expect(err).To(gomega.BeNil())
+		updated, err = c.UpdateInterface("wg-out", wireguardConnection(keys.generate(g)), OUTGOING)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(updated).To(gomega.BeTrue())
+		g.Expect(c.WireguardMetrics("wg-out")).To(gomega.Equal(map[string]string{"wireguard_key_rotations": "1"}))
+		rotated, err := netlink.LinkByName("wg-out")
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(rotated.Attrs().Index).To(gomega.Equal(link.Attrs().Index))
+
+		// Private key of the forwarder is required to update the interface
+		_, remotePublicKey := keys.generate(g)
+		_, err = c.UpdateInterface("wg-out", wireguardConnection(remotePublicKey, remotePublicKey), OUTGOING)
+		g.Expect(err).NotTo(gomega.BeNil())
+
+		// Missing interface is to be created
+		updated, err = c.UpdateInterface("wg-other", conn, OUTGOING)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(updated).To(gomega.BeFalse())
+		g.Expect(c.WireguardMetrics("wg-other")).To(gomega.BeNil())
+	})
+}
@@ -0,0 +1,77 @@
+package kernelforwarder
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connectioncontext"
+	. "github.com/networkservicemesh/networkservicemesh/forwarder/kernel-forwarder/pkg/kernelforwarder/remote"
+)
+
+func wireguardSetup(srcPublicKey, dstPublicKey string) *remoteSetup {
+	return &remoteSetup{
+		localConnection: &connection.Connection{
+			Mechanism: &connection.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.InterfaceNameKey: "nsm0",
+					common.NetNsInodeKey:    "1",
+				},
+			},
+			Context: &connectioncontext.ConnectionContext{
+				IpContext: &connectioncontext.IPContext{SrcIpAddr: "10.0.0.1/30"},
+			},
+		},
+		remoteConnection: &connection.Connection{
+			Mechanism: &connection.Mechanism{
+				Type: wireguard.MECHANISM,
+				Parameters: map[string]string{
+					wireguard.SrcIP:        "192.168.0.1",
+					wireguard.DstIP:        "192.168.0.2",
+					wireguard.SrcPublicKey: srcPublicKey,
+					wireguard.DstPublicKey: dstPublicKey,
+				},
+			},
+		},
+		direction: OUTGOING,
+	}
+}
+
+func TestOnlyKeysChanged(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	previous := wireguardSetup("src", "dst")
+	g.Expect(onlyKeysChanged(previous, wireguardSetup("src", "dst"))).To(gomega.BeTrue())
+	g.Expect(onlyKeysChanged(previous, wireguardSetup("new-src", "new-dst"))).To(gomega.BeTrue())
+	// Keys of previous setup are kept
+	g.Expect(previous.remoteConnection.GetMechanism().GetParameters()[wireguard.SrcPublicKey]).To(gomega.Equal("src"))
+
+	// Any other change requires the interface to be recreated
+	current := wireguardSetup("new-src", "dst")
+	current.remoteConnection.GetMechanism().GetParameters()[wireguard.DstIP] = "192.168.0.3"
+	g.Expect(onlyKeysChanged(previous, current)).To(gomega.BeFalse())
+
+	current = wireguardSetup("new-src", "dst")
+	current.localConnection.GetMechanism().GetParameters()[common.NetNsInodeKey] = "2"
+	g.Expect(onlyKeysChanged(previous, current)).To(gomega.BeFalse())
+
+	current = wireguardSetup("new-src", "dst")
+	current.localConnection.GetContext().GetIpContext().SrcIpAddr = "10.0.0.5/30"
+	g.Expect(onlyKeysChanged(previous, current)).To(gomega.BeFalse())
+
+	current = wireguardSetup("new-src", "dst")
+	current.direction = INCOMING
+	g.Expect(onlyKeysChanged(previous, current)).To(gomega.BeFalse())
+
+	// Other mechanisms are not updated in place
+	current = wireguardSetup("src", "dst")
+	current.remoteConnection.GetMechanism().Type = vxlan.MECHANISM
+	previous.remoteConnection.GetMechanism().Type = vxlan.MECHANISM
+	g.Expect(onlyKeysChanged(previous, current)).To(gomega.BeFalse())
+}
@@ -35,8 +35,12 @@ import (
 type Metrics struct {
 	requestPeriod time.Duration
 	devices       *RegisteredDevices
+	deviceMetrics DeviceMetrics
 }
 
+// DeviceMetrics returns metrics of a device kept by the forwarding plane rather than the kernel, nil if there are none
+type DeviceMetrics func(device string) map[string]string
+
 // RegisteredDevices keeps track of all devices created by the forwarding plane
 type RegisteredDevices struct {
 	sync.Mutex
@@ -62,20 +66,25 @@ func (m *Metrics) GetDevices() *RegisteredDevices {
 	return m.devices
 }
 
+// SetDeviceMetrics sets metrics of devices added to the kernel ones, it is to be called before the monitoring is started
+func (m *Metrics) SetDeviceMetrics(deviceMetrics DeviceMetrics) {
+	m.deviceMetrics = deviceMetrics
+}
+
 // Start starts the monitoring
 func (m *Metrics) Start(monitor metrics.MetricsMonitor) {
 	logrus.Info("metrics: monitoring started")
-	go serveMetrics(monitor, m.requestPeriod, m.devices)
+	go serveMetrics(monitor, m.requestPeriod, m.devices, m.deviceMetrics)
 }
 
 // serveMetrics aims to be started as a Go routine
-func serveMetrics(monitor metrics.MetricsMonitor, requestPeriod time.Duration, devices *RegisteredDevices) {
+func serveMetrics(monitor metrics.MetricsMonitor, requestPeriod time.Duration, devices *RegisteredDevices, deviceMetrics DeviceMetrics) {
 	for {
 		/* No need to process anything if list is empty */
 		if len(devices.devices) != 0 {
 			devices.Lock()
 			/* Collect metrics for all present devices */
-			stats, err := collectMetrics(devices)
+			stats, err := collectMetrics(devices, deviceMetrics)
 			devices.Unlock()
 			if err != nil {
 				logrus.Warn("metrics: failed to collect metrics:", err)
@@ -90,7 +99,7 @@ func serveMetrics(monitor metrics.MetricsMonitor, requestPeriod time.Duration, d
 }
 
 // collectMetrics loops over each device and extracts the metrics for it
-func collectMetrics(devices *RegisteredDevices) (map[string]*crossconnect.Metrics, error) {
+func collectMetrics(devices *RegisteredDevices, deviceMetrics DeviceMetrics) (map[string]*crossconnect.Metrics, error) {
 	/* Store the metrics for all registered devices here */
 	stats := make(map[string]*crossconnect.Metrics)
 	failedDevices := make(map[string][]Device)
@@ -103,6 +112,11 @@ func collectMetrics(devices *RegisteredDevices) (map[string]*crossconnect.Metric
 				logrus.Warnf("metrics: removing device %s from device list", device.Name)
 				failedDevices[namespace] = append(failedDevices[namespace], device)
 			} else {
+				if deviceMetrics != nil {
+					for name, value := range deviceMetrics(device.Name) {
+						metrics[name] = value
+					}
+				}
 				logrus.Infof("metrics: device %s@%s, metrics - %v", device.Name, namespace, metrics)
 				stats[generateMetricsName(device)] = &crossconnect.Metrics{Metrics: metrics}
 			}
@@ -21,6 +21,8 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
@@ -33,6 +35,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
+	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	monitor_crossconnect "github.com/networkservicemesh/networkservicemesh/sdk/monitor/crossconnect"
 )
@@ -62,6 +65,19 @@ const (
 	ForwarderSocketTypeKey               = "FORWARDER_SOCKET_TYPE"
 	ForwarderSocketTypeDefault           = "unix"
 	ForwarderSrcIPKey                    = "NSM_FORWARDER_SRC_IP"
+	ForwarderDrainTimeoutKey             = "FORWARDER_DRAIN_TIMEOUT"
+	ForwarderDrainTimeoutDefault         = time.Duration(0)
+	ForwarderVersionKey                  = "FORWARDER_VERSION"
+	ForwarderVersionDefault              = ""
+	ForwarderMaxCrossConnectsKey         = "FORWARDER_MAX_CROSS_CONNECTS"
+	ForwarderMaxCrossConnectsDefault     = 0
+	ForwarderLabelsKey                   = "FORWARDER_LABELS"
+	ForwarderLabelsDefault               = ""
+
+	ForwarderHeartbeatIntervalKey          = "FORWARDER_HEARTBEAT_INTERVAL"
+	ForwarderHeartbeatIntervalDefault      = forwarderregistrar.DefaultHeartbeatInterval
+	ForwarderHeartbeatMissThresholdKey     = "FORWARDER_HEARTBEAT_MISS_THRESHOLD"
+	ForwarderHeartbeatMissThresholdDefault = forwarderregistrar.DefaultHeartbeatMissThreshold
 )
 
 // ForwarderConfig keeps the common configuration for a forwarding plane
@@ -76,11 +92,25 @@ type ForwarderConfig struct {
 	Mechanisms              *Mechanisms
 	MetricsEnabled          bool
 	MetricsPeriod           time.Duration
+	DrainTimeout            time.Duration
+	HeartbeatInterval       time.Duration
+	HeartbeatMissThreshold  int
 	SrcIP                   net.IP
 	EgressInterface         EgressInterfaceType
 	GRPCserver              *grpc.Server
 	Monitor                 monitor_crossconnect.MonitorServer
 	Listener                net.Listener
+	WireguardKeys           *WireguardKeys
+	// mechanismsMutex guards Mechanisms updated by MonitorMechanisms while Wireguard key rotation reads them, so they
+	// are accessed with GetMechanisms and SetMechanisms once the forwarder is configured
+	mechanismsMutex sync.RWMutex
+
+	// Implementation is a type of the forwarder set on Init, Capabilities are derived from Mechanisms if not set on Init
+	Implementation   string
+	Version          string
+	Capabilities     []*forwarderregistrar.MechanismCapability
+	MaxCrossConnects uint32
+	Labels           map[string]string
 }
 
 // Mechanisms is a message used to communicate any changes in operational parameters and constraints
@@ -89,6 +119,22 @@ type Mechanisms struct {
 	LocalMechanisms  []*connection.Mechanism
 }
 
+// GetMechanisms returns mechanisms currently advertised by the forwarder
+func (c *ForwarderConfig) GetMechanisms() *Mechanisms {
+	c.mechanismsMutex.RLock()
+	defer c.mechanismsMutex.RUnlock()
+
+	return c.Mechanisms
+}
+
+// SetMechanisms sets mechanisms advertised by the forwarder
+func (c *ForwarderConfig) SetMechanisms(mechanisms *Mechanisms) {
+	c.mechanismsMutex.Lock()
+	defer c.mechanismsMutex.Unlock()
+
+	c.Mechanisms = mechanisms
+}
+
 func getEnvWithDefault(span spanhelper.SpanHelper, env, defaultValue string) string {
 	result, ok := os.LookupEnv(env)
 	if !ok {
@@ -152,6 +198,48 @@ func createForwarderConfig(ctx context.Context, forwarderGoals *ForwarderProbeGo
 		span.Logger().Infof("MetricsPeriod: %v ", cfg.MetricsPeriod)
 	}
 
+	cfg.DrainTimeout = ForwarderDrainTimeoutDefault
+	if val, ok := os.LookupEnv(ForwarderDrainTimeoutKey); ok {
+		parsedTimeout, err := time.ParseDuration(val)
+		if err == nil {
+			cfg.DrainTimeout = parsedTimeout
+		}
+	}
+	span.Logger().Infof("DrainTimeout: %v ", cfg.DrainTimeout)
+
+	cfg.HeartbeatInterval = ForwarderHeartbeatIntervalDefault
+	if val, ok := os.LookupEnv(ForwarderHeartbeatIntervalKey); ok {
+		parsedInterval, err := time.ParseDuration(val)
+		if err != nil || parsedInterval <= 0 {
+			span.Logger().Fatalf("Env variable %s must be set to a positive duration, was set to %s", ForwarderHeartbeatIntervalKey, val)
+		}
+		cfg.HeartbeatInterval = parsedInterval
+	}
+	cfg.HeartbeatMissThreshold = ForwarderHeartbeatMissThresholdDefault
+	if val, ok := os.LookupEnv(ForwarderHeartbeatMissThresholdKey); ok {
+		missThreshold, err := strconv.ParseUint(val, 10, 32)
+		if err != nil || missThreshold == 0 {
+			span.Logger().Fatalf("Env variable %s must be set to a positive number, was set to %s", ForwarderHeartbeatMissThresholdKey, val)
+		}
+		cfg.HeartbeatMissThreshold = int(missThreshold)
+	}
+	span.Logger().Infof("HeartbeatInterval: %v, HeartbeatMissThreshold: %v ", cfg.HeartbeatInterval, cfg.HeartbeatMissThreshold)
+
+	cfg.Version = getEnvWithDefault(span, ForwarderVersionKey, ForwarderVersionDefault)
+	cfg.MaxCrossConnects = ForwarderMaxCrossConnectsDefault
+	if val, ok := os.LookupEnv(ForwarderMaxCrossConnectsKey); ok {
+		maxCrossConnects, err := strconv.ParseUint(val, 10, 32)
+		if err != nil {
+			span.Logger().Fatalf("Env variable %s must be set to a non negative number, was set to %s", ForwarderMaxCrossConnectsKey, val)
+		}
+		cfg.MaxCrossConnects = uint32(maxCrossConnects)
+	}
+	labels, err := parseLabels(getEnvWithDefault(span, ForwarderLabelsKey, ForwarderLabelsDefault))
+	if err != nil {
+		span.Logger().Fatalf("Env variable %s must be set to comma separated key=value labels: %v", ForwarderLabelsKey, err)
+	}
+	cfg.Labels = labels
+
 	srcIPStr, ok := os.LookupEnv(ForwarderSrcIPKey)
 	if !ok {
 		span.Logger().Fatalf("Env variable %s must be set to valid srcIP for use for tunnels from this Pod.  Consider using downward API to do so.", ForwarderSrcIPKey)
@@ -164,7 +252,6 @@ func createForwarderConfig(ctx context.Context, forwarderGoals *ForwarderProbeGo
 	} else {
 		forwarderGoals.SetValidIPReady()
 	}
-	var err error
 	cfg.EgressInterface, err = NewEgressInterface(cfg.SrcIP)
 	if err != nil {
 		span.Logger().Fatalf("Unable to find egress Interface: %s", err)
@@ -172,6 +259,9 @@ func createForwarderConfig(ctx context.Context, forwarderGoals *ForwarderProbeGo
 		forwarderGoals.SetNewEgressIFReady()
 	}
 	span.Logger().Infof("SrcIP: %s, IfaceName: %s, SrcIPNet: %s", cfg.SrcIP, cfg.EgressInterface.Name(), cfg.EgressInterface.SrcIPNet())
+	if cfg.WireguardKeys, err = NewWireguardKeys(); err != nil {
+		span.Logger().Fatalf("Unable to create Wireguard keys: %s", err)
+	}
 	span.LogObject("config", cfg)
 	return cfg
 }
@@ -218,12 +308,53 @@ func CreateForwarder(ctx context.Context, dp NSMForwarder, forwarderGoals *Forwa
 	span.Logger().Infof("%s server serving", config.Name)
 	span.Logger().Info("Creating Forwarder Registrar Client...")
 	registrar := NewForwarderRegistrarClient(config.RegistrarSocketType, config.RegistrarSocket)
-	registration := registrar.Register(span.Context(), config.Name, config.ForwarderSocket, nil, nil)
+	registrar.heartbeatInterval = config.HeartbeatInterval
+	registrar.heartbeatMissThreshold = config.HeartbeatMissThreshold
+	registrar.rotateWireguardKey = func(ctx context.Context) error {
+		return rotateWireguardKey(ctx, config)
+	}
+	registration, err := registrar.Register(span.Context(), config.Name, config.ForwarderSocket, forwarderCapabilities(config), nil, nil)
+	if err != nil {
+		span.Logger().Fatalf("Forwarder registration failed: %v", err)
+	}
+	registration.drainTimeout = config.DrainTimeout
 	span.Logger().Info("Registered Forwarder Registrar Client")
 
 	return registration
 }
 
+// parseLabels parses comma separated key=value labels
+func parseLabels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid label %q", pair)
+		}
+		labels[kv[0]] = kv[1]
+	}
+	return labels, nil
+}
+
+// forwarderCapabilities returns capabilities forwarder advertises on registration
+func forwarderCapabilities(config *ForwarderConfig) *ForwarderCapabilities {
+	capabilities := config.Capabilities
+	if mechanisms := config.GetMechanisms(); capabilities == nil && mechanisms != nil {
+		capabilities = append(mechanismCapabilities(mechanisms.LocalMechanisms, false),
+			mechanismCapabilities(mechanisms.RemoteMechanisms, true)...)
+	}
+	return &ForwarderCapabilities{
+		Implementation:   config.Implementation,
+		Version:          config.Version,
+		Mechanisms:       capabilities,
+		MaxCrossConnects: config.MaxCrossConnects,
+		Labels:           config.Labels,
+	}
+}
+
 func sanityCheckConfig(forwarderConfig *ForwarderConfig) bool {
 	return len(forwarderConfig.Name) > 0 &&
 		len(forwarderConfig.NSMBaseDir) > 0 &&
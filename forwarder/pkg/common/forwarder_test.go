@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
+)
+
+func TestParseLabels(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	labels, err := parseLabels("")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(labels).To(gomega.BeEmpty())
+
+	labels, err = parseLabels("zone=a, tier=fast,empty=")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(labels).To(gomega.Equal(map[string]string{"zone": "a", "tier": "fast", "empty": ""}))
+
+	_, err = parseLabels("zone")
+	g.Expect(err).NotTo(gomega.BeNil())
+	_, err = parseLabels("=a")
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestForwarderCapabilities(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	config := &ForwarderConfig{
+		Implementation:   "kernel",
+		MaxCrossConnects: 10,
+		Mechanisms: &Mechanisms{
+			LocalMechanisms: []*connection.Mechanism{
+				{Type: "KERNEL_INTERFACE"},
+			},
+			RemoteMechanisms: []*connection.Mechanism{
+				{Type: "VXLAN", Parameters: map[string]string{"src_ip": "10.0.0.1"}},
+				{Type: "CUSTOM", Parameters: map[string]string{"b": "2", "a": "1"}},
+			},
+		},
+	}
+	capabilities := forwarderCapabilities(config)
+	g.Expect(capabilities.Implementation).To(gomega.Equal("kernel"))
+	g.Expect(capabilities.MaxCrossConnects).To(gomega.Equal(uint32(10)))
+	g.Expect(capabilities.Mechanisms).To(gomega.Equal([]*forwarderregistrar.MechanismCapability{
+		{
+			Type: "KERNEL_INTERFACE",
+			Parameters: []*forwarderregistrar.MechanismParameter{
+				{Name: "netnsInode", Type: "string", Required: true},
+				{Name: "name", Type: "string"},
+				{Name: "description", Type: "string"},
+				{Name: "workspace", Type: "string"},
+			},
+		},
+		{
+			Type:   "VXLAN",
+			Remote: true,
+			Parameters: []*forwarderregistrar.MechanismParameter{
+				{Name: "src_ip", Type: "ip", Required: true},
+				{Name: "dst_ip", Type: "ip", Required: true},
+				{Name: "vni", Type: "uint32", Required: true},
+			},
+		},
+		{
+			Type:   "CUSTOM",
+			Remote: true,
+			Parameters: []*forwarderregistrar.MechanismParameter{
+				{Name: "a", Type: "string"},
+				{Name: "b", Type: "string"},
+			},
+		},
+	}))
+}
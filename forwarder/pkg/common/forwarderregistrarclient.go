@@ -20,8 +20,12 @@ import (
 	"os"
 	"time"
 
-	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
@@ -35,6 +39,12 @@ var (
 type ForwarderRegistrarClient struct {
 	registrationRetryInterval time.Duration
 	registrarSocket           net.Addr
+	// heartbeatInterval is an interval of heartbeats sent to NSM, NSM is considered hung after
+	// heartbeatMissThreshold of its heartbeats are missed in a row
+	heartbeatInterval      time.Duration
+	heartbeatMissThreshold int
+	// rotateWireguardKey rotates Wireguard key of the forwarder, it is called every interval NSM sets on registration
+	rotateWireguardKey func(ctx context.Context) error
 }
 
 // ForwarderRegistration contains Forwarder registrar client info and connection events callbacks
@@ -42,34 +52,57 @@ type ForwarderRegistration struct {
 	registrar       *ForwarderRegistrarClient
 	forwarderName   string
 	forwarderSocket string
+	capabilities    *ForwarderCapabilities
 	cancelFunc      context.CancelFunc
 	onConnect       OnConnectFunc
 	onDisconnect    OnDisConnectFunc
 	client          forwarderregistrar.ForwarderRegistrationClient
 	wasRegistered   bool
+	// nsmProtocolVersion is a version of the registrar protocol used by NSM the forwarder is registered with
+	nsmProtocolVersion uint32
+	// livenessToken is issued by NSM on registration, it binds the liveness stream to the registration
+	livenessToken string
+	// drainTimeout is a time NSM is given to migrate connections from the forwarder on Close, zero disables draining
+	drainTimeout time.Duration
+}
+
+// ForwarderCapabilities describes forwarder to NSM on registration
+type ForwarderCapabilities struct {
+	Implementation   string
+	Version          string
+	Mechanisms       []*forwarderregistrar.MechanismCapability
+	MaxCrossConnects uint32
+	Labels           map[string]string
 }
 
 type OnConnectFunc func() error
 type OnDisConnectFunc func() error
 
-func (dr *ForwarderRegistration) register(ctx context.Context) {
+// register retries registration with NSM until it succeeds or ctx is done, registration rejected by NSM for
+// incompatible protocol version is not retried and its error is returned
+func (dr *ForwarderRegistration) register(ctx context.Context) error {
 	logrus.Info("Registering with NetworkServiceManager")
 	logrus.Infof("Retry interval: %s", dr.registrar.registrationRetryInterval)
 
 	// Wait fo NSMD to be ready to register forwarder.
 	_ = tools.WaitForPortAvailable(context.Background(), dr.registrar.registrarSocket.Network(), dr.registrar.registrarSocket.String(), 100*time.Millisecond)
 	ticker := time.NewTicker(dr.registrar.registrationRetryInterval)
+	defer ticker.Stop()
 	for ; true; <-ticker.C {
 		select {
 		case <-ctx.Done():
-			return
+			return nil
 		default:
 			err := dr.tryRegistration(ctx)
 			if err == nil {
-				return
+				return nil
+			}
+			if status.Code(err) == codes.FailedPrecondition {
+				return errors.Wrapf(err, "%s: registration is rejected by NSM", dr.forwarderName)
 			}
 		}
 	}
+	return nil
 }
 
 func (dr *ForwarderRegistration) tryRegistration(ctx context.Context) error {
@@ -95,56 +128,156 @@ func (dr *ForwarderRegistration) tryRegistration(ctx context.Context) error {
 	req := &forwarderregistrar.ForwarderRegistrationRequest{
 		ForwarderName:   dr.forwarderName,
 		ForwarderSocket: dr.forwarderSocket,
+		ProtocolVersion: forwarderregistrar.ProtocolVersion,
+	}
+	if dr.capabilities != nil {
+		req.Implementation = dr.capabilities.Implementation
+		req.Version = dr.capabilities.Version
+		req.Mechanisms = dr.capabilities.Mechanisms
+		req.MaxCrossConnects = dr.capabilities.MaxCrossConnects
+		req.Labels = dr.capabilities.Labels
 	}
-	_, err = dr.client.RequestForwarderRegistration(ctx, req)
+	reply, err := dr.client.RequestForwarderRegistration(ctx, req)
 	logrus.Infof("%s: send request to Forwarder Registrar: %+v", dr.forwarderName, req)
 	if err != nil {
 		logrus.Infof("%s: failure to create grpc client for RequestForwarderRegistration on socket %v", dr.forwarderName, dr.registrar.registrarSocket)
 		return err
 	}
+	dr.nsmProtocolVersion = reply.GetProtocolVersion()
+	dr.livenessToken = reply.GetLivenessToken()
 	if dr.onConnect != nil {
 		dr.onConnect()
 		dr.wasRegistered = true
 	}
-	go dr.livenessMonitor(ctx)
+	rotationCtx, cancelRotation := context.WithCancel(ctx)
+	go dr.wireguardKeyRotation(rotationCtx, reply.GetWireguardKeyRotationInterval())
+	go func() {
+		defer cancelRotation()
+		dr.livenessMonitor(ctx)
+	}()
 	return nil
 }
 
-// livenessMonitor is a stream initiated by NSM to inform the forwarder that NSM is still alive and
-// no re-registration is required. Detection a failure on this "channel" will mean
-// that NSM is gone and the forwarder needs to start re-registration logic.
+// wireguardKeyRotation rotates Wireguard key of the forwarder every interval until ctx is done, NSM not setting
+// the interval does not renegotiate connections, so the key is not rotated
+func (dr *ForwarderRegistration) wireguardKeyRotation(ctx context.Context, intervalProto *duration.Duration) {
+	if dr.registrar.rotateWireguardKey == nil || intervalProto == nil {
+		return
+	}
+	interval, err := ptypes.Duration(intervalProto)
+	if err != nil || interval <= 0 {
+		logrus.Errorf("%s: invalid Wireguard key rotation interval %v: %v", dr.forwarderName, intervalProto, err)
+		return
+	}
+	logrus.Infof("%s: rotating Wireguard key every %v", dr.forwarderName, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := dr.registrar.rotateWireguardKey(ctx); err != nil {
+				logrus.Errorf("%s: failed to rotate Wireguard key: %v", dr.forwarderName, err)
+			}
+		}
+	}
+}
+
+// livenessMonitor is a bidirectional heartbeat stream between NSM and the forwarder to inform both of them
+// that the peer is still alive and no re-registration is required. Detection a failure or missed heartbeats
+// on this "channel" will mean that NSM is gone and the forwarder needs to start re-registration logic.
 func (dr *ForwarderRegistration) livenessMonitor(ctx context.Context) {
 	logrus.Infof("Starting ForwarderRegistrarClient liveliness monitor")
-	stream, err := dr.client.RequestLiveness(context.Background())
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if dr.livenessToken != "" {
+		streamCtx = metadata.AppendToOutgoingContext(streamCtx, forwarderregistrar.LivenessTokenKey, dr.livenessToken)
+	}
+	stream, err := dr.client.RequestLiveness(streamCtx)
 	if err != nil {
 		logrus.Errorf("%s: fail to create liveness grpc channel with NSM with error: %s, grpc code: %+v", dr.forwarderName, err.Error(), status.Convert(err).Code())
 		return
 	}
+	if err := dr.heartbeat(ctx, stream); err != nil {
+		logrus.Errorf("%s: liveness of NSM is lost: %v", dr.forwarderName, err)
+		dr.disconnect()
+		// Use base ctx, to not go into deep
+		go func() {
+			if err := dr.register(ctx); err != nil {
+				logrus.Errorf("%s: forwarder is not registered with NSM anymore: %v", dr.forwarderName, err)
+			}
+		}()
+		return
+	}
+	logrus.Infof("ForwarderRegistrarClient cancelled, cleaning up")
+	dr.disconnect()
+}
+
+// heartbeat exchanges heartbeats with NSM until ctx is done, returns error if the stream fails or NSM misses
+// configured amount of heartbeats in a row. Heartbeats are not sent to NSM not reading them.
+func (dr *ForwarderRegistration) heartbeat(ctx context.Context, stream forwarderregistrar.ForwarderRegistration_RequestLivenessClient) error {
+	heartbeats := make(chan *forwarderregistrar.Heartbeat)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			heartbeat, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case heartbeats <- heartbeat:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	interval := dr.registrar.heartbeatInterval
+	sendHeartbeats := dr.nsmProtocolVersion >= forwarderregistrar.HeartbeatProtocolVersion
+	logrus.Infof("%s: NSM protocol version %v, sending heartbeats: %v, interval %v", dr.forwarderName, dr.nsmProtocolVersion, sendHeartbeats, interval)
+
+	tracker := forwarderregistrar.NewHeartbeatTracker(dr.registrar.heartbeatMissThreshold)
+	tracker.Start(time.Now())
+	sequence := uint64(0)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
-			logrus.Infof("ForwarderRegistrarClient cancelled, cleaning up")
-			if dr.onDisconnect != nil {
-				dr.onDisconnect()
-				dr.wasRegistered = false
+			return nil
+		case err := <-recvErr:
+			return errors.Wrapf(err, "fail to receive from liveness grpc channel, grpc code: %+v", status.Convert(err).Code())
+		case heartbeat := <-heartbeats:
+			if lost := tracker.Receive(heartbeat, time.Now()); lost > 0 {
+				logrus.Warnf("%s: NSM heartbeats lost: %v, last received sequence %v", dr.forwarderName, lost, heartbeat.GetSequence())
 			}
-			return
-		default:
-			err := stream.RecvMsg(&empty.Empty{})
-			if err != nil {
-				logrus.Errorf("%s: fail to receive from liveness grpc channel with error: %s, grpc code: %+v", dr.forwarderName, err.Error(), status.Convert(err).Code())
-				if dr.onConnect != nil {
-					dr.onDisconnect()
-					dr.wasRegistered = false
-				}
-				go dr.register(ctx) // Use base ctx, to not go into deep
-				return
+		case <-ticker.C:
+			if tracker.Expired(time.Now()) {
+				return errors.Errorf("no heartbeats received for %v, last received sequence %v", tracker.Deadline(), tracker.LastSequence())
+			}
+			if !sendHeartbeats {
+				continue
+			}
+			sequence++
+			if err := stream.Send(tracker.NewHeartbeat(dr.forwarderName, sequence, interval)); err != nil {
+				return errors.Wrapf(err, "fail to send to liveness grpc channel, grpc code: %+v", status.Convert(err).Code())
 			}
 		}
 	}
 }
 
-// Close forwarder registrar client
+// disconnect informs the forwarder it is no longer registered with NSM
+func (dr *ForwarderRegistration) disconnect() {
+	if dr.onDisconnect != nil {
+		dr.onDisconnect()
+		dr.wasRegistered = false
+	}
+}
+
+// Close forwarder registrar client, if drain timeout is set, waits for NSM to migrate connections from the forwarder
 func (dr *ForwarderRegistration) Close() {
 	dr.cancelFunc()
 
@@ -160,18 +293,33 @@ func (dr *ForwarderRegistration) Close() {
 		logrus.Infof("%s: connection to forwarder registrar socket %v succeeded.", dr.forwarderName, dr.registrar.registrarSocket)
 		client := forwarderregistrar.NewForwarderUnRegistrationClient(conn)
 
-		unregCtx, unRegCancel := context.WithTimeout(context.Background(), 1*time.Second)
+		unregTimeout := 1 * time.Second
+		if dr.drainTimeout > 0 {
+			logrus.Infof("%s: draining connections for %v", dr.forwarderName, dr.drainTimeout)
+			unregTimeout = dr.drainTimeout
+		}
+		unregCtx, unRegCancel := context.WithTimeout(context.Background(), unregTimeout)
 		defer unRegCancel()
 
-		_, _ = client.RequestForwarderUnRegistration(unregCtx, &forwarderregistrar.ForwarderUnRegistrationRequest{
+		reply, err := client.RequestForwarderUnRegistration(unregCtx, &forwarderregistrar.ForwarderUnRegistrationRequest{
 			ForwarderName: dr.forwarderName,
+			Drain:         dr.drainTimeout > 0,
 		})
+		if err != nil {
+			logrus.Errorf("%s: failure to un-register with error: %+v", dr.forwarderName, err)
+			return
+		}
+		if dr.drainTimeout > 0 {
+			logrus.Infof("%s: un-registered, connections drained: %v", dr.forwarderName, reply.Drained)
+		}
 	}
 }
 
 func NewForwarderRegistrarClient(network, registrarSocket string) *ForwarderRegistrarClient {
 	return &ForwarderRegistrarClient{
 		registrationRetryInterval: registrationRetryInterval,
+		heartbeatInterval:         forwarderregistrar.DefaultHeartbeatInterval,
+		heartbeatMissThreshold:    forwarderregistrar.DefaultHeartbeatMissThreshold,
 		registrarSocket: &net.UnixAddr{
 			Name: registrarSocket,
 			Net:  network,
@@ -179,17 +327,22 @@ func NewForwarderRegistrarClient(network, registrarSocket string) *ForwarderRegi
 	}
 }
 
-// Register creates and register new ForwarderRegistration client
-func (n *ForwarderRegistrarClient) Register(ctx context.Context, forwarderName, forwarderSocket string, onConnect OnConnectFunc, onDisconnect OnDisConnectFunc) *ForwarderRegistration {
+// Register creates and register new ForwarderRegistration client, capabilities are advertised to NSM if set.
+// Returns error if NSM rejects registration for incompatible protocol version.
+func (n *ForwarderRegistrarClient) Register(ctx context.Context, forwarderName, forwarderSocket string, capabilities *ForwarderCapabilities, onConnect OnConnectFunc, onDisconnect OnDisConnectFunc) (*ForwarderRegistration, error) {
 	ctx, cancelFunc := context.WithCancel(ctx)
 	rv := &ForwarderRegistration{
 		registrar:       n,
 		forwarderName:   forwarderName,
 		forwarderSocket: forwarderSocket,
+		capabilities:    capabilities,
 		onConnect:       onConnect,
 		onDisconnect:    onDisconnect,
 		cancelFunc:      cancelFunc,
 	}
-	rv.register(ctx)
-	return rv
+	if err := rv.register(ctx); err != nil {
+		cancelFunc()
+		return nil, err
+	}
+	return rv, nil
 }
@@ -0,0 +1,192 @@
+package common
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+// livenessClientStub is a forwarder side of liveness stream, NSM heartbeats are received from recv
+type livenessClientStub struct {
+	grpc.ClientStream
+	ctx  context.Context
+	recv chan *forwarderregistrar.Heartbeat
+	sent chan *forwarderregistrar.Heartbeat
+}
+
+func newLivenessClientStub(ctx context.Context) *livenessClientStub {
+	return &livenessClientStub{
+		ctx:  ctx,
+		recv: make(chan *forwarderregistrar.Heartbeat),
+		sent: make(chan *forwarderregistrar.Heartbeat, 100),
+	}
+}
+
+func (s *livenessClientStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *livenessClientStub) Send(heartbeat *forwarderregistrar.Heartbeat) error {
+	s.sent <- heartbeat
+	return nil
+}
+
+func (s *livenessClientStub) Recv() (*forwarderregistrar.Heartbeat, error) {
+	select {
+	case heartbeat := <-s.recv:
+		return heartbeat, nil
+	case <-s.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func newHeartbeatTestRegistration(nsmProtocolVersion uint32) *ForwarderRegistration {
+	registrar := NewForwarderRegistrarClient("unix", "registrar.sock")
+	registrar.heartbeatInterval = 50 * time.Millisecond
+	registrar.heartbeatMissThreshold = 2
+	return &ForwarderRegistration{
+		registrar:          registrar,
+		forwarderName:      "forwarder",
+		nsmProtocolVersion: nsmProtocolVersion,
+	}
+}
+
+func TestHeartbeatHungNSM(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newLivenessClientStub(ctx)
+	registration := newHeartbeatTestRegistration(forwarderregistrar.ProtocolVersion)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- registration.heartbeat(ctx, stream)
+	}()
+	// NSM sends a single heartbeat and hangs
+	stream.recv <- &forwarderregistrar.Heartbeat{
+		Sequence: 1,
+		Interval: ptypes.DurationProto(50 * time.Millisecond),
+	}
+
+	var err error
+	g.Eventually(result, time.Second).Should(gomega.Receive(&err))
+	g.Expect(err).NotTo(gomega.BeNil())
+
+	var heartbeat *forwarderregistrar.Heartbeat
+	g.Expect(stream.sent).To(gomega.Receive(&heartbeat))
+	g.Expect(heartbeat.ForwarderName).To(gomega.Equal("forwarder"))
+	g.Expect(heartbeat.Sequence).To(gomega.Equal(uint64(1)))
+	g.Expect(heartbeat.Ack).To(gomega.Equal(uint64(1)))
+}
+
+func TestHeartbeatLegacyNSM(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newLivenessClientStub(ctx)
+	registration := newHeartbeatTestRegistration(0)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- registration.heartbeat(ctx, stream)
+	}()
+	// NSM of protocol version 1 sends empty messages every 5 seconds and does not read heartbeats
+	for i := 0; i < 5; i++ {
+		stream.recv <- &forwarderregistrar.Heartbeat{}
+		<-time.After(40 * time.Millisecond)
+	}
+	g.Expect(result).NotTo(gomega.Receive())
+	g.Expect(stream.sent).To(gomega.BeEmpty())
+
+	cancel()
+	var err error
+	g.Eventually(result).Should(gomega.Receive(&err))
+	g.Expect(err).To(gomega.BeNil())
+}
+
+func TestWireguardKeyRotation(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	rotations := make(chan struct{}, 10)
+	registration := newHeartbeatTestRegistration(forwarderregistrar.ProtocolVersion)
+	registration.registrar.rotateWireguardKey = func(ctx context.Context) error {
+		rotations <- struct{}{}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		registration.wireguardKeyRotation(ctx, ptypes.DurationProto(10*time.Millisecond))
+	}()
+	g.Eventually(rotations, time.Second).Should(gomega.Receive())
+	g.Eventually(rotations, time.Second).Should(gomega.Receive())
+	cancel()
+	g.Eventually(done, time.Second).Should(gomega.BeClosed())
+
+	// NSM does not set the interval, so the key is not rotated
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		registration.wireguardKeyRotation(context.Background(), nil)
+	}()
+	g.Eventually(done, time.Second).Should(gomega.BeClosed())
+}
+
+// rejectingRegistrarStub rejects registrations for incompatible protocol version
+type rejectingRegistrarStub struct {
+	forwarderregistrar.ForwarderRegistrationServer
+	requests int32
+}
+
+func (s *rejectingRegistrarStub) RequestForwarderRegistration(context.Context, *forwarderregistrar.ForwarderRegistrationRequest) (*forwarderregistrar.ForwarderRegistrationReply, error) {
+	atomic.AddInt32(&s.requests, 1)
+	return nil, status.Error(codes.FailedPrecondition, "forwarder protocol version is not supported")
+}
+
+func TestRegisterRejected(t *testing.T) {
+	g := gomega.NewWithT(t)
+	_ = os.Setenv(tools.InsecureEnv, "true")
+
+	dir, err := ioutil.TempDir("", "forwarder-registrar")
+	g.Expect(err).To(gomega.BeNil())
+	defer func() { _ = os.RemoveAll(dir) }()
+	socket := filepath.Join(dir, "registrar.sock")
+	listener, err := net.Listen("unix", socket)
+	g.Expect(err).To(gomega.BeNil())
+	server := grpc.NewServer()
+	stub := &rejectingRegistrarStub{}
+	forwarderregistrar.RegisterForwarderRegistrationServer(server, stub)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	registrar := NewForwarderRegistrarClient("unix", socket)
+	registrar.registrationRetryInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	registration, err := registrar.Register(ctx, "forwarder", "forwarder.sock", nil, nil, nil)
+	g.Expect(registration).To(gomega.BeNil())
+	g.Expect(status.Code(errors.Cause(err))).To(gomega.Equal(codes.FailedPrecondition))
+	// Rejected registration is not retried
+	time.Sleep(100 * time.Millisecond)
+	g.Expect(atomic.LoadInt32(&stub.requests)).To(gomega.Equal(int32(1)))
+}
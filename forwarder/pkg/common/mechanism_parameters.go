@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"sort"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/gre"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/memif"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
+)
+
+// Types of mechanism parameter values
+const (
+	// ParameterTypeString - any string value
+	ParameterTypeString = "string"
+	// ParameterTypeIP - IP address
+	ParameterTypeIP = "ip"
+	// ParameterTypeUint32 - decimal unsigned 32 bit integer
+	ParameterTypeUint32 = "uint32"
+)
+
+// mechanismParameters - parameters of connections using mechanism of the type, required ones have to be set for the
+// forwarder to program the connection
+var mechanismParameters = map[string][]*forwarderregistrar.MechanismParameter{
+	kernel.MECHANISM: {
+		{Name: mechanismCommon.NetNsInodeKey, Type: ParameterTypeString, Required: true},
+		{Name: mechanismCommon.InterfaceNameKey, Type: ParameterTypeString},
+		{Name: mechanismCommon.InterfaceDescriptionKey, Type: ParameterTypeString},
+		{Name: mechanismCommon.Workspace, Type: ParameterTypeString},
+	},
+	memif.MECHANISM: {
+		{Name: mechanismCommon.NetNsInodeKey, Type: ParameterTypeString, Required: true},
+		{Name: mechanismCommon.Workspace, Type: ParameterTypeString, Required: true},
+		{Name: memif.SocketFilename, Type: ParameterTypeString, Required: true},
+		{Name: mechanismCommon.InterfaceNameKey, Type: ParameterTypeString},
+		{Name: mechanismCommon.InterfaceDescriptionKey, Type: ParameterTypeString},
+	},
+	vxlan.MECHANISM: {
+		{Name: mechanismCommon.SrcIP, Type: ParameterTypeIP, Required: true},
+		{Name: mechanismCommon.DstIP, Type: ParameterTypeIP, Required: true},
+		{Name: vxlan.VNI, Type: ParameterTypeUint32, Required: true},
+	},
+	gre.MECHANISM: {
+		{Name: mechanismCommon.SrcIP, Type: ParameterTypeIP, Required: true},
+		{Name: mechanismCommon.DstIP, Type: ParameterTypeIP, Required: true},
+		{Name: gre.Key, Type: ParameterTypeUint32, Required: true},
+		{Name: gre.Mode, Type: ParameterTypeString},
+	},
+	wireguard.MECHANISM: {
+		{Name: mechanismCommon.SrcIP, Type: ParameterTypeIP, Required: true},
+		{Name: mechanismCommon.DstIP, Type: ParameterTypeIP, Required: true},
+		{Name: wireguard.SrcPort, Type: ParameterTypeUint32, Required: true},
+		{Name: wireguard.DstPort, Type: ParameterTypeUint32, Required: true},
+		{Name: wireguard.SrcPublicKey, Type: ParameterTypeString, Required: true},
+		{Name: wireguard.DstPublicKey, Type: ParameterTypeString, Required: true},
+		{Name: wireguard.SrcOriginalIP, Type: ParameterTypeIP},
+		{Name: wireguard.DstExternalIP, Type: ParameterTypeIP},
+	},
+	srv6.MECHANISM: {
+		{Name: srv6.SrcHostIP, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.DstHostIP, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.SrcBSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.DstBSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.SrcLocalSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.DstLocalSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.SrcHostLocalSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.DstHostLocalSID, Type: ParameterTypeIP, Required: true},
+		{Name: srv6.SrcHardwareAddress, Type: ParameterTypeString, Required: true},
+		{Name: srv6.DstHardwareAddress, Type: ParameterTypeString, Required: true},
+	},
+}
+
+// mechanismCapabilities describes mechanisms along with parameters of connections using them, parameters of mechanism
+// types without known schema are described by the ones mechanism is advertised with
+func mechanismCapabilities(mechanisms []*connection.Mechanism, remote bool) []*forwarderregistrar.MechanismCapability {
+	var rv []*forwarderregistrar.MechanismCapability
+	for _, m := range mechanisms {
+		capability := &forwarderregistrar.MechanismCapability{
+			Type:   m.GetType(),
+			Remote: remote,
+		}
+		if parameters, ok := mechanismParameters[m.GetType()]; ok {
+			for _, p := range parameters {
+				capability.Parameters = append(capability.Parameters, &forwarderregistrar.MechanismParameter{
+					Name:     p.GetName(),
+					Type:     p.GetType(),
+					Required: p.GetRequired(),
+				})
+			}
+			rv = append(rv, capability)
+			continue
+		}
+		names := make([]string, 0, len(m.GetParameters()))
+		for name := range m.GetParameters() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			capability.Parameters = append(capability.Parameters, &forwarderregistrar.MechanismParameter{
+				Name: name,
+				Type: ParameterTypeString,
+			})
+		}
+		rv = append(rv, capability)
+	}
+	return rv
+}
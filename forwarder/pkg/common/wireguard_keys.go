@@ -0,0 +1,142 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+)
+
+// maxWireguardKeys is an amount of keys kept by the forwarder, connections are renegotiated by NSM within the rotation
+// interval, so keys older than two intervals are no longer used
+const maxWireguardKeys = 3
+
+// WireguardKeys keeps Wireguard private keys of the forwarder, only their public keys are advertised to NSM and
+// exchanged within connections, so the forwarder finds its private key by the public key of its side of a connection
+type WireguardKeys struct {
+	sync.RWMutex
+	current wgtypes.Key
+	keys    map[string]wgtypes.Key
+	// publicKeys are public keys of kept private keys in order of generation
+	publicKeys []string
+}
+
+// NewWireguardKeys creates WireguardKeys with a newly generated private key
+func NewWireguardKeys() (*WireguardKeys, error) {
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate Wireguard private key")
+	}
+	return &WireguardKeys{
+		current: key,
+		keys: map[string]wgtypes.Key{
+			key.PublicKey().String(): key,
+		},
+		publicKeys: []string{key.PublicKey().String()},
+	}, nil
+}
+
+// Rotate generates a new private key to be advertised for new connections and returns its public key, previous keys
+// are kept for connections not renegotiated yet
+func (k *WireguardKeys) Rotate() (string, error) {
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate Wireguard private key")
+	}
+	publicKey := key.PublicKey().String()
+
+	k.Lock()
+	defer k.Unlock()
+
+	k.current = key
+	k.keys[publicKey] = key
+	k.publicKeys = append(k.publicKeys, publicKey)
+	for len(k.publicKeys) > maxWireguardKeys {
+		delete(k.keys, k.publicKeys[0])
+		k.publicKeys = k.publicKeys[1:]
+	}
+	return publicKey, nil
+}
+
+// PublicKey returns the public key to be advertised for new connections
+func (k *WireguardKeys) PublicKey() string {
+	k.RLock()
+	defer k.RUnlock()
+
+	return k.current.PublicKey().String()
+}
+
+// PrivateKey returns the private key matching publicKey
+func (k *WireguardKeys) PrivateKey(publicKey string) (wgtypes.Key, error) {
+	k.RLock()
+	defer k.RUnlock()
+
+	key, ok := k.keys[publicKey]
+	if !ok {
+		return wgtypes.Key{}, errors.Errorf("no Wireguard private key matches public key %s", publicKey)
+	}
+	return key, nil
+}
+
+// rotateWireguardKey rotates Wireguard key of the forwarder and advertises the new public key to NSM by mechanisms
+// update, so NSM renegotiates connections established with the previous one
+func rotateWireguardKey(ctx context.Context, config *ForwarderConfig) error {
+	mechanisms := config.GetMechanisms()
+	if mechanisms == nil || config.MechanismsUpdateChannel == nil {
+		return errors.New("forwarder does not advertise mechanisms updates")
+	}
+	publicKey, err := config.WireguardKeys.Rotate()
+	if err != nil {
+		return err
+	}
+	update, ok := withWireguardPublicKey(mechanisms, publicKey)
+	if !ok {
+		return nil
+	}
+	select {
+	case config.MechanismsUpdateChannel <- update:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "Wireguard key is rotated, but not advertised")
+	}
+}
+
+// withWireguardPublicKey returns a copy of mechanisms advertising publicKey for the remote Wireguard mechanism,
+// false if the mechanism is not supported
+func withWireguardPublicKey(mechanisms *Mechanisms, publicKey string) (*Mechanisms, bool) {
+	found := false
+	rv := &Mechanisms{
+		LocalMechanisms: mechanisms.LocalMechanisms,
+	}
+	for _, m := range mechanisms.RemoteMechanisms {
+		if m.GetType() == wireguard.MECHANISM {
+			m = m.Clone()
+			if m.Parameters == nil {
+				m.Parameters = map[string]string{}
+			}
+			m.Parameters[wireguard.SrcPublicKey] = publicKey
+			found = true
+		}
+		rv.RemoteMechanisms = append(rv.RemoteMechanisms, m)
+	}
+	return rv, found
+}
@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+)
+
+func TestWireguardKeys(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	keys, err := NewWireguardKeys()
+	g.Expect(err).To(gomega.BeNil())
+
+	publicKey := keys.PublicKey()
+	privateKey, err := keys.PrivateKey(publicKey)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(privateKey.PublicKey().String()).To(gomega.Equal(publicKey))
+
+	other, err := NewWireguardKeys()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(other.PublicKey()).NotTo(gomega.Equal(publicKey))
+	_, err = keys.PrivateKey(other.PublicKey())
+	g.Expect(err).NotTo(gomega.BeNil())
+}
+
+func TestWireguardKeysRotate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	keys, err := NewWireguardKeys()
+	g.Expect(err).To(gomega.BeNil())
+
+	publicKeys := []string{keys.PublicKey()}
+	for i := 1; i < maxWireguardKeys+1; i++ {
+		publicKey, err := keys.Rotate()
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(publicKey).NotTo(gomega.Equal(publicKeys[i-1]))
+		g.Expect(keys.PublicKey()).To(gomega.Equal(publicKey))
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	// The oldest key is dropped, the rest are kept for connections not renegotiated yet
+	_, err = keys.PrivateKey(publicKeys[0])
+	g.Expect(err).NotTo(gomega.BeNil())
+	for _, publicKey := range publicKeys[1:] {
+		privateKey, err := keys.PrivateKey(publicKey)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(privateKey.PublicKey().String()).To(gomega.Equal(publicKey))
+	}
+}
+
+func TestRotateWireguardKey(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	keys, err := NewWireguardKeys()
+	g.Expect(err).To(gomega.BeNil())
+	previous := keys.PublicKey()
+	config := &ForwarderConfig{
+		WireguardKeys:           keys,
+		MechanismsUpdateChannel: make(chan *Mechanisms, 1),
+		Mechanisms: &Mechanisms{
+			RemoteMechanisms: []*connection.Mechanism{
+				{
+					Type:       vxlan.MECHANISM,
+					Parameters: map[string]string{vxlan.SrcIP: "10.0.0.1"},
+				},
+				{
+					Type: wireguard.MECHANISM,
+					Parameters: map[string]string{
+						wireguard.SrcIP:        "10.0.0.1",
+						wireguard.SrcPublicKey: previous,
+					},
+				},
+			},
+		},
+	}
+
+	g.Expect(rotateWireguardKey(context.Background(), config)).To(gomega.Succeed())
+	var update *Mechanisms
+	g.Expect(config.MechanismsUpdateChannel).To(gomega.Receive(&update))
+	g.Expect(update.RemoteMechanisms).To(gomega.HaveLen(2))
+	g.Expect(update.RemoteMechanisms[0]).To(gomega.Equal(config.Mechanisms.RemoteMechanisms[0]))
+	g.Expect(update.RemoteMechanisms[1].GetParameters()).To(gomega.Equal(map[string]string{
+		wireguard.SrcIP:        "10.0.0.1",
+		wireguard.SrcPublicKey: keys.PublicKey(),
+	}))
+	g.Expect(keys.PublicKey()).NotTo(gomega.Equal(previous))
+	// Current mechanisms are replaced once the update is sent to NSM
+	g.Expect(config.GetMechanisms().RemoteMechanisms[1].GetParameters()[wireguard.SrcPublicKey]).To(gomega.Equal(previous))
+}
+
+func TestRotateWireguardKeyMonitorMechanisms(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	keys, err := NewWireguardKeys()
+	g.Expect(err).To(gomega.BeNil())
+	config := &ForwarderConfig{
+		WireguardKeys:           keys,
+		MechanismsUpdateChannel: make(chan *Mechanisms, 1),
+		Mechanisms: &Mechanisms{
+			RemoteMechanisms: []*connection.Mechanism{
+				{
+					Type:       wireguard.MECHANISM,
+					Parameters: map[string]string{wireguard.SrcPublicKey: keys.PublicKey()},
+				},
+			},
+		},
+	}
+
+	// Mechanisms are updated the way MonitorMechanisms does while the key is rotated
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for update := range config.MechanismsUpdateChannel {
+			config.SetMechanisms(update)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		g.Expect(rotateWireguardKey(context.Background(), config)).To(gomega.Succeed())
+	}
+	close(config.MechanismsUpdateChannel)
+	<-done
+
+	g.Expect(config.GetMechanisms().RemoteMechanisms[0].GetParameters()[wireguard.SrcPublicKey]).To(gomega.Equal(keys.PublicKey()))
+}
@@ -11,17 +11,22 @@ import (
 )
 
 //KernelInterfaces creates forwarder server handler with creation dataChange config for kernel and not direct memif connections
-func KernelInterfaces(baseDir string) forwarder.ForwarderServer {
-	return &kernelInterfaces{baseDir: baseDir}
+func KernelInterfaces(baseDir string, wireguardKeys converter.WireguardKeys) forwarder.ForwarderServer {
+	return &kernelInterfaces{
+		baseDir:       baseDir,
+		wireguardKeys: wireguardKeys,
+	}
 }
 
 type kernelInterfaces struct {
-	baseDir string
+	baseDir       string
+	wireguardKeys converter.WireguardKeys
 }
 
 func (c *kernelInterfaces) Request(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error) {
 	conversionParameters := &converter.CrossConnectConversionParameters{
-		BaseDir: c.baseDir,
+		BaseDir:       c.baseDir,
+		WireguardKeys: c.wireguardKeys,
 	}
 	dataChange, err := converter.NewCrossConnectConverter(crossConnect, conversionParameters).ToDataRequest(nil, true)
 	if err != nil {
@@ -37,7 +42,8 @@ func (c *kernelInterfaces) Request(ctx context.Context, crossConnect *crossconne
 
 func (c *kernelInterfaces) Close(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*empty.Empty, error) {
 	conversionParameters := &converter.CrossConnectConversionParameters{
-		BaseDir: c.baseDir,
+		BaseDir:       c.baseDir,
+		WireguardKeys: c.wireguardKeys,
 	}
 	dataChange, err := converter.NewCrossConnectConverter(crossConnect, conversionParameters).ToDataRequest(nil, false)
 	if err != nil {
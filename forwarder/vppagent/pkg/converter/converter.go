@@ -1,15 +1,28 @@
 package converter
 
-import "go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+import (
+	"go.ligato.io/vpp-agent/v3/proto/ligato/configurator"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
 
 type Converter interface {
 	ToDataRequest(rv *configurator.Config, connect bool) (*configurator.Config, error)
 }
 
 type CrossConnectConversionParameters struct {
-	BaseDir string
+	BaseDir       string
+	WireguardKeys WireguardKeys
+}
+
+// WireguardKeys provides private keys of the forwarder by their public keys
+type WireguardKeys interface {
+	PrivateKey(publicKey string) (wgtypes.Key, error)
 }
 
+// ManagementInterface is a name of the VPP interface connected to the host network, remote connections are routed
+// through it and interfaces without addresses are made unnumbered to it
+const ManagementInterface = "mgmt"
+
 type ConnectionContextSide int
 
 const (
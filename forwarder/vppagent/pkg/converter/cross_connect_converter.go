@@ -3,7 +3,9 @@ package converter
 import (
 	"path"
 
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
 	vpp_l2 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l2"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
 
 	"github.com/pkg/errors"
 
@@ -90,6 +92,17 @@ func (c *CrossConnectConverter) ToDataRequest(rv *configurator.Config, connect b
 	// For connections mechanisms with xconnect required (For example SRv6 does not require xconnect)
 	if len(rv.VppConfig.Interfaces) == 2 {
 		ifaces := rv.VppConfig.Interfaces[len(rv.VppConfig.Interfaces)-2:]
+		if isL3Interface(ifaces[0]) || isL3Interface(ifaces[1]) {
+			// L3 tunnels could not be L2 cross connected, IP traffic is cross connected instead
+			for _, iface := range ifaces {
+				enableIP(iface)
+			}
+			rv.VppConfig.L3Xconnects = append(rv.VppConfig.L3Xconnects,
+				l3XConnects(ifaces[0].Name, ifaces[1].Name)...)
+			rv.VppConfig.L3Xconnects = append(rv.VppConfig.L3Xconnects,
+				l3XConnects(ifaces[1].Name, ifaces[0].Name)...)
+			return rv, nil
+		}
 		rv.VppConfig.XconnectPairs = append(rv.VppConfig.XconnectPairs, &vpp_l2.XConnectPair{
 			ReceiveInterface:  ifaces[0].Name,
 			TransmitInterface: ifaces[1].Name,
@@ -117,14 +130,14 @@ func (c *CrossConnectConverter) MechanismsToDataRequest(rv *configurator.Config,
 
 	var err error
 	if src := c.GetRemoteSource(); src != nil {
-		rv, err = NewRemoteConnectionConverter(src, srcName, dstName, SOURCE).ToDataRequest(rv, connect)
+		rv, err = NewRemoteConnectionConverter(src, srcName, dstName, SOURCE, c.conversionParameters.WireguardKeys).ToDataRequest(rv, connect)
 		if err != nil {
 			return rv, errors.Wrapf(err, "error Converting CrossConnect %v", c)
 		}
 	}
 
 	if dst := c.GetRemoteDestination(); dst != nil {
-		rv, err = NewRemoteConnectionConverter(dst, dstName, srcName, DESTINATION).ToDataRequest(rv, connect)
+		rv, err = NewRemoteConnectionConverter(dst, dstName, srcName, DESTINATION, c.conversionParameters.WireguardKeys).ToDataRequest(rv, connect)
 		if err != nil {
 			return rv, errors.Wrapf(err, "error Converting CrossConnect %v", c)
 		}
@@ -132,3 +145,34 @@ func (c *CrossConnectConverter) MechanismsToDataRequest(rv *configurator.Config,
 
 	return rv, nil
 }
+
+// isL3Interface returns true for interfaces carrying IP packets rather than Ethernet frames
+func isL3Interface(iface *vpp.Interface) bool {
+	return iface.GetType() == vpp_interfaces.Interface_WIREGUARD_TUNNEL
+}
+
+// enableIP makes interface without addresses accept IP traffic by borrowing the management interface address
+func enableIP(iface *vpp.Interface) {
+	if len(iface.GetIpAddresses()) == 0 && iface.GetUnnumbered() == nil {
+		iface.Unnumbered = &vpp_interfaces.Interface_Unnumbered{
+			InterfaceWithIp: ManagementInterface,
+		}
+	}
+}
+
+// l3XConnects returns IPv4 and IPv6 cross connects of the traffic received on receiveInterface to transmitInterface
+func l3XConnects(receiveInterface, transmitInterface string) []*vpp_l3.L3XConnect {
+	var rv []*vpp_l3.L3XConnect
+	for _, protocol := range []vpp_l3.L3XConnect_Protocol{vpp_l3.L3XConnect_IPV4, vpp_l3.L3XConnect_IPV6} {
+		rv = append(rv, &vpp_l3.L3XConnect{
+			Interface: receiveInterface,
+			Protocol:  protocol,
+			Paths: []*vpp_l3.L3XConnect_Path{
+				{
+					OutgoingInterface: transmitInterface,
+				},
+			},
+		})
+	}
+	return rv
+}
@@ -28,32 +28,41 @@ import (
 	"go.ligato.io/vpp-agent/v3/proto/ligato/vpp"
 	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
 	vpp_srv6 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/srv6"
+	vpp_wg "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/wireguard"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 )
 
+const (
+	// wireguardMtu leaves room for WireGuard encapsulation overhead in the default 1500 MTU
+	wireguardMtu = 1420
+)
+
 // RemoteConnectionConverter described the remote connection
 type RemoteConnectionConverter struct {
 	*connection.Connection
-	name    string
-	tapName string
-	side    ConnectionContextSide
+	name          string
+	tapName       string
+	side          ConnectionContextSide
+	wireguardKeys WireguardKeys
 }
 
 // NewRemoteConnectionConverter creates a new remote connection coverter
-func NewRemoteConnectionConverter(c *connection.Connection, name, tapName string, side ConnectionContextSide) *RemoteConnectionConverter {
+func NewRemoteConnectionConverter(c *connection.Connection, name, tapName string, side ConnectionContextSide, wireguardKeys WireguardKeys) *RemoteConnectionConverter {
 	return &RemoteConnectionConverter{
-		Connection: c,
-		name:       name,
-		tapName:    tapName,
-		side:       side,
+		Connection:    c,
+		name:          name,
+		tapName:       tapName,
+		side:          side,
+		wireguardKeys: wireguardKeys,
 	}
 }
 
 func (c *RemoteConnectionConverter) checkMechanism() bool {
-	mechanisms := []string{vxlan.MECHANISM, srv6.MECHANISM}
+	mechanisms := []string{vxlan.MECHANISM, srv6.MECHANISM, wireguard.MECHANISM}
 	for _, m := range mechanisms {
 		if m == c.GetMechanism().GetType() {
 			return true
@@ -111,6 +120,10 @@ func (c *RemoteConnectionConverter) ToDataRequest(rv *configurator.Config, conne
 				},
 			},
 		})
+	case wireguard.MECHANISM:
+		if err := c.wireguardToDataRequest(rv, connect); err != nil {
+			return rv, err
+		}
 	case srv6.MECHANISM:
 		m := srv6.ToMechanism(c.GetMechanism())
 
@@ -187,14 +200,14 @@ func (c *RemoteConnectionConverter) ToDataRequest(rv *configurator.Config, conne
 
 			rv.VppConfig.Routes = append(rv.VppConfig.Routes, &vpp.Route{
 				Type:              vpp_l3.Route_INTER_VRF,
-				OutgoingInterface: "mgmt",
+				OutgoingInterface: ManagementInterface,
 				DstNetwork:        dstHostLocalSID + "/128",
 				Weight:            1,
 				NextHopAddr:       dstHostLocalSID,
 			})
 
 			rv.VppConfig.Arps = append(rv.VppConfig.Arps, &vpp.ARPEntry{
-				Interface:   "mgmt",
+				Interface:   ManagementInterface,
 				IpAddress:   dstHostLocalSID,
 				PhysAddress: hardwareAddress,
 				Static:      true,
@@ -204,3 +217,94 @@ func (c *RemoteConnectionConverter) ToDataRequest(rv *configurator.Config, conne
 
 	return rv, nil
 }
+
+// wireguardToDataRequest adds WireGuard tunnel interface and its peer, the local side of the tunnel is the one
+// of the Connection matching the forwarder
+func (c *RemoteConnectionConverter) wireguardToDataRequest(rv *configurator.Config, connect bool) error {
+	m := wireguard.ToMechanism(c.GetMechanism())
+
+	srcIP, srcPort, srcPublicKey, err := wireguardSrcParameters(m)
+	if err != nil {
+		return err
+	}
+	dstIP, dstPort, dstPublicKey, err := wireguardDstParameters(m)
+	if err != nil {
+		return err
+	}
+	if c.side == SOURCE {
+		// If the remote Connection is SOURCE Side then local/peer parameters need to be flipped from the Connection
+		srcIP, dstIP = dstIP, srcIP
+		srcPort, dstPort = dstPort, srcPort
+		srcPublicKey, dstPublicKey = dstPublicKey, srcPublicKey
+	}
+
+	logrus.Infof("m.GetParameters()[%s]: %s", wireguard.SrcIP, srcIP)
+	logrus.Infof("m.GetParameters()[%s]: %s", wireguard.DstIP, dstIP)
+	logrus.Infof("m.GetParameters()[%s]: %d", wireguard.SrcPort, srcPort)
+	logrus.Infof("m.GetParameters()[%s]: %d", wireguard.DstPort, dstPort)
+
+	// Private key is never sent by NSM, it is kept by the forwarder that advertised the public one
+	privateKey, err := c.privateKey(srcPublicKey)
+	if err != nil && connect {
+		return err
+	}
+
+	rv.VppConfig.Interfaces = append(rv.VppConfig.Interfaces, &vpp.Interface{
+		Name:    c.name,
+		Type:    vpp_interfaces.Interface_WIREGUARD_TUNNEL,
+		Enabled: true,
+		Mtu:     wireguardMtu,
+		Unnumbered: &vpp_interfaces.Interface_Unnumbered{
+			InterfaceWithIp: ManagementInterface,
+		},
+		Link: &vpp_interfaces.Interface_Wireguard{
+			Wireguard: &vpp_interfaces.WireguardLink{
+				PrivateKey: privateKey,
+				Port:       uint32(srcPort),
+				SrcAddr:    srcIP,
+			},
+		},
+	})
+	rv.VppConfig.WgPeers = append(rv.VppConfig.WgPeers, &vpp_wg.Peer{
+		PublicKey: dstPublicKey,
+		Port:      uint32(dstPort),
+		Endpoint:  dstIP,
+		WgIfName:  c.name,
+		// The tunnel is dedicated to the single Connection, so all the traffic is allowed
+		AllowedIps: []string{"0.0.0.0/0", "::/0"},
+	})
+	return nil
+}
+
+func (c *RemoteConnectionConverter) privateKey(publicKey string) (string, error) {
+	if c.wireguardKeys == nil {
+		return "", errors.New("Wireguard keys are not configured")
+	}
+	key, err := c.wireguardKeys.PrivateKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+func wireguardSrcParameters(m wireguard.Mechanism) (ip string, port int, publicKey string, err error) {
+	if ip, err = m.SrcIP(); err != nil {
+		return
+	}
+	if port, err = m.SrcPort(); err != nil {
+		return
+	}
+	publicKey, err = m.SrcPublicKey()
+	return
+}
+
+func wireguardDstParameters(m wireguard.Mechanism) (ip string, port int, publicKey string, err error) {
+	if ip, err = m.DstIP(); err != nil {
+		return
+	}
+	if port, err = m.DstPort(); err != nil {
+		return
+	}
+	publicKey, err = m.DstPublicKey()
+	return
+}
@@ -0,0 +1,235 @@
+package converter_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	vpp_interfaces "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/interfaces"
+	vpp_l3 "go.ligato.io/vpp-agent/v3/proto/ligato/vpp/l3"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	. "github.com/networkservicemesh/networkservicemesh/forwarder/vppagent/pkg/converter"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+const (
+	wgSrcIP         = "10.0.0.1"
+	wgDstIP         = "10.0.0.2"
+	wgSrcPort       = "51821"
+	wgDstPort       = "51822"
+	wgInterfaceName = "wg-interface"
+	wgTapName       = "wg-tap"
+)
+
+// testWireguardKeys keeps private keys of both sides of the test connection, each side is converted by its own
+// forwarder in the real world
+type testWireguardKeys map[string]wgtypes.Key
+
+func (k testWireguardKeys) PrivateKey(publicKey string) (wgtypes.Key, error) {
+	key, ok := k[publicKey]
+	if !ok {
+		return wgtypes.Key{}, errors.Errorf("no private key for %s", publicKey)
+	}
+	return key, nil
+}
+
+func newTestWireguardKeys(g *WithT) (keys testWireguardKeys, srcKey, dstKey wgtypes.Key) {
+	var err error
+	srcKey, err = wgtypes.GeneratePrivateKey()
+	g.Expect(err).To(BeNil())
+	dstKey, err = wgtypes.GeneratePrivateKey()
+	g.Expect(err).To(BeNil())
+	keys = testWireguardKeys{
+		srcKey.PublicKey().String(): srcKey,
+		dstKey.PublicKey().String(): dstKey,
+	}
+	return keys, srcKey, dstKey
+}
+
+func createWireguardConnection(srcKey, dstKey wgtypes.Key) *connection.Connection {
+	return &connection.Connection{
+		Id:             connectionId,
+		NetworkService: networkService,
+		Mechanism: &connection.Mechanism{
+			Type: wireguard.MECHANISM,
+			Parameters: map[string]string{
+				wireguard.SrcIP:        wgSrcIP,
+				wireguard.DstIP:        wgDstIP,
+				wireguard.SrcPort:      wgSrcPort,
+				wireguard.DstPort:      wgDstPort,
+				wireguard.SrcPublicKey: srcKey.PublicKey().String(),
+				wireguard.DstPublicKey: dstKey.PublicKey().String(),
+			},
+		},
+		Context: createTestContext(),
+		Path: &connection.Path{
+			PathSegments: []*connection.PathSegment{
+				{Name: "nsm-1"},
+				{Name: "nsm-2"},
+			},
+		},
+	}
+}
+
+func TestWireguardDestinationSideConverter(t *testing.T) {
+	g := NewWithT(t)
+	keys, srcKey, dstKey := newTestWireguardKeys(g)
+	converter := NewRemoteConnectionConverter(createWireguardConnection(srcKey, dstKey), wgInterfaceName, wgTapName, DESTINATION, keys)
+	dataRequest, err := converter.ToDataRequest(nil, true)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(dataRequest.VppConfig.Interfaces).To(HaveLen(1))
+	intf := dataRequest.VppConfig.Interfaces[0]
+	g.Expect(intf.Name).To(Equal(wgInterfaceName))
+	g.Expect(intf.Type).To(Equal(vpp_interfaces.Interface_WIREGUARD_TUNNEL))
+	link := intf.Link.(*vpp_interfaces.Interface_Wireguard).Wireguard
+	g.Expect(link.PrivateKey).To(Equal(srcKey.String()))
+	g.Expect(link.Port).To(Equal(uint32(51821)))
+	g.Expect(link.SrcAddr).To(Equal(wgSrcIP))
+
+	g.Expect(dataRequest.VppConfig.WgPeers).To(HaveLen(1))
+	peer := dataRequest.VppConfig.WgPeers[0]
+	g.Expect(peer.WgIfName).To(Equal(wgInterfaceName))
+	g.Expect(peer.PublicKey).To(Equal(dstKey.PublicKey().String()))
+	g.Expect(peer.Port).To(Equal(uint32(51822)))
+	g.Expect(peer.Endpoint).To(Equal(wgDstIP))
+}
+
+func TestWireguardSourceSideConverter(t *testing.T) {
+	g := NewWithT(t)
+	keys, srcKey, dstKey := newTestWireguardKeys(g)
+	converter := NewRemoteConnectionConverter(createWireguardConnection(srcKey, dstKey), wgInterfaceName, wgTapName, SOURCE, keys)
+	dataRequest, err := converter.ToDataRequest(nil, true)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(dataRequest.VppConfig.Interfaces).To(HaveLen(1))
+	link := dataRequest.VppConfig.Interfaces[0].Link.(*vpp_interfaces.Interface_Wireguard).Wireguard
+	g.Expect(link.PrivateKey).To(Equal(dstKey.String()))
+	g.Expect(link.Port).To(Equal(uint32(51822)))
+	g.Expect(link.SrcAddr).To(Equal(wgDstIP))
+
+	g.Expect(dataRequest.VppConfig.WgPeers).To(HaveLen(1))
+	peer := dataRequest.VppConfig.WgPeers[0]
+	g.Expect(peer.PublicKey).To(Equal(srcKey.PublicKey().String()))
+	g.Expect(peer.Port).To(Equal(uint32(51821)))
+	g.Expect(peer.Endpoint).To(Equal(wgSrcIP))
+}
+
+func TestWireguardConverterMissingParameters(t *testing.T) {
+	g := NewWithT(t)
+	keys, srcKey, dstKey := newTestWireguardKeys(g)
+	conn := createWireguardConnection(srcKey, dstKey)
+	delete(conn.GetMechanism().GetParameters(), wireguard.DstPublicKey)
+	_, err := NewRemoteConnectionConverter(conn, wgInterfaceName, wgTapName, DESTINATION, keys).ToDataRequest(nil, true)
+	g.Expect(err).NotTo(BeNil())
+
+	conn = createWireguardConnection(srcKey, dstKey)
+	conn.GetMechanism().GetParameters()[wireguard.SrcPort] = "invalid"
+	_, err = NewRemoteConnectionConverter(conn, wgInterfaceName, wgTapName, DESTINATION, keys).ToDataRequest(nil, true)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestWireguardConverterUnknownKey(t *testing.T) {
+	g := NewWithT(t)
+	_, srcKey, dstKey := newTestWireguardKeys(g)
+	otherKeys, _, _ := newTestWireguardKeys(g)
+	conn := createWireguardConnection(srcKey, dstKey)
+
+	// Private key of another forwarder could not be used to connect
+	_, err := NewRemoteConnectionConverter(conn, wgInterfaceName, wgTapName, DESTINATION, otherKeys).ToDataRequest(nil, true)
+	g.Expect(err).NotTo(BeNil())
+
+	// Interfaces are removed by their names, so private key is not required to disconnect
+	dataRequest, err := NewRemoteConnectionConverter(conn, wgInterfaceName, wgTapName, DESTINATION, otherKeys).ToDataRequest(nil, false)
+	g.Expect(err).To(BeNil())
+	g.Expect(dataRequest.VppConfig.Interfaces).To(HaveLen(1))
+}
+
+func TestWireguardCrossConnectConverter(t *testing.T) {
+	g := NewWithT(t)
+	keys, srcKey, dstKey := newTestWireguardKeys(g)
+	xcon := &crossconnect.CrossConnect{
+		Id:             connectionId,
+		NetworkService: networkService,
+		Source:         createTestConnection(),
+		Destination:    createWireguardConnection(srcKey, dstKey),
+	}
+	converter := NewCrossConnectConverter(xcon, &CrossConnectConversionParameters{
+		BaseDir:       baseDir,
+		WireguardKeys: keys,
+	})
+	dataRequest, err := converter.ToDataRequest(nil, true)
+	g.Expect(err).To(BeNil())
+
+	g.Expect(dataRequest.VppConfig.XconnectPairs).To(BeEmpty())
+	g.Expect(dataRequest.VppConfig.L3Xconnects).To(HaveLen(4))
+	srcName, dstName := GetSrcInterfaceName(connectionId), GetDstInterfaceName(connectionId)
+	for _, xc := range dataRequest.VppConfig.L3Xconnects {
+		g.Expect([]string{srcName, dstName}).To(ContainElement(xc.Interface))
+		g.Expect(xc.Paths).To(HaveLen(1))
+		g.Expect(xc.Paths[0].OutgoingInterface).NotTo(Equal(xc.Interface))
+	}
+	g.Expect(dataRequest.VppConfig.L3Xconnects[0].Protocol).To(Equal(vpp_l3.L3XConnect_IPV4))
+	g.Expect(dataRequest.VppConfig.L3Xconnects[1].Protocol).To(Equal(vpp_l3.L3XConnect_IPV6))
+
+	os.RemoveAll(baseDir)
+}
+
+func TestWireguardKernelCrossConnectConverter(t *testing.T) {
+	g := NewWithT(t)
+	keys, srcKey, dstKey := newTestWireguardKeys(g)
+	netNsInode, err := tools.GetCurrentNS()
+	g.Expect(err).To(BeNil())
+	xcon := &crossconnect.CrossConnect{
+		Id:             connectionId,
+		NetworkService: networkService,
+		Source: &connection.Connection{
+			Id:             connectionId,
+			NetworkService: networkService,
+			Mechanism: &connection.Mechanism{
+				Type: kernel.MECHANISM,
+				Parameters: map[string]string{
+					common.NetNsInodeKey:    netNsInode,
+					common.InterfaceNameKey: "nsm0",
+				},
+			},
+			Context: createTestContext(),
+		},
+		Destination: createWireguardConnection(srcKey, dstKey),
+	}
+	converter := NewCrossConnectConverter(xcon, &CrossConnectConversionParameters{
+		BaseDir:       baseDir,
+		WireguardKeys: keys,
+	})
+	dataRequest, err := converter.ToDataRequest(nil, true)
+	g.Expect(err).To(BeNil())
+
+	// Kernel interface address is set in the client namespace, so both VPP interfaces are unnumbered
+	srcName, dstName := GetSrcInterfaceName(connectionId), GetDstInterfaceName(connectionId)
+	g.Expect(dataRequest.VppConfig.Interfaces).To(HaveLen(2))
+	for _, iface := range dataRequest.VppConfig.Interfaces {
+		g.Expect([]string{srcName, dstName}).To(ContainElement(iface.Name))
+		g.Expect(iface.IpAddresses).To(BeEmpty())
+		g.Expect(iface.Unnumbered).NotTo(BeNil())
+		g.Expect(iface.Unnumbered.InterfaceWithIp).To(Equal(ManagementInterface))
+	}
+	g.Expect(dataRequest.LinuxConfig.Interfaces).NotTo(BeEmpty())
+	g.Expect(dataRequest.LinuxConfig.Interfaces[len(dataRequest.LinuxConfig.Interfaces)-1].IpAddresses).To(Equal([]string{srcIp}))
+
+	g.Expect(dataRequest.VppConfig.XconnectPairs).To(BeEmpty())
+	g.Expect(dataRequest.VppConfig.L3Xconnects).To(HaveLen(4))
+	for _, xc := range dataRequest.VppConfig.L3Xconnects {
+		g.Expect([]string{srcName, dstName}).To(ContainElement(xc.Interface))
+		g.Expect(xc.Paths).To(HaveLen(1))
+		g.Expect(xc.Paths[0].OutgoingInterface).NotTo(Equal(xc.Interface))
+	}
+
+	os.RemoveAll(baseDir)
+}
@@ -35,9 +35,11 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/memif"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/pkg/common"
 	sdk "github.com/networkservicemesh/networkservicemesh/forwarder/sdk/vppagent"
+	"github.com/networkservicemesh/networkservicemesh/forwarder/vppagent/pkg/converter"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/vppagent/pkg/vppagent/kvschedclient"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
@@ -48,7 +50,7 @@ import (
 const (
 	VPPEndpointKey      = "VPPAGENT_ENDPOINT"
 	VPPEndpointDefault  = "localhost:9111"
-	ManagementInterface = "mgmt"
+	ManagementInterface = converter.ManagementInterface
 )
 
 type VPPAgent struct {
@@ -68,7 +70,7 @@ func (v *VPPAgent) CreateForwarderServer(config *common.ForwarderConfig) forward
 		sdk.UseCrossConnectMonitor(config.Monitor),
 		sdk.DirectMemifInterfaces(config.NSMBaseDir),
 		sdk.Connect(v.endpoint()),
-		sdk.KernelInterfaces(config.NSMBaseDir),
+		sdk.KernelInterfaces(config.NSMBaseDir, config.WireguardKeys),
 		sdk.UseEthernetContext(),
 		sdk.ClearMechanisms(config.NSMBaseDir),
 		sdk.Commit(v.downstreamResync))
@@ -79,9 +81,10 @@ func (v *VPPAgent) MonitorMechanisms(empty *empty.Empty, updateSrv forwarder.Mec
 	span := spanhelper.FromContext(context.Background(), "MonitorMecnahisms")
 	defer span.Finish()
 	span.Logger().Infof("MonitorMechanisms was called")
+	mechanisms := v.common.GetMechanisms()
 	initialUpdate := &forwarder.MechanismUpdate{
-		RemoteMechanisms: v.common.Mechanisms.RemoteMechanisms,
-		LocalMechanisms:  v.common.Mechanisms.LocalMechanisms,
+		RemoteMechanisms: mechanisms.RemoteMechanisms,
+		LocalMechanisms:  mechanisms.LocalMechanisms,
 	}
 	span.Logger().Infof("Sending MonitorMechanisms update: %v", initialUpdate)
 	if err := updateSrv.Send(initialUpdate); err != nil {
@@ -95,7 +98,7 @@ func (v *VPPAgent) MonitorMechanisms(empty *empty.Empty, updateSrv forwarder.Mec
 		// them back to NSM.
 		case update := <-v.common.MechanismsUpdateChannel:
 			updateSpan := spanhelper.FromContext(span.Context(), "Sending update")
-			v.common.Mechanisms = update
+			v.common.SetMechanisms(update)
 			updateSpan.Logger().Infof("Sending MonitorMechanisms update")
 			updateSpan.LogObject("update", update)
 			if err := updateSrv.Send(&forwarder.MechanismUpdate{
@@ -258,6 +261,7 @@ func (v *VPPAgent) programMgmtInterface() error {
 // Init makes setup for the VPPAgent
 func (v *VPPAgent) Init(common *common.ForwarderConfig) error {
 	v.common = common
+	v.common.Implementation = "vppagent"
 	err := v.configureVPPAgent()
 	if err != nil {
 		logrus.Errorf("Error configuring the VPP Agent: %s", err)
@@ -396,7 +400,7 @@ func (v *VPPAgent) configureVPPAgent() error {
 	common.CreateNSMonitor(v.common.Monitor, kvSchedulerClient.DownstreamResync)
 
 	v.common.MechanismsUpdateChannel = make(chan *common.Mechanisms, 1)
-	v.common.Mechanisms = &common.Mechanisms{
+	mechanisms := &common.Mechanisms{
 		LocalMechanisms: []*connection.Mechanism{
 			{
 				Type: memif.MECHANISM,
@@ -412,10 +416,17 @@ func (v *VPPAgent) configureVPPAgent() error {
 					vxlan.SrcIP: v.common.EgressInterface.SrcIPNet().IP.String(),
 				},
 			},
+			{
+				Type: wireguard.MECHANISM,
+				Parameters: map[string]string{
+					wireguard.SrcIP:        v.common.EgressInterface.SrcIPNet().IP.String(),
+					wireguard.SrcPublicKey: v.common.WireguardKeys.PublicKey(),
+				},
+			},
 		},
 	}
 	if v.common.EgressInterface.SrcLocalSID() != nil {
-		v.common.Mechanisms.RemoteMechanisms = append(v.common.Mechanisms.RemoteMechanisms,
+		mechanisms.RemoteMechanisms = append(mechanisms.RemoteMechanisms,
 			&connection.Mechanism{
 				Type: "SRV6",
 				Parameters: map[string]string{
@@ -425,6 +436,7 @@ func (v *VPPAgent) configureVPPAgent() error {
 				},
 			})
 	}
+	v.common.SetMechanisms(mechanisms)
 	err = v.reset()
 	if err != nil {
 		logrus.Errorf("Error resetting the VPP Agent: %s", err)
@@ -25,18 +25,28 @@ type NetworkService struct {
 }
 
 type NetworkServiceSpec struct {
-	Payload string   `json:"payload"`
-	Matches []*Match `json:"matches"`
+	Payload           string   `json:"payload"`
+	Matches           []*Match `json:"matches"`
+	SelectionStrategy string   `json:"selectionStrategy,omitempty"`
 }
 
 type Match struct {
-	SourceSelector map[string]string `json:"sourceSelector,omitempty"`
-	Routes         []*Destination    `json:"route"`
+	SourceSelector            map[string]string           `json:"sourceSelector,omitempty"`
+	Routes                    []*Destination              `json:"route"`
+	SourceSelectorExpressions []*LabelSelectorRequirement `json:"sourceSelectorExpressions,omitempty"`
 }
 
 type Destination struct {
-	DestinationSelector map[string]string `json:"destinationSelector,omitempty"`
-	Weight              uint32            `json:"weight,omitempty"`
+	DestinationSelector            map[string]string           `json:"destinationSelector,omitempty"`
+	Weight                         uint32                      `json:"weight,omitempty"`
+	DestinationSelectorExpressions []*LabelSelectorRequirement `json:"destinationSelectorExpressions,omitempty"`
+}
+
+// LabelSelectorRequirement is a set-based label requirement, Operator is one of In, NotIn, Exists, DoesNotExist
+type LabelSelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
 }
 
 type NetworkServiceStatus struct{}
@@ -34,6 +34,17 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 			(*out)[key] = val
 		}
 	}
+	if in.DestinationSelectorExpressions != nil {
+		in, out := &in.DestinationSelectorExpressions, &out.DestinationSelectorExpressions
+		*out = make([]*LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(LabelSelectorRequirement)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -47,6 +58,27 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelSelectorRequirement) DeepCopyInto(out *LabelSelectorRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelSelectorRequirement.
+func (in *LabelSelectorRequirement) DeepCopy() *LabelSelectorRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelSelectorRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Match) DeepCopyInto(out *Match) {
 	*out = *in
@@ -68,6 +100,17 @@ func (in *Match) DeepCopyInto(out *Match) {
 			}
 		}
 	}
+	if in.SourceSelectorExpressions != nil {
+		in, out := &in.SourceSelectorExpressions, &out.SourceSelectorExpressions
+		*out = make([]*LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(LabelSelectorRequirement)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
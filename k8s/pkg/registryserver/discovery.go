@@ -85,32 +85,15 @@ func FindNetworkServiceWithCache(cache RegistryCache, networkServiceName string)
 		NSMs[endpoint.Spec.NsmName] = mapNsmFromCustomResource(nsm)
 	}
 
-	var matches []*registry.Match
-
-	for _, m := range service.Spec.Matches {
-		var routes []*registry.Destination
-
-		for _, r := range m.Routes {
-			destination := &registry.Destination{
-				DestinationSelector: r.DestinationSelector,
-				Weight:              r.Weight,
-			}
-			routes = append(routes, destination)
-		}
-
-		match := &registry.Match{
-			SourceSelector: m.SourceSelector,
-			Routes:         routes,
-		}
-		matches = append(matches, match)
-	}
+	matches := mapMatchesFromCustomResource(service.Spec.Matches)
 
 	response := &registry.FindNetworkServiceResponse{
 		Payload: payload,
 		NetworkService: &registry.NetworkService{
-			Name:    service.ObjectMeta.Name,
-			Payload: service.Spec.Payload,
-			Matches: matches,
+			Name:              service.ObjectMeta.Name,
+			Payload:           service.Spec.Payload,
+			Matches:           matches,
+			SelectionStrategy: service.Spec.SelectionStrategy,
 		},
 		NetworkServiceManagers:  NSMs,
 		NetworkServiceEndpoints: NSEs,
@@ -67,3 +67,36 @@ func mapNseFromCustomResource(cr *v1.NetworkServiceEndpoint) *registry.NetworkSe
 		State:                     string(cr.Status.State),
 	}
 }
+
+func mapMatchesFromCustomResource(crMatches []*v1.Match) []*registry.Match {
+	var matches []*registry.Match
+	for _, m := range crMatches {
+		var routes []*registry.Destination
+		for _, r := range m.Routes {
+			routes = append(routes, &registry.Destination{
+				DestinationSelector:            r.DestinationSelector,
+				Weight:                         r.Weight,
+				DestinationSelectorExpressions: mapRequirementsFromCustomResource(r.DestinationSelectorExpressions),
+			})
+		}
+
+		matches = append(matches, &registry.Match{
+			SourceSelector:            m.SourceSelector,
+			Routes:                    routes,
+			SourceSelectorExpressions: mapRequirementsFromCustomResource(m.SourceSelectorExpressions),
+		})
+	}
+	return matches
+}
+
+func mapRequirementsFromCustomResource(crRequirements []*v1.LabelSelectorRequirement) []*registry.LabelSelectorRequirement {
+	var requirements []*registry.LabelSelectorRequirement
+	for _, r := range crRequirements {
+		requirements = append(requirements, &registry.LabelSelectorRequirement{
+			Key:      r.Key,
+			Operator: r.Operator,
+			Values:   r.Values,
+		})
+	}
+	return requirements
+}
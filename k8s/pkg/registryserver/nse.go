@@ -246,24 +246,9 @@ func (rs *nseRegistryService) forwardRegisterNSE(ctx context.Context, request *r
 	}
 
 	request.NetworkService.Payload = service.Spec.Payload
+	request.NetworkService.SelectionStrategy = service.Spec.SelectionStrategy
 
-	for _, m := range service.Spec.Matches {
-		var routes []*registry.Destination
-
-		for _, r := range m.Routes {
-			destination := &registry.Destination{
-				DestinationSelector: r.DestinationSelector,
-				Weight:              r.Weight,
-			}
-			routes = append(routes, destination)
-		}
-
-		match := &registry.Match{
-			SourceSelector: m.SourceSelector,
-			Routes:         routes,
-		}
-		request.NetworkService.Matches = append(request.NetworkService.Matches, match)
-	}
+	request.NetworkService.Matches = append(request.NetworkService.Matches, mapMatchesFromCustomResource(service.Spec.Matches)...)
 
 	_, err = nseRegistryClient.RegisterNSE(spanCtx, request)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/sirupsen/logrus"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	v1 "github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1alpha1"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
 	. "github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/informers/externalversions"
@@ -74,6 +75,10 @@ func (c *NetworkServiceCache) replace(resources []v1.NetworkService) {
 
 func (c *NetworkServiceCache) resourceAdded(obj interface{}) {
 	ns := obj.(*v1.NetworkService)
+	if err := validateNetworkService(ns); err != nil {
+		logrus.Errorf("Rejecting invalid NetworkService %s: %v", ns.Name, err)
+		return
+	}
 	c.networkServices[ns.Name] = ns
 }
 
@@ -85,6 +90,47 @@ func (c *NetworkServiceCache) resourceGet(key string) interface{} {
 	return c.networkServices[key]
 }
 
+// validateNetworkService checks the NetworkService selectors are valid templates and expressions
+func validateNetworkService(ns *v1.NetworkService) error {
+	for i, match := range ns.Spec.Matches {
+		if match == nil {
+			return errors.Errorf("match %d should not be nil", i)
+		}
+		if err := validateSelector(match.SourceSelector, match.SourceSelectorExpressions); err != nil {
+			return errors.Wrapf(err, "match %d source selector", i)
+		}
+		for j, route := range match.Routes {
+			if route == nil {
+				return errors.Errorf("match %d route %d should not be nil", i, j)
+			}
+			if err := validateSelector(route.DestinationSelector, route.DestinationSelectorExpressions); err != nil {
+				return errors.Wrapf(err, "match %d route %d destination selector", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSelector(selector map[string]string, requirements []*v1.LabelSelectorRequirement) error {
+	if err := registry.ValidateSelector(selector); err != nil {
+		return err
+	}
+	for _, r := range requirements {
+		if r == nil {
+			return errors.New("expression should not be nil")
+		}
+		requirement := &registry.LabelSelectorRequirement{
+			Key:      r.Key,
+			Operator: r.Operator,
+			Values:   r.Values,
+		}
+		if err := requirement.IsValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func getNsKey(obj interface{}) string {
 	return obj.(*v1.NetworkService).Name
 }
@@ -38,3 +38,56 @@ func TestNsCacheConcurrentModification(t *testing.T) {
 
 	<-time.After(time.Second)
 }
+
+func TestNsCacheRejectsInvalidSelectors(t *testing.T) {
+	g := NewWithT(t)
+
+	c := resourcecache.NewNetworkServiceCache(resourcecache.NoFilterPolicy())
+	fakeRegistry := fakeRegistry{}
+
+	stopFunc, err := c.Start(&fakeRegistry)
+	g.Expect(stopFunc).ToNot(BeNil())
+	g.Expect(err).To(BeNil())
+	defer stopFunc()
+
+	c.Add(&v1.NetworkService{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid-template"},
+		Spec: v1.NetworkServiceSpec{
+			Matches: []*v1.Match{
+				{
+					Routes: []*v1.Destination{
+						{DestinationSelector: map[string]string{"app": "{{index . \"app\""}},
+					},
+				},
+			},
+		},
+	})
+	c.Add(&v1.NetworkService{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid-expression"},
+		Spec: v1.NetworkServiceSpec{
+			Matches: []*v1.Match{
+				{
+					SourceSelectorExpressions: []*v1.LabelSelectorRequirement{
+						{Key: "app", Operator: "In"},
+					},
+				},
+			},
+		},
+	})
+	c.Add(&v1.NetworkService{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+		Spec: v1.NetworkServiceSpec{
+			Matches: []*v1.Match{
+				{
+					Routes: []*v1.Destination{
+						{DestinationSelector: map[string]string{"app": "{{index . \"app\"}}"}},
+					},
+				},
+			},
+		},
+	})
+
+	g.Expect(c.Get("valid")).ToNot(BeNil())
+	g.Expect(c.Get("invalid-template")).To(BeNil())
+	g.Expect(c.Get("invalid-expression")).To(BeNil())
+}
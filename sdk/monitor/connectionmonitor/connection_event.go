@@ -22,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/sdk/monitor"
 )
 
@@ -108,7 +109,8 @@ func connectionsFromEntities(entities map[string]monitor.Entity) (map[string]*co
 
 	for k, v := range entities {
 		if conn, ok := v.(*connection.Connection); ok {
-			connections[k] = conn
+			// Private keys are never sent to the monitor recipients
+			connections[k] = wireguard.WithoutPrivateKeys(conn)
 		} else {
 			return nil, errors.New("unable to cast Entity to connection.Connection")
 		}
@@ -3,8 +3,10 @@ package crossconnect
 import (
 	context "context"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/sdk/monitor"
 )
@@ -106,7 +108,7 @@ func xconsFromEntities(entities map[string]monitor.Entity) (map[string]*crosscon
 
 	for k, v := range entities {
 		if conn, ok := v.(*crossconnect.CrossConnect); ok {
-			xcons[k] = conn
+			xcons[k] = withoutPrivateKeys(conn)
 		} else {
 			return nil, errors.New("unable to cast Entity to CrossConnect")
 		}
@@ -115,6 +117,20 @@ func xconsFromEntities(entities map[string]monitor.Entity) (map[string]*crosscon
 	return xcons, nil
 }
 
+// withoutPrivateKeys returns cross connect without private keys of its connections, private keys are never sent to
+// the monitor recipients
+func withoutPrivateKeys(xcon *crossconnect.CrossConnect) *crossconnect.CrossConnect {
+	src := wireguard.WithoutPrivateKeys(xcon.GetSource())
+	dst := wireguard.WithoutPrivateKeys(xcon.GetDestination())
+	if src == xcon.GetSource() && dst == xcon.GetDestination() {
+		return xcon
+	}
+	rv := proto.Clone(xcon).(*crossconnect.CrossConnect)
+	rv.Source = src
+	rv.Destination = dst
+	return rv
+}
+
 func entitiesFromXcons(xcons map[string]*crossconnect.CrossConnect) map[string]monitor.Entity {
 	entities := map[string]monitor.Entity{}
 
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/sdk/monitor"
+	monitor_crossconnect "github.com/networkservicemesh/networkservicemesh/sdk/monitor/crossconnect"
+)
+
+func wireguardConnection(id string) *connection.Connection {
+	return &connection.Connection{
+		Id: id,
+		Mechanism: &connection.Mechanism{
+			Type: wireguard.MECHANISM,
+			Parameters: map[string]string{
+				wireguard.SrcPublicKey:  "src-public",
+				wireguard.SrcPrivateKey: "src-private",
+				wireguard.DstPublicKey:  "dst-public",
+				wireguard.DstPrivateKey: "dst-private",
+			},
+		},
+	}
+}
+
+func TestCrossConnectEventWithoutPrivateKeys(t *testing.T) {
+	g := NewWithT(t)
+
+	xcon := &crossconnect.CrossConnect{
+		Id:          "1",
+		Source:      wireguardConnection("1"),
+		Destination: wireguardConnection("2"),
+	}
+	event := &monitor_crossconnect.Event{
+		BaseEvent: monitor.NewBaseEvent(context.Background(), monitor.EventTypeUpdate, map[string]monitor.Entity{"1": xcon}),
+	}
+	message, err := event.Message()
+	g.Expect(err).To(BeNil())
+
+	sent := message.(*crossconnect.CrossConnectEvent).GetCrossConnects()["1"]
+	for _, conn := range []*connection.Connection{sent.GetSource(), sent.GetDestination()} {
+		g.Expect(conn.GetMechanism().GetParameters()).NotTo(HaveKey(wireguard.SrcPrivateKey))
+		g.Expect(conn.GetMechanism().GetParameters()).NotTo(HaveKey(wireguard.DstPrivateKey))
+		g.Expect(conn.GetMechanism().GetParameters()).To(HaveKeyWithValue(wireguard.SrcPublicKey, "src-public"))
+	}
+	// The monitored entity is kept unchanged
+	g.Expect(xcon.GetSource().GetMechanism().GetParameters()).To(HaveKey(wireguard.SrcPrivateKey))
+}